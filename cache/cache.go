@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"strconv"
 	"sync"
 	"time"
 )
@@ -23,12 +24,44 @@ type Cacher interface {
 	Separator() string
 }
 
+// Counter is an optional interface for Cacher implementors that support atomic counters.
+//
+// Implementors backed by distributed storage (e.g. Redis) allows counters to be shared safely between multiple
+// application instances.
+type Counter interface {
+	// Increment atomically increments the counter value of the key by delta and returns the new value.
+	//
+	// If the key does not exist, the key is created with the value of delta. ttl refreshes the expiry of the key on every
+	// call. ttl less than 1 means the key has no expiry.
+	Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+}
+
+// Increment increments the counter value of the key by delta using the Counter implementation of the Cacher if it
+// is supported. Returns the new value of the counter.
+//
+// If the Cacher does not implement Counter, Increment fallbacks to Get and Set operations which are not atomic.
+// Values that are not a valid integer are treated as 0.
+func Increment(ctx context.Context, c Cacher, key string, delta int64, ttl time.Duration) (int64, error) {
+	if counter, ok := c.(Counter); ok {
+		return counter.Increment(ctx, key, delta, ttl)
+	}
+	var value int64
+	if b, err := c.Get(ctx, key); err == nil {
+		value, _ = strconv.ParseInt(string(b), 10, 64)
+	}
+	value += delta
+	return value, c.Set(ctx, key, []byte(strconv.FormatInt(value, 10)), ttl)
+}
+
 type cacheValue struct {
 	value []byte
 	time  time.Time
 }
 
-var _ Cacher = (*LocalCache)(nil)
+var (
+	_ Cacher  = (*LocalCache)(nil)
+	_ Counter = (*LocalCache)(nil)
+)
 
 type LocalCache struct {
 	mu            *sync.RWMutex
@@ -94,6 +127,33 @@ func (m *LocalCache) Get(ctx context.Context, key string) ([]byte, error) {
 	return cache.value, nil
 }
 
+// Increment atomically increments the counter value of the key by delta and returns the new value.
+//
+// Values that are not a valid integer or have already expired are treated as 0.
+func (m *LocalCache) Increment(_ context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	if ttl < 1 {
+		ttl = math.MaxInt64
+	}
+	now := time.Now()
+	m.mu.Lock()
+	cache := m.state[key]
+	if cache == nil {
+		m.length += 1
+		cache = &cacheValue{}
+	}
+	var value int64
+	if now.Before(cache.time) {
+		value, _ = strconv.ParseInt(string(cache.value), 10, 64)
+	}
+	value += delta
+	cache.value = []byte(strconv.FormatInt(value, 10))
+	cache.time = now.Add(ttl)
+	m.state[key] = cache
+	m.mu.Unlock()
+	m.checkGC()
+	return value, nil
+}
+
 // Exist Checks if Key exist in cache.
 func (m *LocalCache) Exist(_ context.Context, key string) bool {
 	m.mu.RLock()
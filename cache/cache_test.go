@@ -51,3 +51,56 @@ func TestLocalCache(t *testing.T) {
 	cache.checkGC()
 	time.Sleep(time.Millisecond)
 }
+
+func TestLocalCache_Increment(t *testing.T) {
+	cache := NewLocalCache()
+	for i := int64(1); i <= 3; i++ {
+		v, err := cache.Increment(nil, "counter", 1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != i {
+			t.Fatalf("expected counter to be %d, got %d", i, v)
+		}
+	}
+	v, err := cache.Increment(nil, "counter", -3, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != 0 {
+		t.Fatalf("expected counter to be 0, got %d", v)
+	}
+	value, err := cache.Get(nil, "counter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != "0" {
+		t.Fatalf("expected value to be '0', got '%s'", string(value))
+	}
+
+	_, _ = cache.Increment(nil, "expired", 5, time.Nanosecond)
+	time.Sleep(time.Nanosecond)
+	v, _ = cache.Increment(nil, "expired", 1, 0)
+	if v != 1 {
+		t.Fatalf("expected expired counter to restart from 0, got %d", v)
+	}
+}
+
+type nonCounterCache struct{ *LocalCache }
+
+// Increment shadows LocalCache.Increment, so nonCounterCache does not implement Counter.
+func (n nonCounterCache) Increment() {}
+
+func TestIncrement(t *testing.T) {
+	cache := nonCounterCache{NewLocalCache()}
+	_ = cache.Set(nil, "counter", []byte("not a number"), 0)
+	for i := int64(1); i <= 3; i++ {
+		v, err := Increment(nil, cache, "counter", 1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != i {
+			t.Fatalf("expected counter to be %d, got %d", i, v)
+		}
+	}
+}
@@ -2,12 +2,32 @@ package gomemcache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/bradfitz/gomemcache/memcache"
 	"github.com/tigorlazuardi/tower/cache"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// maxRelativeExpiration is the longest expiration memcached accepts in seconds. Longer values are read as Unix
+// timestamps.
+const maxRelativeExpiration = 30 * 24 * 60 * 60
+
+// expiration converts ttl into memcached expiration. ttl less than 1 means no expiry. Sub-second ttl is rounded up to 1
+// second, since 0 means no expiry to memcached, and ttl over 30 days is converted to Unix timestamp.
+func expiration(ttl time.Duration) int32 {
+	if ttl < 1 {
+		return 0
+	}
+	seconds := int64((ttl + time.Second - 1) / time.Second)
+	if seconds > maxRelativeExpiration {
+		return int32(time.Now().Add(ttl).Unix())
+	}
+	return int32(seconds)
+}
+
 type cacher struct {
 	client *memcache.Client
 }
@@ -19,7 +39,7 @@ func (c cacher) Set(_ context.Context, key string, value []byte, ttl time.Durati
 	item := &memcache.Item{
 		Key:        key,
 		Value:      value,
-		Expiration: int32(ttl.Seconds()),
+		Expiration: expiration(ttl),
 	}
 	err := c.client.Set(item)
 	if err != nil {
@@ -46,6 +66,71 @@ func (c cacher) Delete(_ context.Context, key string) {
 	_ = c.client.Delete(key)
 }
 
+// Increment increments the counter value of the key by delta and returns the new value. ttl refreshes the expiry of the
+// key on every call with Touch.
+//
+// Memcached counters are unsigned, so negative delta, and counters that hold negative values, are updated with compare
+// and swap instead.
+func (c cacher) Increment(_ context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	if len(key) > 250 {
+		key = key[:250]
+	}
+	if delta >= 0 {
+		value, err := c.client.Increment(key, uint64(delta))
+		switch {
+		case err == nil:
+			if err := c.client.Touch(key, expiration(ttl)); err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+				return 0, fmt.Errorf("unable to refresh expiry of key '%s': %w", key, err)
+			}
+			return int64(value), nil
+		case errors.Is(err, memcache.ErrCacheMiss), strings.Contains(err.Error(), "non-numeric"):
+		default:
+			return 0, fmt.Errorf("unable to increment value of key '%s': %w", key, err)
+		}
+	}
+	return c.add(key, delta, ttl)
+}
+
+// add adds delta to the value of the key with compare and swap. The key is created with the value of delta if it does
+// not exist.
+func (c cacher) add(key string, delta int64, ttl time.Duration) (int64, error) {
+	for {
+		item, err := c.client.Get(key)
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			err = c.client.Add(&memcache.Item{
+				Key:        key,
+				Value:      []byte(strconv.FormatInt(delta, 10)),
+				Expiration: expiration(ttl),
+			})
+			if err == nil {
+				return delta, nil
+			}
+			// Another client has created the key first. Retry with its value.
+			if errors.Is(err, memcache.ErrNotStored) {
+				continue
+			}
+			return 0, fmt.Errorf("unable to create counter for key '%s': %w", key, err)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("unable to get counter of key '%s': %w", key, err)
+		}
+		// Memcached pads the value with spaces when a decrement shortens it.
+		value, _ := strconv.ParseInt(strings.TrimSpace(string(item.Value)), 10, 64)
+		value += delta
+		item.Value = []byte(strconv.FormatInt(value, 10))
+		item.Expiration = expiration(ttl)
+		err = c.client.CompareAndSwap(item)
+		if err == nil {
+			return value, nil
+		}
+		// Another client has modified or deleted the key. Retry with the latest state.
+		if errors.Is(err, memcache.ErrCASConflict) || errors.Is(err, memcache.ErrNotStored) {
+			continue
+		}
+		return 0, fmt.Errorf("unable to update counter of key '%s': %w", key, err)
+	}
+}
+
 func (c cacher) Exist(_ context.Context, key string) bool {
 	if len(key) > 250 {
 		key = key[:250]
@@ -58,6 +143,11 @@ func (c cacher) Separator() string {
 	return "::"
 }
 
+var (
+	_ cache.Cacher  = (*cacher)(nil)
+	_ cache.Counter = (*cacher)(nil)
+)
+
 func Wrap(client *memcache.Client) cache.Cacher {
 	return cacher{client: client}
 }
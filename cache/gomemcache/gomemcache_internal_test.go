@@ -0,0 +1,33 @@
+package gomemcache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpiration(t *testing.T) {
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		want int32
+	}{
+		{"no expiry", 0, 0},
+		{"negative", -time.Second, 0},
+		{"sub-second", time.Millisecond, 1},
+		{"rounded up", 1500 * time.Millisecond, 2},
+		{"whole seconds", time.Minute, 60},
+		{"30 days", 30 * 24 * time.Hour, maxRelativeExpiration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expiration(tt.ttl); got != tt.want {
+				t.Errorf("expiration() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	ttl := 31 * 24 * time.Hour
+	if got, want := int64(expiration(ttl)), time.Now().Add(ttl).Unix(); got < want-1 || got > want+1 {
+		t.Errorf("expected ttl over 30 days to be Unix timestamp %d, got %d", want, got)
+	}
+}
@@ -10,6 +10,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/tigorlazuardi/tower/cache"
 )
 
 func createClient() (*memcache.Client, func(), error) {
@@ -88,3 +91,42 @@ func TestGoMemcache(t *testing.T) {
 		t.Fatal("expected error when key is not exist")
 	}
 }
+
+func TestGoMemcache_Increment(t *testing.T) {
+	client, cleanup, err := createClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	counter := gomemcache.Wrap(client).(cache.Counter)
+	ctx := context.Background()
+	steps := []struct {
+		delta int64
+		want  int64
+	}{{-2, -2}, {5, 3}, {10, 13}, {-12, 1}, {-3, -2}}
+	for _, step := range steps {
+		got, err := counter.Increment(ctx, "counter", step.delta, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != step.want {
+			t.Fatalf("Increment(%d) = %d, want %d", step.delta, got, step.want)
+		}
+	}
+
+	// Memcached expires keys in whole seconds, so the ttl of 1.5 seconds becomes 1 to 2 seconds.
+	for i := 0; i < 2; i++ {
+		if _, err := counter.Increment(ctx, "expiring", 1, 1500*time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(900 * time.Millisecond)
+	}
+	if got, _ := counter.Increment(ctx, "expiring", 1, 1500*time.Millisecond); got != 3 {
+		t.Fatalf("expected the expiry to be refreshed, got %d", got)
+	}
+	time.Sleep(3100 * time.Millisecond)
+	if _, err := client.Get("expiring"); err == nil {
+		t.Fatal("expected the counter to expire")
+	}
+}
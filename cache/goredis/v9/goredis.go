@@ -8,6 +8,11 @@ import (
 	"github.com/tigorlazuardi/tower/cache"
 )
 
+var (
+	_ cache.Cacher  = (*goredis)(nil)
+	_ cache.Counter = (*goredis)(nil)
+)
+
 func Wrap(client *redis.Client) cache.Cacher {
 	return &goredis{client: client}
 }
@@ -32,6 +37,24 @@ func (goredis *goredis) Delete(ctx context.Context, key string) {
 	goredis.client.Del(ctx, key)
 }
 
+// Increment atomically increments the counter value of the key by delta and returns the new value.
+//
+// The increment and the expiry refresh are sent in a single transaction, so the counter is safe to be shared between
+// multiple application instances. The expiry is set in milliseconds.
+func (goredis *goredis) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	pipe := goredis.client.TxPipeline()
+	incr := pipe.IncrBy(ctx, key, delta)
+	if ttl > 0 {
+		pipe.PExpire(ctx, key, ttl)
+	} else {
+		pipe.Persist(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
 // Exist Checks if Key exist in cache.
 func (goredis *goredis) Exist(ctx context.Context, key string) bool {
 	return goredis.client.Exists(ctx, key).Val() > 0
@@ -6,10 +6,12 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-redis/redis/v9"
 	"github.com/ory/dockertest/v3"
 	"github.com/ory/dockertest/v3/docker"
+	"github.com/tigorlazuardi/tower/cache"
 	"github.com/tigorlazuardi/tower/cache/goredis/v9"
 )
 
@@ -85,3 +87,23 @@ func TestGoRedisV9(t *testing.T) {
 		t.Fatal("expected error when key is not exist")
 	}
 }
+
+func TestGoRedis_Increment(t *testing.T) {
+	client, cleanup, err := createClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	counter := goredis.Wrap(client).(cache.Counter)
+	ctx := context.Background()
+	if got, err := counter.Increment(ctx, "counter", -2, 1500*time.Millisecond); err != nil || got != -2 {
+		t.Fatalf("expected -2, got %d, %v", got, err)
+	}
+	if got, err := counter.Increment(ctx, "counter", 5, 1500*time.Millisecond); err != nil || got != 3 {
+		t.Fatalf("expected 3, got %d, %v", got, err)
+	}
+	if ttl := client.PTTL(ctx, "counter").Val(); ttl <= time.Second || ttl > 1500*time.Millisecond {
+		t.Fatalf("expected the expiry to be kept in milliseconds, got %s", ttl)
+	}
+}
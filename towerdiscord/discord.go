@@ -44,6 +44,7 @@ type Discord struct {
 	hook             Hook
 	dataEncoder      DataEncoder
 	codeBlockBuilder CodeBlockBuilder
+	repeatMode       RepeatMode
 }
 
 // NewDiscordBot creates a new discord bot.
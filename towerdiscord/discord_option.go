@@ -92,3 +92,10 @@ func WithClient(client Client) DiscordOption {
 		discord.client = client
 	})
 }
+
+// WithRepeatMode sets how messages with the same key are posted after their cooldown ends. Defaults to RepeatNewMessage.
+func WithRepeatMode(mode RepeatMode) DiscordOption {
+	return discordOptionFunc(func(discord *Discord) {
+		discord.repeatMode = mode
+	})
+}
@@ -17,6 +17,16 @@ type ExtraInformation struct {
 	CooldownTimeEnds time.Time
 	CacheKey         string
 	ThreadID         snowflake.ID
+	// Occurrences is the number of times messages with the same key were suppressed by cooldown since the previous
+	// message was posted.
+	Occurrences int64
+	// OccurrencesSince is the time when the previous message with the same key was posted.
+	// Zero value if there is no record of previous message.
+	OccurrencesSince time.Time
+	// Reference points to the first message posted with the same key.
+	//
+	// Nil if the message is the first message of the key, or repeat mode is set to RepeatNewMessage.
+	Reference *MessageReference
 }
 
 type EmbedBuilderFunc func(ctx context.Context, msg tower.MessageContext, info *ExtraInformation) ([]*Embed, []bucket.File)
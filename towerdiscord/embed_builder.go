@@ -308,6 +308,23 @@ func (d Discord) buildMetadataEmbed(ctx context.Context, msg tower.MessageContex
 		Inline: true,
 	})
 	count += len(messageIteration) + len(iteration)
+	if extra.Occurrences > 0 {
+		const occurrences = "Occurrences"
+		value := fmt.Sprintf("occurred %d times", extra.Occurrences)
+		if extra.Occurrences == 1 {
+			value = "occurred 1 time"
+		}
+		if !extra.OccurrencesSince.IsZero() {
+			since := extra.OccurrencesSince.Unix()
+			value += fmt.Sprintf(" since <t:%d:F> | <t:%d:R>", since, since)
+		}
+		embed.Fields = append(embed.Fields, &EmbedField{
+			Name:   occurrences,
+			Value:  value,
+			Inline: false,
+		})
+		count += len(occurrences) + len(value)
+	}
 	ts := extra.CooldownTimeEnds.Unix()
 	const nextPossibleEarliestRepeat = "Next Possible Earliest Repeat"
 	repeatValue := fmt.Sprintf("<t:%d:F> | <t:%d:R>", ts, ts)
@@ -0,0 +1,80 @@
+package towerdiscord
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/cache"
+)
+
+// occurrenceTTL returns the lifetime of occurrence records of a message with the given cooldown.
+//
+// Occurrences are counted while the message is in cooldown and read when the next message is posted after the cooldown
+// ends. The expiry of the counter is refreshed on every count, so twice the cooldown keeps the records alive until the
+// next message.
+func occurrenceTTL(cooldown time.Duration) time.Duration {
+	return cooldown * 2
+}
+
+func (d Discord) occurrenceCountKey(key string) string {
+	return key + d.cache.Separator() + "occurrences"
+}
+
+func (d Discord) occurrenceSinceKey(key string) string {
+	return key + d.cache.Separator() + "since"
+}
+
+// countOccurrence records a message that is suppressed by cooldown for the given duration.
+func (d Discord) countOccurrence(ctx context.Context, msg tower.MessageContext, key string, suppressed time.Duration) {
+	countKey := d.occurrenceCountKey(key)
+	if _, err := cache.Increment(ctx, d.cache, countKey, 1, occurrenceTTL(suppressed)); err != nil {
+		_ = msg.Tower().
+			Wrap(err).
+			Message("%s: failed to increment message occurrences in cache", d.Name()).
+			Caller(msg.Caller()).
+			Context(tower.F{"key": countKey}).
+			Log(ctx)
+	}
+}
+
+// getOccurrence fills the occurrences information of the key to extra.
+func (d Discord) getOccurrence(ctx context.Context, key string, extra *ExtraInformation) {
+	if b, err := d.cache.Get(ctx, d.occurrenceCountKey(key)); err == nil {
+		extra.Occurrences, _ = strconv.ParseInt(string(b), 10, 64)
+	}
+	if b, err := d.cache.Get(ctx, d.occurrenceSinceKey(key)); err == nil {
+		extra.OccurrencesSince, _ = time.Parse(time.RFC3339Nano, string(b))
+	}
+}
+
+// resetOccurrence subtracts the reported occurrences from the counter and marks the current time as the starting point
+// of the next occurrences.
+//
+// The counter is decremented instead of deleted, so occurrences counted by other instances while the message is being
+// posted are not lost.
+func (d Discord) resetOccurrence(ctx context.Context, msg tower.MessageContext, key string, extra *ExtraInformation) {
+	ttl := occurrenceTTL(time.Until(extra.CooldownTimeEnds))
+	if extra.Occurrences > 0 {
+		countKey := d.occurrenceCountKey(key)
+		if _, err := cache.Increment(ctx, d.cache, countKey, -extra.Occurrences, ttl); err != nil {
+			_ = msg.Tower().
+				Wrap(err).
+				Message("%s: failed to reset message occurrences in cache", d.Name()).
+				Caller(msg.Caller()).
+				Context(tower.F{"key": countKey}).
+				Log(ctx)
+		}
+	}
+	sinceKey := d.occurrenceSinceKey(key)
+	since := []byte(time.Now().Format(time.RFC3339Nano))
+	if err := d.cache.Set(ctx, sinceKey, since, ttl); err != nil {
+		_ = msg.Tower().
+			Wrap(err).
+			Message("%s: failed to set message occurrences time to cache", d.Name()).
+			Caller(msg.Caller()).
+			Context(tower.F{"key": sinceKey}).
+			Log(ctx)
+	}
+}
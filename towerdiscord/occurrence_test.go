@@ -0,0 +1,97 @@
+package towerdiscord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/cache"
+)
+
+type captureMessenger chan tower.MessageContext
+
+func (c captureMessenger) Name() string { return "capture" }
+
+func (c captureMessenger) SendMessage(_ context.Context, msg tower.MessageContext) { c <- msg }
+
+func (c captureMessenger) Wait(context.Context) error { return nil }
+
+func TestDiscord_occurrence(t *testing.T) {
+	tow := tower.NewTower(tower.Service{Name: "test", Environment: "test", Type: "test"})
+	capture := make(captureMessenger, 1)
+	ctx := context.Background()
+	tow.NewEntry("foo").Key("occurrence").Notify(ctx, tower.OnlyThisMessenger(capture))
+	msg := <-capture
+
+	d := NewDiscordBot("")
+	key := d.buildKey(msg)
+
+	extra := &ExtraInformation{CooldownTimeEnds: time.Now().Add(time.Minute)}
+	d.getOccurrence(ctx, key, extra)
+	if extra.Occurrences != 0 {
+		t.Fatalf("expected occurrences to be 0, got %d", extra.Occurrences)
+	}
+	if !extra.OccurrencesSince.IsZero() {
+		t.Fatalf("expected occurrences since to be zero, got %s", extra.OccurrencesSince)
+	}
+
+	before := time.Now()
+	d.resetOccurrence(ctx, msg, key, extra)
+	for i := 0; i < 3; i++ {
+		d.countOccurrence(ctx, msg, key, time.Minute)
+	}
+	d.getOccurrence(ctx, key, extra)
+	if extra.Occurrences != 3 {
+		t.Fatalf("expected occurrences to be 3, got %d", extra.Occurrences)
+	}
+	if extra.OccurrencesSince.Before(before) {
+		t.Fatalf("expected occurrences since to be after %s, got %s", before, extra.OccurrencesSince)
+	}
+
+	// occurrence that happens while the message is being posted must not be lost.
+	d.countOccurrence(ctx, msg, key, time.Minute)
+	d.resetOccurrence(ctx, msg, key, extra)
+	extra = &ExtraInformation{}
+	d.getOccurrence(ctx, key, extra)
+	if extra.Occurrences != 1 {
+		t.Fatalf("expected occurrences to be 1, got %d", extra.Occurrences)
+	}
+}
+
+// ttlRecorder records the ttl of the last write of every key.
+type ttlRecorder struct {
+	*cache.LocalCache
+	ttls map[string]time.Duration
+}
+
+func (r ttlRecorder) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	r.ttls[key] = ttl
+	return r.LocalCache.Set(ctx, key, value, ttl)
+}
+
+func (r ttlRecorder) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	r.ttls[key] = ttl
+	return r.LocalCache.Increment(ctx, key, delta, ttl)
+}
+
+func TestDiscord_occurrenceTTL(t *testing.T) {
+	tow := tower.NewTower(tower.Service{Name: "test", Environment: "test", Type: "test"})
+	capture := make(captureMessenger, 1)
+	ctx := context.Background()
+	tow.NewEntry("foo").Key("occurrence-ttl").Notify(ctx, tower.OnlyThisMessenger(capture))
+	msg := <-capture
+
+	recorder := ttlRecorder{LocalCache: cache.NewLocalCache(), ttls: map[string]time.Duration{}}
+	d := NewDiscordBot("", WithCache(recorder))
+	key := d.buildKey(msg)
+
+	d.countOccurrence(ctx, msg, key, time.Minute*10)
+	if ttl := recorder.ttls[d.occurrenceCountKey(key)]; ttl != time.Minute*20 {
+		t.Errorf("expected occurrences count ttl to be twice the suppressed duration, got %s", ttl)
+	}
+	d.resetOccurrence(ctx, msg, key, &ExtraInformation{Occurrences: 1, CooldownTimeEnds: time.Now().Add(time.Hour * 30)})
+	if ttl := recorder.ttls[d.occurrenceSinceKey(key)]; ttl < time.Hour*30 {
+		t.Errorf("expected occurrences since ttl to outlive the cooldown, got %s", ttl)
+	}
+}
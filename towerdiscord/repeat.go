@@ -0,0 +1,133 @@
+package towerdiscord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/bwmarrin/snowflake"
+	"github.com/tigorlazuardi/tower"
+)
+
+// RepeatMode sets how Discord handles messages whose key has been posted before.
+type RepeatMode uint8
+
+const (
+	// RepeatNewMessage posts every repeat as a new message. This is the default.
+	RepeatNewMessage RepeatMode = iota
+	// RepeatInThread posts repeats as replies in the thread of the first message.
+	//
+	// Discord only allows webhooks to create threads in forum channels, so this mode requires the webhook to point to a
+	// forum channel. The first message creates a new post in the forum, and the repeats are posted into that post.
+	RepeatInThread
+	// RepeatEditOriginal edits the first message with the latest message and occurrence counter instead of posting a
+	// new message.
+	RepeatEditOriginal
+)
+
+func (r RepeatMode) String() string {
+	switch r {
+	case RepeatNewMessage:
+		return "new_message"
+	case RepeatInThread:
+		return "in_thread"
+	case RepeatEditOriginal:
+		return "edit_original"
+	default:
+		return "unknown"
+	}
+}
+
+// MessageReference points to the first message posted for a key. It's stored in the cache along with the key.
+type MessageReference struct {
+	MessageID snowflake.ID `json:"message_id"`
+	ChannelID snowflake.ID `json:"channel_id"`
+	// ThreadID is the thread where repeats are posted to. Zero if repeats are not posted in a thread.
+	ThreadID snowflake.ID `json:"thread_id,omitempty"`
+	// Count is the total number of occurrences of the key since the first message, including suppressed ones.
+	Count int64 `json:"count"`
+	// FirstSeen is the time when the first message was posted.
+	FirstSeen time.Time `json:"first_seen"`
+}
+
+// referenceTTL is the lifetime of message references since the last message.
+const referenceTTL = time.Hour * 24
+
+func (d Discord) referenceKey(key string) string {
+	return key + d.cache.Separator() + "reference"
+}
+
+// getMessageReference gets the reference to the first message of the key. Returns nil if the repeat mode is
+// RepeatNewMessage or there is no reference in the cache.
+func (d Discord) getMessageReference(ctx context.Context, key string) *MessageReference {
+	if d.repeatMode == RepeatNewMessage {
+		return nil
+	}
+	b, err := d.cache.Get(ctx, d.referenceKey(key))
+	if err != nil {
+		return nil
+	}
+	ref := &MessageReference{}
+	if err := json.Unmarshal(b, ref); err != nil {
+		return nil
+	}
+	return ref
+}
+
+// saveMessageReference stores the reference to the first message of the key. posted is the message Discord responded
+// with, and is used to create new reference if there is no reference yet.
+func (d Discord) saveMessageReference(ctx context.Context, msg tower.MessageContext, key string, extra *ExtraInformation, posted *WebhookMessage) {
+	if d.repeatMode == RepeatNewMessage {
+		return
+	}
+	ref := extra.Reference
+	if ref == nil {
+		if posted == nil {
+			return
+		}
+		ref = &MessageReference{
+			MessageID: posted.ID,
+			ChannelID: posted.ChannelID,
+			Count:     1 + extra.Occurrences,
+			FirstSeen: time.Now(),
+		}
+		if d.repeatMode == RepeatInThread {
+			// Messages that create a thread in forum channel have the channel id set to the thread id.
+			ref.ThreadID = posted.ChannelID
+		}
+	}
+	referenceKey := d.referenceKey(key)
+	b, _ := json.Marshal(ref)
+	if err := d.cache.Set(ctx, referenceKey, b, referenceTTL); err != nil {
+		_ = msg.Tower().
+			Wrap(err).
+			Message("%s: failed to set message reference to cache", d.Name()).
+			Caller(msg.Caller()).
+			Context(tower.F{"key": referenceKey, "payload": json.RawMessage(b)}).
+			Log(ctx)
+	}
+}
+
+// isReferenceGone checks if the error is caused by the referenced message or thread no longer exist.
+func isReferenceGone(err error) bool {
+	var errResp *DiscordErrorResponse
+	return errors.As(err, &errResp) && errResp.StatusCode == http.StatusNotFound
+}
+
+func buildThreadName(msg tower.MessageContext) string {
+	// Discord limits thread name to 100 characters.
+	const limit = 100
+	name := msg.Message()
+	if name == "" && msg.Err() != nil {
+		name = msg.Err().Error()
+	}
+	if name == "" {
+		name = msg.Caller().ShortName()
+	}
+	if r := []rune(name); len(r) > limit {
+		name = string(r[:limit-3]) + "..."
+	}
+	return name
+}
@@ -0,0 +1,196 @@
+package towerdiscord
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/bwmarrin/snowflake"
+	"github.com/tigorlazuardi/tower"
+)
+
+func TestDiscord_webhookURL(t *testing.T) {
+	d := NewDiscordBot("https://discord.com/api/webhooks/123/token")
+	tests := []struct {
+		name      string
+		messageID int64
+		payload   *WebhookPayload
+		want      string
+	}{
+		{
+			name:    "execute",
+			payload: &WebhookPayload{Wait: true},
+			want:    "https://discord.com/api/webhooks/123/token?wait=true",
+		},
+		{
+			name:    "execute in thread",
+			payload: &WebhookPayload{Wait: true, ThreadID: 456},
+			want:    "https://discord.com/api/webhooks/123/token?thread_id=456&wait=true",
+		},
+		{
+			name:      "edit message in thread",
+			messageID: 789,
+			payload:   &WebhookPayload{Wait: true, ThreadID: 456},
+			want:      "https://discord.com/api/webhooks/123/token/messages/789?thread_id=456",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.webhookURL(snowflakeID(tt.messageID), tt.payload); got != tt.want {
+				t.Errorf("webhookURL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+type recordedRequest struct {
+	method string
+	path   string
+	query  string
+	body   map[string]any
+}
+
+func newRecordingWebhookServer(t *testing.T, status int) (*httptest.Server, func() []recordedRequest) {
+	mu := &sync.Mutex{}
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body := map[string]any{}
+		_ = json.Unmarshal(b, &body)
+		mu.Lock()
+		requests = append(requests, recordedRequest{method: r.Method, path: r.URL.Path, query: r.URL.RawQuery, body: body})
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status >= 400 {
+			_, _ = io.WriteString(w, `{"code": 10008, "message": "Unknown Message"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id": "1000", "channel_id": "2000"}`)
+	}))
+	return server, func() []recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func captureMessage(t *testing.T) tower.MessageContext {
+	tow := tower.NewTower(tower.Service{Name: "test", Environment: "test", Type: "test"})
+	capture := make(captureMessenger, 1)
+	tow.NewEntry("foo").Key("repeat").Notify(context.Background(), tower.OnlyThisMessenger(capture))
+	return <-capture
+}
+
+func TestDiscord_RepeatInThread(t *testing.T) {
+	server, requests := newRecordingWebhookServer(t, http.StatusOK)
+	defer server.Close()
+	ctx := context.Background()
+	msg := captureMessage(t)
+	d := NewDiscordBot(server.URL+"/webhooks/123/token", WithRepeatMode(RepeatInThread))
+	key := d.buildKey(msg)
+
+	for i := 0; i < 2; i++ {
+		extra := &ExtraInformation{CacheKey: key, Iteration: i + 1}
+		extra.Reference = d.getMessageReference(ctx, key)
+		posted, err := d.postMessage(ctx, msg, extra)
+		if err != nil {
+			t.Fatalf("postMessage() error = %v", err)
+		}
+		d.saveMessageReference(ctx, msg, key, extra, posted)
+	}
+
+	reqs := requests()
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(reqs))
+	}
+	if reqs[0].body["thread_name"] != "foo" {
+		t.Errorf("expected first message to create thread named 'foo', got %v", reqs[0].body["thread_name"])
+	}
+	if reqs[0].query != "wait=true" {
+		t.Errorf("expected first message query to be 'wait=true', got %s", reqs[0].query)
+	}
+	if _, ok := reqs[1].body["thread_name"]; ok {
+		t.Errorf("expected repeat to not create a new thread")
+	}
+	if reqs[1].query != "thread_id=2000&wait=true" {
+		t.Errorf("expected repeat to be posted in thread 2000, got query %s", reqs[1].query)
+	}
+}
+
+func TestDiscord_RepeatEditOriginal(t *testing.T) {
+	server, requests := newRecordingWebhookServer(t, http.StatusOK)
+	defer server.Close()
+	ctx := context.Background()
+	msg := captureMessage(t)
+	d := NewDiscordBot(server.URL+"/webhooks/123/token", WithRepeatMode(RepeatEditOriginal))
+	key := d.buildKey(msg)
+
+	for i := 0; i < 3; i++ {
+		extra := &ExtraInformation{CacheKey: key, Iteration: i + 1}
+		extra.Reference = d.getMessageReference(ctx, key)
+		if extra.Reference != nil {
+			extra.Reference.Count += 1
+		}
+		posted, err := d.postMessage(ctx, msg, extra)
+		if err != nil {
+			t.Fatalf("postMessage() error = %v", err)
+		}
+		d.saveMessageReference(ctx, msg, key, extra, posted)
+	}
+
+	reqs := requests()
+	if len(reqs) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(reqs))
+	}
+	if reqs[0].method != http.MethodPost {
+		t.Errorf("expected first message to be posted, got method %s", reqs[0].method)
+	}
+	for _, req := range reqs[1:] {
+		if req.method != http.MethodPatch {
+			t.Errorf("expected repeat to edit the original message, got method %s", req.method)
+		}
+		if req.path != "/webhooks/123/token/messages/1000" {
+			t.Errorf("expected repeat to edit message 1000, got path %s", req.path)
+		}
+	}
+	ref := d.getMessageReference(ctx, key)
+	if ref == nil {
+		t.Fatal("expected message reference to be stored in cache")
+	}
+	if ref.Count != 3 {
+		t.Errorf("expected reference count to be 3, got %d", ref.Count)
+	}
+}
+
+func TestDiscord_RepeatEditOriginal_Deleted(t *testing.T) {
+	server, requests := newRecordingWebhookServer(t, http.StatusNotFound)
+	defer server.Close()
+	ctx := context.Background()
+	msg := captureMessage(t)
+	d := NewDiscordBot(server.URL+"/webhooks/123/token", WithRepeatMode(RepeatEditOriginal))
+
+	extra := &ExtraInformation{Reference: &MessageReference{MessageID: 1000, ChannelID: 2000, Count: 2}}
+	_, err := d.postMessage(ctx, msg, extra)
+	if err == nil {
+		t.Fatal("expected error from server")
+	}
+	reqs := requests()
+	if len(reqs) != 2 {
+		t.Fatalf("expected edit to fallback to new message, got %d requests", len(reqs))
+	}
+	if reqs[1].method != http.MethodPost {
+		t.Errorf("expected fallback to post new message, got method %s", reqs[1].method)
+	}
+	if extra.Reference != nil {
+		t.Error("expected reference to be removed")
+	}
+}
+
+func snowflakeID(i int64) snowflake.ID {
+	return snowflake.ID(i)
+}
@@ -2,6 +2,8 @@ package towerdiscord
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -23,22 +25,30 @@ func (d Discord) send(ctx context.Context, msg tower.MessageContext) {
 	}
 	if msg.SkipVerification() {
 		extra.CooldownTimeEnds = time.Now().Add(time.Second * 2)
-		_ = d.postMessage(ctx, msg, extra)
+		_, _ = d.postMessage(ctx, msg, extra)
 		d.deleteGlobalCacheKeyAfter2Seconds(ctx)
 		return
 	}
+	iterKey := key + d.cache.Separator() + "iter"
 	if d.cache.Exist(ctx, key) {
+		d.countOccurrence(ctx, msg, key, d.countCooldown(msg, d.getIter(ctx, iterKey)))
 		d.cache.Delete(ctx, d.globalKey)
 		return
 	}
 	defer d.deleteGlobalCacheKeyAfter2Seconds(ctx)
-	iterKey := key + d.cache.Separator() + "iter"
 	iter := d.getAndSetIter(ctx, iterKey)
 	cooldown := d.countCooldown(msg, iter)
 	extra.Iteration = iter
 	extra.CooldownTimeEnds = time.Now().Add(cooldown)
-	err := d.postMessage(ctx, msg, extra)
+	d.getOccurrence(ctx, key, extra)
+	extra.Reference = d.getMessageReference(ctx, key)
+	if extra.Reference != nil {
+		extra.Reference.Count += 1 + extra.Occurrences
+	}
+	posted, err := d.postMessage(ctx, msg, extra)
 	if err == nil {
+		d.resetOccurrence(ctx, msg, key, extra)
+		d.saveMessageReference(ctx, msg, key, extra, posted)
 		message := msg.Message()
 		if msg.Err() != nil {
 			message = msg.Err().Error()
@@ -84,20 +94,31 @@ func buildIntro(service tower.Service, err error) string {
 	return s.String()
 }
 
-func (d Discord) postMessage(ctx context.Context, msg tower.MessageContext, extra *ExtraInformation) error {
+// postMessage posts the message to Discord. Returns the message Discord responded with, which may be nil if Discord
+// responds with empty body.
+func (d Discord) postMessage(ctx context.Context, msg tower.MessageContext, extra *ExtraInformation) (*WebhookMessage, error) {
 	service := msg.Service()
 	err := msg.Err()
 	intro := buildIntro(service, err)
 	if extra.ThreadID == 0 {
 		extra.ThreadID = d.snowflake.Generate()
 	}
+	ref := extra.Reference
+	if d.repeatMode == RepeatEditOriginal && ref != nil {
+		intro += fmt.Sprintf("\nOccurred **%d** times since <t:%d:F>. Last updated <t:%d:R>", ref.Count, ref.FirstSeen.Unix(), time.Now().Unix())
+	}
 
 	embeds, files := d.builder.BuildEmbed(ctx, msg, extra)
 	payload := &WebhookPayload{
-		Wait:     true,
-		ThreadID: extra.ThreadID,
-		Content:  intro,
-		Embeds:   embeds,
+		Wait:    true,
+		Content: intro,
+		Embeds:  embeds,
+	}
+	switch {
+	case d.repeatMode == RepeatInThread && ref != nil:
+		payload.ThreadID = ref.ThreadID
+	case d.repeatMode == RepeatInThread:
+		payload.ThreadName = buildThreadName(msg)
 	}
 
 	webhookContext := &WebhookContext{
@@ -107,23 +128,44 @@ func (d Discord) postMessage(ctx context.Context, msg tower.MessageContext, extr
 		Extra:   extra,
 	}
 
+	edit := d.repeatMode == RepeatEditOriginal && ref != nil
 	switch {
 	case d.bucket != nil && len(files) > 0:
 		payload, errUpload := d.bucketUpload(ctx, webhookContext)
 		webhookContext.Payload = payload
-		err := d.PostWebhookJSON(ctx, webhookContext)
-		switch {
-		case err != nil:
-			return err
-		case errUpload != nil:
-			return errUpload
-		default:
-			return nil
+		if edit {
+			err = d.EditWebhookMessageJSON(ctx, ref.MessageID, webhookContext)
+		} else {
+			err = d.PostWebhookJSON(ctx, webhookContext)
+		}
+		if err == nil {
+			err = errUpload
 		}
+	case len(files) > 0 && edit:
+		err = d.EditWebhookMessageMultipart(ctx, ref.MessageID, webhookContext)
 	case len(files) > 0:
-		return d.PostWebhookMultipart(ctx, webhookContext)
+		err = d.PostWebhookMultipart(ctx, webhookContext)
+	case edit:
+		err = d.EditWebhookMessageJSON(ctx, ref.MessageID, webhookContext)
+	default:
+		err = d.PostWebhookJSON(ctx, webhookContext)
+	}
+	if ref != nil && isReferenceGone(err) {
+		// The original message or thread is deleted. Start over as the first message of the key.
+		extra.Reference = nil
+		return d.postMessage(ctx, msg, extra)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(webhookContext.ResponseBody) == 0 {
+		return nil, nil
+	}
+	posted := &WebhookMessage{}
+	if err := json.Unmarshal(webhookContext.ResponseBody, posted); err != nil {
+		return nil, nil //nolint:nilerr // the message is already posted, only the reference is lost.
 	}
-	return d.PostWebhookJSON(ctx, webhookContext)
+	return posted, nil
 }
 
 func (d Discord) bucketUpload(ctx context.Context, web *WebhookContext) (*WebhookPayload, error) {
@@ -198,14 +240,18 @@ func (d Discord) countCooldown(msg tower.MessageContext, iter int) time.Duration
 	return cooldown
 }
 
-func (d Discord) getAndSetIter(ctx context.Context, key string) int {
+// getIter returns the iteration of the last posted message of the key.
+func (d Discord) getIter(ctx context.Context, key string) int {
 	var iter int
-	iterByte, err := d.cache.Get(ctx, key)
-	if err == nil {
+	if iterByte, err := d.cache.Get(ctx, key); err == nil {
 		iter, _ = strconv.Atoi(string(iterByte))
 	}
-	iter += 1
-	iterByte = []byte(strconv.Itoa(iter))
+	return iter
+}
+
+func (d Discord) getAndSetIter(ctx context.Context, key string) int {
+	iter := d.getIter(ctx, key) + 1
+	iterByte := []byte(strconv.Itoa(iter))
 	nextCooldown := d.cooldown*time.Duration(iter) + d.cooldown
 	_ = d.cache.Set(ctx, key, iterByte, nextCooldown)
 	return iter
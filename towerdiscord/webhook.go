@@ -12,11 +12,15 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"net/url"
+	"strings"
 )
 
 type WebhookPayload struct {
-	Wait            bool             `json:"-"`
-	ThreadID        snowflake.ID     `json:"-"`
+	Wait     bool         `json:"-"`
+	ThreadID snowflake.ID `json:"-"`
+	// ThreadName creates a new thread with this name. Only supported when the webhook's channel is a forum channel.
+	ThreadName      string           `json:"thread_name,omitempty"`
 	Content         string           `json:"content,omitempty"`
 	Username        string           `json:"username,omitempty"`
 	AvatarURL       string           `json:"avatarURL,omitempty"`
@@ -31,6 +35,9 @@ func (w *WebhookPayload) BuildMultipartPayloadJSON() ([]byte, error) {
 	if w.Content != "" {
 		fields["content"] = w.Content
 	}
+	if w.ThreadName != "" {
+		fields["thread_name"] = w.ThreadName
+	}
 	if w.Username != "" {
 		fields["username"] = w.Username
 	}
@@ -106,6 +113,14 @@ type Attachment struct {
 	Ephemeral   bool   `json:"ephemeral,omitempty"`
 }
 
+// WebhookMessage is the message object Discord returns when webhook is executed or a webhook message is edited.
+//
+// Only the fields towerdiscord needs are declared.
+type WebhookMessage struct {
+	ID        snowflake.ID `json:"id"`
+	ChannelID snowflake.ID `json:"channel_id"`
+}
+
 type WebhookContext struct {
 	Message tower.MessageContext
 	Files   []bucket.File
@@ -121,7 +136,28 @@ type WebhookContext struct {
 	Response *http.Response
 }
 
+// PostWebhookJSON executes the webhook with JSON payload.
 func (d Discord) PostWebhookJSON(ctx context.Context, web *WebhookContext) error {
+	return d.executeWebhookJSON(ctx, http.MethodPost, 0, web)
+}
+
+// PostWebhookMultipart executes the webhook with multipart payload. Used when the message has file attachments.
+func (d Discord) PostWebhookMultipart(ctx context.Context, web *WebhookContext) error {
+	return d.executeWebhookMultipart(ctx, http.MethodPost, 0, web)
+}
+
+// EditWebhookMessageJSON edits a message previously sent by the webhook with JSON payload.
+func (d Discord) EditWebhookMessageJSON(ctx context.Context, messageID snowflake.ID, web *WebhookContext) error {
+	return d.executeWebhookJSON(ctx, http.MethodPatch, messageID, web)
+}
+
+// EditWebhookMessageMultipart edits a message previously sent by the webhook with multipart payload.
+// Used when the message has file attachments.
+func (d Discord) EditWebhookMessageMultipart(ctx context.Context, messageID snowflake.ID, web *WebhookContext) error {
+	return d.executeWebhookMultipart(ctx, http.MethodPatch, messageID, web)
+}
+
+func (d Discord) executeWebhookJSON(ctx context.Context, method string, messageID snowflake.ID, web *WebhookContext) error {
 	ctx = d.hook.PreMessageHook(ctx, web)
 	var out bytes.Buffer
 	enc := json.NewEncoder(&out)
@@ -129,62 +165,48 @@ func (d Discord) PostWebhookJSON(ctx context.Context, web *WebhookContext) error
 	if err := enc.Encode(web.Payload); err != nil {
 		return fmt.Errorf("failed to encode webhook payload: %w", err)
 	}
-	b := out.Bytes()
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.webhook, bytes.NewReader(b))
+	req, err := http.NewRequestWithContext(ctx, method, d.webhookURL(messageID, web.Payload), &out)
 	if err != nil {
 		return fmt.Errorf("failed to create webhook request: %w", err)
 	}
-	if web.Payload.Wait {
-		req.URL.Query().Add("wait", "true")
-	}
-	req.URL.Query().Add("thread_id", web.Payload.ThreadID.String())
 	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := d.client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to execute webhook: %w", err)
-	}
-	defer func(Body io.ReadCloser) {
-		_ = Body.Close()
-	}(resp.Body)
-	web.Response = resp
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		d.hook.PostMessageHook(ctx, web, err)
-		return fmt.Errorf("failed to read webhook response body: %w", err)
-	}
-	web.ResponseBody = body
-	if resp.StatusCode >= 400 {
-		errResp, err := newDiscordErrorResponse(resp.StatusCode, body)
-		if err != nil {
-			d.hook.PostMessageHook(ctx, web, err)
-			return fmt.Errorf("failed to parse discord error response: %w", err)
-		}
-		errResp.PrintJSON()
-		d.hook.PostMessageHook(ctx, web, errResp)
-		return errResp
-	}
-	d.hook.PostMessageHook(ctx, web, err)
-
-	return nil
+	return d.doWebhookRequest(ctx, req, web)
 }
 
-func (d Discord) PostWebhookMultipart(ctx context.Context, web *WebhookContext) error {
+func (d Discord) executeWebhookMultipart(ctx context.Context, method string, messageID snowflake.ID, web *WebhookContext) error {
 	ctx = d.hook.PreMessageHook(ctx, web)
 	requestBody, contentType, err := d.buildMultipartWebhookBody(web)
 	if err != nil {
 		return fmt.Errorf("failed to build multipart webhook body: %w", err)
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.webhook, requestBody)
+	req, err := http.NewRequestWithContext(ctx, method, d.webhookURL(messageID, web.Payload), requestBody)
 	if err != nil {
 		return fmt.Errorf("failed to create webhook request: %w", err)
 	}
-	if web.Payload.Wait {
-		req.URL.Query().Add("wait", "true")
-	}
-	req.URL.Query().Add("thread_id", web.Payload.ThreadID.String())
 	req.Header.Set("Content-Type", contentType)
+	return d.doWebhookRequest(ctx, req, web)
+}
 
+// webhookURL builds the url to execute the webhook. If messageID is not zero, the url points to the message instead.
+func (d Discord) webhookURL(messageID snowflake.ID, payload *WebhookPayload) string {
+	u, err := url.Parse(d.webhook)
+	if err != nil {
+		return d.webhook
+	}
+	query := u.Query()
+	if messageID != 0 {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/messages/" + messageID.String()
+	} else if payload.Wait {
+		query.Set("wait", "true")
+	}
+	if payload.ThreadID != 0 {
+		query.Set("thread_id", payload.ThreadID.String())
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func (d Discord) doWebhookRequest(ctx context.Context, req *http.Request, web *WebhookContext) error {
 	resp, err := d.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute webhook: %w", err)
@@ -192,11 +214,13 @@ func (d Discord) PostWebhookMultipart(ctx context.Context, web *WebhookContext)
 	defer func(Body io.ReadCloser) {
 		_ = Body.Close()
 	}(resp.Body)
+	web.Response = resp
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		d.hook.PostMessageHook(ctx, web, err)
 		return fmt.Errorf("failed to read webhook response body: %w", err)
 	}
+	web.ResponseBody = body
 	if resp.StatusCode >= 400 {
 		errResp, err := newDiscordErrorResponse(resp.StatusCode, body)
 		if err != nil {
@@ -0,0 +1,245 @@
+package towerhttp
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+type accessLog struct {
+	tower              *tower.Tower
+	caller             tower.Caller
+	filter             FilterRequest
+	readRequestLimit   int
+	readResponseLimit  int
+	filterRequestBody  FilterRequest
+	filterResponseBody FilterRespond
+	level              AccessLogLevelFunc
+	rules              LogRules
+}
+
+// AccessLog creates a middleware that logs every request and response passing through it, including the ones not
+// written by the Responder, like static files, reverse proxies, and third party handlers.
+//
+// The log entry has the same fields as the logger hook (see NewLoggerHook), with the addition of number of bytes
+// written in the response fields. The entry is logged using the Responder's tower instance.
+//
+// The http.ResponseWriter given to the next handler implements http.Flusher, http.Hijacker, and http.Pusher only when
+// the underlying http.ResponseWriter does, so feature detection with type assertions keeps working. It also supports
+// io.ReaderFrom, and http.ResponseController through the Unwrap method.
+//
+// Do not use this middleware alongside the logger hook, or requests handled by the Responder will be logged twice.
+func (r Responder) AccessLog(opts ...AccessLogOption) Middleware {
+	a := &accessLog{
+		tower:             r.tower,
+		caller:            tower.GetCaller(2),
+		readRequestLimit:  1024 * 1024,
+		readResponseLimit: 1024 * 1024,
+		filterRequestBody: func(r *http.Request) bool {
+			return isHumanReadable(r.Header.Get("Content-Type"))
+		},
+		filterResponseBody: func(respondContentType string, r *http.Request) bool {
+			return isHumanReadable(respondContentType)
+		},
+		level: defaultAccessLogLevel,
+	}
+	for _, opt := range opts {
+		opt.apply(a)
+	}
+	return a.middleware
+}
+
+func (a *accessLog) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, request *http.Request) {
+		if a.filter != nil && !a.filter(request) {
+			next.ServeHTTP(rw, request)
+			return
+		}
+		start := time.Now()
+		request = request.WithContext(contextWithRequestStart(request.Context(), start))
+		rule := a.rules.Find(request)
+		requestLimit, responseLimit := a.readRequestLimit, a.readResponseLimit
+		if rule != nil && rule.hasRequestLimit {
+			requestLimit = rule.requestLimit
+		}
+		if rule != nil && rule.hasResponseLimit {
+			responseLimit = rule.responseLimit
+		}
+		var requestBody ClonedBody = NoopCloneBody{}
+		if b, ok := request.Body.(ClonedBody); ok {
+			requestBody = b
+		} else if c := clonedBodyFromContext(request.Context()); c != nil {
+			requestBody = c
+		} else if request.Body != nil && request.Body != http.NoBody && requestLimit != 0 && a.filterRequestBody(request) {
+			cloner := wrapBodyCloner(request.Body, requestLimit)
+			request.Body = cloner
+			request = request.WithContext(contextWithClonedBody(request.Context(), cloner))
+			requestBody = cloner
+		}
+		w := &accessLogWriter{
+			ResponseWriter: rw,
+			request:        request,
+			limit:          responseLimit,
+			filter:         a.filterResponseBody,
+		}
+		defer func() {
+			p := recover()
+			if p != nil && !w.wroteHeader && !w.hijacked {
+				w.wroteHeader = true
+				w.status = http.StatusInternalServerError
+			}
+			latency := time.Since(start)
+			if rule == nil || rule.ShouldLog(w.Status(), latency) {
+				a.log(request, requestBody, w, start, latency)
+			}
+			if p != nil {
+				panic(p)
+			}
+		}()
+		next.ServeHTTP(wrapResponseWriter(w), request)
+	})
+}
+
+func (a *accessLog) log(request *http.Request, requestBody ClonedBody, w *accessLogWriter, start time.Time, latency time.Duration) {
+	status := w.Status()
+	var body []byte
+	if w.clone != nil {
+		body = w.clone.Bytes()
+	}
+	hook := &baseHook{
+		Request:        request,
+		RequestBody:    requestBody,
+		ResponseStatus: status,
+		ResponseHeader: w.Header(),
+		Tower:          a.tower,
+		Timing:         &RespondTiming{Start: start, Total: latency},
+	}
+	fields := buildLoggerFields(hook, body, w.Truncated())
+	if response, ok := fields["response"].(tower.F); ok {
+		response["bytes"] = w.written
+	}
+	message := fmt.Sprintf("%s %s %s", request.Method, request.URL.String(), request.Proto)
+	a.tower.NewEntry(message).
+		Level(a.level(status)).
+		Code(status).
+		Caller(a.caller).
+		Context(fields).
+		Log(request.Context())
+}
+
+var _ featureWriter = (*accessLogWriter)(nil)
+
+// accessLogWriter records the status, the number of bytes written, and a bounded clone of the body written to the
+// http.ResponseWriter.
+type accessLogWriter struct {
+	http.ResponseWriter
+	request     *http.Request
+	status      int
+	wroteHeader bool
+	hijacked    bool
+	written     int64
+	clone       *bytes.Buffer
+	limit       int
+	filter      FilterRespond
+}
+
+// Status returns the status code sent to the client.
+func (w *accessLogWriter) Status() int {
+	switch {
+	case w.wroteHeader:
+		return w.status
+	case w.hijacked:
+		return http.StatusSwitchingProtocols
+	}
+	return http.StatusOK
+}
+
+// Truncated returns true if the body written is larger than the clone limit.
+func (w *accessLogWriter) Truncated() bool {
+	return w.clone != nil && w.limit > 0 && w.written > int64(w.limit)
+}
+
+func (w *accessLogWriter) WriteHeader(code int) {
+	// Informational responses except 101 may be followed by the final response.
+	if w.wroteHeader || (code < http.StatusOK && code != http.StatusSwitchingProtocols) {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.wroteHeader = true
+	w.status = code
+	if w.limit != 0 && w.filter(w.Header().Get("Content-Type"), w.request) {
+		w.clone = &bytes.Buffer{}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *accessLogWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.record(b[:n])
+	return n, err
+}
+
+func (w *accessLogWriter) record(b []byte) {
+	w.written += int64(len(b))
+	if w.clone == nil {
+		return
+	}
+	if w.limit < 0 {
+		_, _ = w.clone.Write(b)
+		return
+	}
+	if remaining := w.limit - w.clone.Len(); remaining > 0 {
+		if len(b) > remaining {
+			b = b[:remaining]
+		}
+		_, _ = w.clone.Write(b)
+	}
+}
+
+// ReadFrom keeps the optimization of the underlying io.ReaderFrom, like sendfile for static files, when the body is not
+// cloned.
+func (w *accessLogWriter) ReadFrom(src io.Reader) (int64, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if rf, ok := w.ResponseWriter.(io.ReaderFrom); ok && w.clone == nil {
+		n, err := rf.ReadFrom(src)
+		w.written += n
+		return n, err
+	}
+	// Wraps the writer so io.Copy does not call ReadFrom recursively.
+	return io.Copy(struct{ io.Writer }{w}, src)
+}
+
+func (w *accessLogWriter) flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.ResponseWriter.(http.Flusher).Flush()
+}
+
+func (w *accessLogWriter) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := w.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil {
+		w.hijacked = true
+	}
+	return conn, rw, err
+}
+
+func (w *accessLogWriter) push(target string, opts *http.PushOptions) error {
+	return w.ResponseWriter.(http.Pusher).Push(target, opts)
+}
+
+// Unwrap returns the underlying http.ResponseWriter. Used by http.ResponseController.
+func (w *accessLogWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,102 @@
+package towerhttp
+
+import (
+	"net/http"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+type AccessLogOption interface {
+	apply(*accessLog)
+}
+
+type (
+	AccessLogOptionBuilder []AccessLogOption
+	accessLogOptionFunc    func(*accessLog)
+)
+
+func (a accessLogOptionFunc) apply(log *accessLog) {
+	a(log)
+}
+
+func (a AccessLogOptionBuilder) apply(log *accessLog) {
+	for _, v := range a {
+		v.apply(log)
+	}
+}
+
+// AccessLogLevelFunc decides the level of the access log entry based on the response status.
+type AccessLogLevelFunc = func(status int) tower.Level
+
+// Filter filters requests to be logged. Return false to skip logging the request. Defaults to log all requests.
+func (a AccessLogOptionBuilder) Filter(filter FilterRequest) AccessLogOptionBuilder {
+	return append(a, accessLogOptionFunc(func(log *accessLog) {
+		log.filter = filter
+	}))
+}
+
+// ReadRequestBodyLimit limits the number of bytes of request body being cloned. Defaults to 1MB.
+//
+// Negative value will make the middleware clones all the body.
+//
+// Body will not be read if FilterRequestBody returns false.
+func (a AccessLogOptionBuilder) ReadRequestBodyLimit(limit int) AccessLogOptionBuilder {
+	return append(a, accessLogOptionFunc(func(log *accessLog) {
+		log.readRequestLimit = limit
+	}))
+}
+
+// ReadResponseBodyLimit limits the number of bytes of response body being cloned. Defaults to 1MB.
+//
+// Negative value will make the middleware clones all the body.
+//
+// Body will not be cloned if FilterResponseBody returns false.
+func (a AccessLogOptionBuilder) ReadResponseBodyLimit(limit int) AccessLogOptionBuilder {
+	return append(a, accessLogOptionFunc(func(log *accessLog) {
+		log.readResponseLimit = limit
+	}))
+}
+
+// FilterRequestBody filter requests whose body are going to be cloned. Defaults to filter only human readable content
+// type.
+func (a AccessLogOptionBuilder) FilterRequestBody(filter FilterRequest) AccessLogOptionBuilder {
+	return append(a, accessLogOptionFunc(func(log *accessLog) {
+		log.filterRequestBody = filter
+	}))
+}
+
+// FilterResponseBody filter responses whose body are going to be cloned. The content type is taken from the response
+// header when the handler writes the status code. Defaults to filter only human readable content type.
+func (a AccessLogOptionBuilder) FilterResponseBody(filter FilterRespond) AccessLogOptionBuilder {
+	return append(a, accessLogOptionFunc(func(log *accessLog) {
+		log.filterResponseBody = filter
+	}))
+}
+
+// Rules sets the rules to decide whether a request is logged, and how much of the bodies are cloned per route. The
+// first rule that matches the request is used. Requests that match no rule are always logged.
+//
+// See LogRule for details.
+func (a AccessLogOptionBuilder) Rules(rules ...LogRule) AccessLogOptionBuilder {
+	return append(a, accessLogOptionFunc(func(log *accessLog) {
+		log.rules = append(log.rules, rules...)
+	}))
+}
+
+// Level sets the level of the log entry based on the response status. Defaults to tower.ErrorLevel for 5xx,
+// tower.WarnLevel for 4xx, and tower.InfoLevel for the rest.
+func (a AccessLogOptionBuilder) Level(level AccessLogLevelFunc) AccessLogOptionBuilder {
+	return append(a, accessLogOptionFunc(func(log *accessLog) {
+		log.level = level
+	}))
+}
+
+func defaultAccessLogLevel(status int) tower.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return tower.ErrorLevel
+	case status >= http.StatusBadRequest:
+		return tower.WarnLevel
+	}
+	return tower.InfoLevel
+}
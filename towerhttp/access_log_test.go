@@ -0,0 +1,132 @@
+package towerhttp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kinbiko/jsonassert"
+	"github.com/tigorlazuardi/tower"
+)
+
+func TestResponder_AccessLog(t *testing.T) {
+	logger := tower.NewTestingJSONLogger()
+	tow := tower.NewTower(tower.Service{Name: "test", Environment: "test", Type: "test"})
+	tow.SetLogger(logger)
+	responder := NewResponder()
+	responder.SetTower(tow)
+
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(rw, `{"received":`+string(body)+`}`)
+	})
+	middleware := responder.AccessLog(Option.AccessLog().ReadResponseBodyLimit(13))
+	request := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"x"}`))
+	request.Header.Set("Content-Type", "application/json")
+	middleware(handler).ServeHTTP(httptest.NewRecorder(), request)
+
+	jsonassert.New(t).Assertf(logger.String(), `
+	{
+		"time": "<<PRESENCE>>",
+		"code": 201,
+		"message": "POST /users HTTP/1.1",
+		"caller": "<<PRESENCE>>",
+		"level": "info",
+		"service": "<<PRESENCE>>",
+		"context": {
+			"request": {
+				"method": "POST",
+				"url": "example.com/users",
+				"headers": {"Content-Type": ["application/json"]},
+				"body": {"name": "x"}
+			},
+			"response": {
+				"status": 201,
+				"headers": {"Content-Type": ["application/json"]},
+				"body": "{\"received\":{ (truncated)",
+				"bytes": 25
+			},
+			"duration_ms": "<<PRESENCE>>"
+		}
+	}`)
+	if !strings.Contains(logger.String(), "access_log_test.go") {
+		t.Errorf("expected caller to point to where the middleware is created, got %s", logger.String())
+	}
+}
+
+func TestResponder_AccessLog_Hijack(t *testing.T) {
+	logger := tower.NewTestingJSONLogger()
+	tow := tower.NewTower(tower.Service{Name: "test", Environment: "test", Type: "test"})
+	tow.SetLogger(logger)
+	responder := NewResponder()
+	responder.SetTower(tow)
+
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if _, ok := rw.(http.Flusher); !ok {
+			t.Error("expected writer to implement http.Flusher")
+		}
+		conn, buf, err := rw.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		_, _ = buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: test\r\nConnection: Upgrade\r\n\r\n")
+		_ = buf.Flush()
+	})
+	server := httptest.NewServer(responder.AccessLog()(handler))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "test")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected status 101, got %d", resp.StatusCode)
+	}
+	// The entry is logged after the handler returns, which may happen after the client receives the response.
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(logger.String(), `"code":101`) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected hijacked request to be logged with status 101, got %s", logger.String())
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func TestResponder_AccessLog_UnsupportedInterfaces(t *testing.T) {
+	responder := NewResponder()
+	responder.SetTower(tower.NewTower(tower.Service{Name: "test", Environment: "test", Type: "test"}))
+
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if _, ok := rw.(http.Flusher); ok {
+			t.Error("expected writer to not implement http.Flusher")
+		}
+		if _, ok := rw.(http.Hijacker); ok {
+			t.Error("expected writer to not implement http.Hijacker")
+		}
+		if _, ok := rw.(http.Pusher); ok {
+			t.Error("expected writer to not implement http.Pusher")
+		}
+		if err := http.NewResponseController(rw).Flush(); err == nil {
+			t.Error("expected response controller to report flush as not supported")
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	rec := httptest.NewRecorder()
+	// Hides the Flush method of the recorder.
+	plain := struct{ http.ResponseWriter }{rec}
+	responder.AccessLog()(handler).ServeHTTP(plain, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+	}
+}
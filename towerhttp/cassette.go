@@ -0,0 +1,468 @@
+package towerhttp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+// ErrCassetteNoMatch is the cause of the error returned by Cassette in strict mode when no recorded interaction matches
+// the request.
+var ErrCassetteNoMatch = errors.New("no recorded interaction matches the request")
+
+// CassetteMode decides whether Cassette replays recorded interactions or records new ones.
+type CassetteMode int8
+
+const (
+	// CassetteReplay replays recorded interactions. The cassette file must exist. Unmatched requests are sent with the
+	// inner http.RoundTripper without being recorded, or rejected in strict mode.
+	CassetteReplay CassetteMode = iota
+	// CassetteRecord sends every request with the inner http.RoundTripper and records it, replacing the existing
+	// cassette file on Save.
+	CassetteRecord
+	// CassetteReplayOrRecord replays recorded interactions and records the unmatched requests. The cassette file is
+	// created if it does not exist.
+	CassetteReplayOrRecord
+)
+
+func (c CassetteMode) String() string {
+	switch c {
+	case CassetteReplay:
+		return "replay"
+	case CassetteRecord:
+		return "record"
+	case CassetteReplayOrRecord:
+		return "replay_or_record"
+	}
+	return "unknown"
+}
+
+// CassetteInteraction is a recorded request and response pair.
+type CassetteInteraction struct {
+	Request    CassetteRequest  `json:"request"`
+	Response   CassetteResponse `json:"response"`
+	RecordedAt time.Time        `json:"recorded_at"`
+}
+
+// CassetteRequest is the recorded request.
+type CassetteRequest struct {
+	Method string       `json:"method"`
+	URL    string       `json:"url"`
+	Header http.Header  `json:"header,omitempty"`
+	Body   CassetteBody `json:"body,omitempty"`
+}
+
+// CassetteResponse is the recorded response.
+type CassetteResponse struct {
+	StatusCode int          `json:"status_code"`
+	Header     http.Header  `json:"header,omitempty"`
+	Body       CassetteBody `json:"body,omitempty"`
+}
+
+// CassetteBody is a recorded body. It is stored as string if it is valid UTF-8, otherwise as base64 encoded object, so
+// the cassette file stays readable and diffable.
+type CassetteBody []byte
+
+func (c CassetteBody) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(c) {
+		return json.Marshal(string(c))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(c)})
+}
+
+func (c *CassetteBody) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*c = CassetteBody(s)
+		return nil
+	}
+	var encoded struct {
+		Base64 string `json:"base64"`
+	}
+	if err := json.Unmarshal(b, &encoded); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded.Base64)
+	if err != nil {
+		return err
+	}
+	*c = decoded
+	return nil
+}
+
+type (
+	// CassetteMatcher decides whether the recorded request matches the request. body is the whole request body.
+	CassetteMatcher = func(req *http.Request, body []byte, recorded CassetteRequest) bool
+	// CassetteRedactFunc modifies the interaction before it is stored, e.g. to remove tokens from the URL.
+	CassetteRedactFunc = func(*CassetteInteraction)
+)
+
+// MatchMethod matches requests with the same method.
+func MatchMethod(req *http.Request, _ []byte, recorded CassetteRequest) bool {
+	return req.Method == recorded.Method
+}
+
+// MatchURL matches requests with the same URL. Passwords in the URL are redacted before comparison.
+func MatchURL(req *http.Request, _ []byte, recorded CassetteRequest) bool {
+	return redactedURL(req.URL) == recorded.URL
+}
+
+// MatchBody matches requests with the same body. JSON bodies are compared by value, so formatting and key order do not
+// matter.
+func MatchBody(_ *http.Request, body []byte, recorded CassetteRequest) bool {
+	if bytes.Equal(body, recorded.Body) {
+		return true
+	}
+	if !isJson(body) || !isJson(recorded.Body) {
+		return false
+	}
+	var a, b any
+	if json.Unmarshal(body, &a) != nil || json.Unmarshal(recorded.Body, &b) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	return bytes.Equal(ja, jb)
+}
+
+// MatchAll combines the matchers. The request matches if all the matchers match.
+func MatchAll(matchers ...CassetteMatcher) CassetteMatcher {
+	return func(req *http.Request, body []byte, recorded CassetteRequest) bool {
+		for _, match := range matchers {
+			if !match(req, body, recorded) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+const cassetteRedacted = "[REDACTED]"
+
+// Cassette is an http.RoundTripper that records the traffic into a cassette file and replays it, so tests that depend
+// on external services can run offline and deterministically.
+//
+// Recorded headers that may hold secrets (Authorization, Proxy-Authorization, Cookie, Set-Cookie, X-Api-Key, and those
+// added with RedactHeaders CassetteOption) are replaced with "[REDACTED]", and passwords in the URL are removed. Use
+// Redact CassetteOption to remove other secrets, such as webhook tokens in the URL path.
+//
+// In replay, every request is matched against the recorded interactions with the matcher, by default method and URL.
+// Interactions are replayed in recorded order, and the last matching interaction is replayed again once every matching
+// interaction is used.
+//
+// Call Save after the test to write the recorded interactions to the cassette file:
+//
+//	cassette, err := towerhttp.NewCassette("testdata/slack.json", towerhttp.Option.Cassette().Mode(towerhttp.CassetteReplayOrRecord))
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	t.Cleanup(func() { _ = cassette.Save() })
+//	client := &http.Client{Transport: cassette}
+type Cassette struct {
+	path          string
+	inner         http.RoundTripper
+	tower         *tower.Tower
+	mode          CassetteMode
+	strict        bool
+	matcher       CassetteMatcher
+	redactHeaders []string
+	redact        []CassetteRedactFunc
+
+	mu           sync.Mutex
+	interactions []*CassetteInteraction
+	used         []bool
+	recorded     bool
+}
+
+// NewCassette creates a Cassette backed by the file in path. The inner http.RoundTripper defaults to
+// http.DefaultTransport.
+//
+// Returns error if the mode is CassetteReplay and the file does not exist, or if the file is not a valid cassette.
+func NewCassette(path string, opts ...CassetteOption) (*Cassette, error) {
+	c := &Cassette{
+		path:          path,
+		inner:         http.DefaultTransport,
+		tower:         tower.Global.Tower(),
+		matcher:       MatchAll(MatchMethod, MatchURL),
+		redactHeaders: []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"},
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	if c.mode == CassetteRecord {
+		return c, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && c.mode == CassetteReplayOrRecord {
+			return c, nil
+		}
+		return nil, c.tower.Wrap(err).Message("failed to read cassette %s", path).Freeze()
+	}
+	if err := json.Unmarshal(b, &c.interactions); err != nil {
+		return nil, c.tower.Wrap(err).Message("failed to decode cassette %s", path).Freeze()
+	}
+	c.used = make([]bool, len(c.interactions))
+	return c, nil
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	// http.RoundTripper must not modify the request, so the body is buffered on a clone.
+	req = req.Clone(req.Context())
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if c.mode != CassetteRecord {
+		if interaction := c.match(req, body); interaction != nil {
+			return interaction.Response.toResponse(req), nil
+		}
+		if c.mode == CassetteReplay {
+			if c.strict {
+				return nil, c.tower.Wrap(ErrCassetteNoMatch).
+					Code(http.StatusNotImplemented).
+					Message("cassette %s has no interaction for %s %s", c.path, req.Method, redactedURL(req.URL)).
+					// The body may contain secrets, so only its size is logged.
+					Context(tower.F{"method": req.Method, "url": redactedURL(req.URL), "body_size": len(body)}).
+					Freeze()
+			}
+			return c.inner.RoundTrip(req)
+		}
+	}
+
+	res, err := c.inner.RoundTrip(req)
+	if err != nil {
+		return res, err
+	}
+	interaction := &CassetteInteraction{
+		Request: CassetteRequest{
+			Method: req.Method,
+			URL:    redactedURL(req.URL),
+			Header: req.Header.Clone(),
+			Body:   body,
+		},
+		Response: CassetteResponse{
+			StatusCode: res.StatusCode,
+			Header:     res.Header.Clone(),
+		},
+		RecordedAt: time.Now().UTC(),
+	}
+	// Bodies without content may never be closed by the caller, so they are recorded right away.
+	if res.Body == nil || res.Body == http.NoBody || res.ContentLength == 0 {
+		c.record(interaction)
+		return res, nil
+	}
+	cloner := wrapBodyCloner(res.Body, -1)
+	cloner.onClose(func(error) {
+		interaction.Response.Body = cloner.CloneBytes()
+		c.record(interaction)
+	})
+	res.Body = cloner
+	return res, nil
+}
+
+// Save writes the interactions to the cassette file. It does nothing if no interaction is recorded.
+//
+// Interactions are recorded when the response body is closed, or when the response is received if it has no body.
+func (c *Cassette) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.recorded {
+		return nil
+	}
+	b, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		return c.tower.Wrap(err).Message("failed to encode cassette %s", c.path).Freeze()
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return c.tower.Wrap(err).Message("failed to create directory of cassette %s", c.path).Freeze()
+	}
+	if err := os.WriteFile(c.path, append(b, '\n'), 0o644); err != nil {
+		return c.tower.Wrap(err).Message("failed to write cassette %s", c.path).Freeze()
+	}
+	return nil
+}
+
+// Interactions returns a copy of the interactions in the cassette.
+func (c *Cassette) Interactions() []CassetteInteraction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]CassetteInteraction, len(c.interactions))
+	for i, v := range c.interactions {
+		out[i] = *v
+	}
+	return out
+}
+
+// Unused returns the recorded interactions that are never replayed. Useful to assert that the test sends every
+// expected request.
+func (c *Cassette) Unused() []CassetteInteraction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []CassetteInteraction
+	for i, v := range c.interactions {
+		if !c.used[i] {
+			out = append(out, *v)
+		}
+	}
+	return out
+}
+
+func (c *Cassette) match(req *http.Request, body []byte) *CassetteInteraction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	last := -1
+	for i, interaction := range c.interactions {
+		if !c.matcher(req, body, interaction.Request) {
+			continue
+		}
+		if !c.used[i] {
+			c.used[i] = true
+			return interaction
+		}
+		last = i
+	}
+	if last >= 0 {
+		return c.interactions[last]
+	}
+	return nil
+}
+
+func (c *Cassette) record(interaction *CassetteInteraction) {
+	for _, name := range c.redactHeaders {
+		redactHeader(interaction.Request.Header, name)
+		redactHeader(interaction.Response.Header, name)
+	}
+	for _, redact := range c.redact {
+		redact(interaction)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, interaction)
+	// Recorded interactions are marked as used, so Unused does not report them. They are still replayed as the last
+	// matching interaction, so repeating the request in CassetteReplayOrRecord replays the recorded response instead of
+	// recording it again.
+	c.used = append(c.used, true)
+	c.recorded = true
+}
+
+func redactHeader(header http.Header, name string) {
+	if values := header.Values(name); len(values) > 0 {
+		header.Set(name, cassetteRedacted)
+	}
+}
+
+// readRequestBody reads the whole request body and replaces it with a fresh reader over the read bytes.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	b, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
+
+func (c CassetteResponse) toResponse(req *http.Request) *http.Response {
+	header := c.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(c.StatusCode) + " " + http.StatusText(c.StatusCode),
+		StatusCode:    c.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
+
+type CassetteOption interface {
+	apply(*Cassette)
+}
+
+type (
+	CassetteOptionBuilder []CassetteOption
+	cassetteOptionFunc    func(*Cassette)
+)
+
+func (c cassetteOptionFunc) apply(cassette *Cassette) {
+	c(cassette)
+}
+
+func (c CassetteOptionBuilder) apply(cassette *Cassette) {
+	for _, v := range c {
+		v.apply(cassette)
+	}
+}
+
+// Mode sets whether the cassette replays or records. Defaults to CassetteReplay.
+func (c CassetteOptionBuilder) Mode(mode CassetteMode) CassetteOptionBuilder {
+	return append(c, cassetteOptionFunc(func(cassette *Cassette) {
+		cassette.mode = mode
+	}))
+}
+
+// Strict rejects unmatched requests in CassetteReplay mode with error caused by ErrCassetteNoMatch, instead of sending
+// them with the inner http.RoundTripper.
+func (c CassetteOptionBuilder) Strict() CassetteOptionBuilder {
+	return append(c, cassetteOptionFunc(func(cassette *Cassette) {
+		cassette.strict = true
+	}))
+}
+
+// Matcher sets how requests are matched against the recorded interactions. Defaults to MatchAll(MatchMethod, MatchURL).
+func (c CassetteOptionBuilder) Matcher(matcher CassetteMatcher) CassetteOptionBuilder {
+	return append(c, cassetteOptionFunc(func(cassette *Cassette) {
+		cassette.matcher = matcher
+	}))
+}
+
+// Transport sets the inner http.RoundTripper used to send requests that are not replayed. Defaults to
+// http.DefaultTransport.
+func (c CassetteOptionBuilder) Transport(rt http.RoundTripper) CassetteOptionBuilder {
+	return append(c, cassetteOptionFunc(func(cassette *Cassette) {
+		cassette.inner = rt
+	}))
+}
+
+// RedactHeaders adds headers whose values are replaced with "[REDACTED]" in the recorded interactions.
+func (c CassetteOptionBuilder) RedactHeaders(names ...string) CassetteOptionBuilder {
+	return append(c, cassetteOptionFunc(func(cassette *Cassette) {
+		cassette.redactHeaders = append(cassette.redactHeaders, names...)
+	}))
+}
+
+// Redact adds a function to modify the interactions before they are stored. If the function changes the request, make
+// sure the Matcher still matches the live request against the modified one.
+func (c CassetteOptionBuilder) Redact(redact CassetteRedactFunc) CassetteOptionBuilder {
+	return append(c, cassetteOptionFunc(func(cassette *Cassette) {
+		cassette.redact = append(cassette.redact, redact)
+	}))
+}
+
+// Tower sets the tower instance used to create the errors. Defaults to the global tower instance.
+func (c CassetteOptionBuilder) Tower(t *tower.Tower) CassetteOptionBuilder {
+	return append(c, cassetteOptionFunc(func(cassette *Cassette) {
+		cassette.tower = t
+	}))
+}
@@ -0,0 +1,198 @@
+package towerhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCassette(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, r.Method+" "+r.URL.Path+" "+string(body))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+	send := func(t *testing.T, client *http.Client, method, target, body string) (*http.Response, string, error) {
+		t.Helper()
+		req, _ := http.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, "", err
+		}
+		b, _ := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		return res, string(b), nil
+	}
+
+	t.Run("record", func(t *testing.T) {
+		cassette, err := NewCassette(path, Option.Cassette().Mode(CassetteRecord))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		client := &http.Client{Transport: cassette}
+		if _, _, err := send(t, client, http.MethodPost, server.URL+"/a", `{"a": 1, "b": 2}`); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, _, err := send(t, client, http.MethodGet, server.URL+"/b", ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := cassette.Save(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, _ := os.ReadFile(path)
+		if strings.Contains(string(b), "secret") {
+			t.Errorf("expected secrets to be redacted, got %s", b)
+		}
+		if !strings.Contains(string(b), cassetteRedacted) {
+			t.Errorf("expected redacted marker, got %s", b)
+		}
+	})
+
+	recorded := atomic.LoadInt32(&calls)
+
+	t.Run("replay", func(t *testing.T) {
+		cassette, err := NewCassette(path, Option.Cassette().Strict().Matcher(MatchAll(MatchMethod, MatchURL, MatchBody)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		client := &http.Client{Transport: cassette}
+		res, body, err := send(t, client, http.MethodPost, server.URL+"/a", `{"b":2,"a":1}`)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res.StatusCode != http.StatusOK || body != `POST /a {"a": 1, "b": 2}` {
+			t.Errorf("unexpected replay: %d %q", res.StatusCode, body)
+		}
+		if got := cassette.Unused(); len(got) != 1 || got[0].Request.Method != http.MethodGet {
+			t.Errorf("expected GET interaction to be unused, got %+v", got)
+		}
+		_, _, err = send(t, client, http.MethodGet, server.URL+"/c", "")
+		if !errors.Is(err, ErrCassetteNoMatch) {
+			t.Errorf("expected ErrCassetteNoMatch, got %v", err)
+		}
+		if got := atomic.LoadInt32(&calls); got != recorded {
+			t.Errorf("expected no request to the server in replay, got %d", got-recorded)
+		}
+	})
+
+	t.Run("replay or record", func(t *testing.T) {
+		cassette, err := NewCassette(path, Option.Cassette().Mode(CassetteReplayOrRecord))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		client := &http.Client{Transport: cassette}
+		if _, body, _ := send(t, client, http.MethodGet, server.URL+"/c", ""); body != "GET /c " {
+			t.Errorf("unexpected body %q", body)
+		}
+		before := atomic.LoadInt32(&calls)
+		if _, body, _ := send(t, client, http.MethodGet, server.URL+"/c", ""); body != "GET /c " {
+			t.Errorf("unexpected body %q", body)
+		}
+		if got := atomic.LoadInt32(&calls); got != before {
+			t.Errorf("expected repeated request to replay the recorded interaction, got %d requests", got-before)
+		}
+		for _, interaction := range cassette.Unused() {
+			if strings.HasSuffix(interaction.Request.URL, "/c") {
+				t.Errorf("expected recorded interaction to not be reported as unused, got %+v", interaction)
+			}
+		}
+		if err := cassette.Save(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := len(cassette.Interactions()); got != 3 {
+			t.Errorf("expected 3 interactions, got %d", got)
+		}
+	})
+
+	t.Run("missing cassette", func(t *testing.T) {
+		if _, err := NewCassette(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+			t.Error("expected error for missing cassette in replay mode")
+		}
+	})
+}
+
+func TestCassette_RecordEmptyBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cassette, err := NewCassette(filepath.Join(t.TempDir(), "empty.json"), Option.Cassette().Mode(CassetteRecord))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := &http.Client{Transport: cassette}
+	for _, method := range []string{http.MethodDelete, http.MethodHead} {
+		// The response bodies are not closed on purpose.
+		req, _ := http.NewRequest(method, server.URL, nil)
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res.StatusCode != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, res.StatusCode)
+		}
+	}
+	got := cassette.Interactions()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 interactions, got %d", len(got))
+	}
+	for _, interaction := range got {
+		if interaction.Response.StatusCode != http.StatusNoContent || len(interaction.Response.Body) != 0 {
+			t.Errorf("unexpected interaction %+v", interaction)
+		}
+	}
+}
+
+func TestCassette_RoundTrip_DoesNotModifyRequest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "strict.json")
+	_ = os.WriteFile(path, []byte("[]"), 0o600)
+	cassette, err := NewCassette(path, Option.Cassette().Strict())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Hides the strings.Reader type, so http.NewRequest does not set GetBody.
+	body := io.NopCloser(struct{ io.Reader }{strings.NewReader(`{"token": "secret"}`)})
+	req, _ := http.NewRequest(http.MethodPost, "http://example.com/a", body)
+	_, err = cassette.RoundTrip(req)
+	if !errors.Is(err, ErrCassetteNoMatch) {
+		t.Fatalf("expected ErrCassetteNoMatch, got %v", err)
+	}
+	if req.Body != body {
+		t.Error("expected request body to not be replaced")
+	}
+	b, _ := json.Marshal(err)
+	if strings.Contains(string(b), "secret") {
+		t.Errorf("expected the request body to not be in the error, got %s", b)
+	}
+}
+
+func TestCassetteBody(t *testing.T) {
+	for _, body := range []CassetteBody{CassetteBody("hello"), {0xff, 0x00, 0xfe}} {
+		b, err := body.MarshalJSON()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got CassetteBody
+		if err := got.UnmarshalJSON(b); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(got) != string(body) {
+			t.Errorf("expected %q, got %q", body, got)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package towerhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+// ClientErrorFilter decides whether the response is an error. Defaults to responses with non-2xx status.
+type ClientErrorFilter = func(*http.Response) bool
+
+// Client wraps http.Client to convert failed responses into tower.Error.
+//
+// Responses that are considered errors, by default any non-2xx response, are returned alongside a tower.Error that
+// carries:
+//
+// - Code: the code reported by the upstream service if the body is problem details with "code" extension or the shape
+// of SimpleErrorTransformer, otherwise the upstream status.
+//
+// - Message: the message reported by the upstream service, or the method, URL, and status if there is none.
+//
+// - Context: the method, URL, status, latency, and the response body limited by ReadErrorBodyLimit ClientOption.
+//
+// The body of failed responses is partially read into the error, but the caller can still read the whole body from the
+// response. Unlike http.Client, the response is not nil when the error is caused by the response status, so the caller
+// must still close the body.
+type Client struct {
+	client    *http.Client
+	tower     *tower.Tower
+	bodyLimit int
+	isError   ClientErrorFilter
+}
+
+// NewClient creates a new Client. If client is nil, http.DefaultClient is used.
+//
+// Use WrapHTTPClient on the client beforehand to log the requests.
+func NewClient(client *http.Client, opts ...ClientOption) *Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	c := &Client{
+		client:    client,
+		tower:     tower.Global.Tower(),
+		bodyLimit: 64 * 1024,
+		isError: func(res *http.Response) bool {
+			return res.StatusCode < 200 || res.StatusCode > 299
+		},
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// HTTPClient returns the underlying http.Client.
+func (c *Client) HTTPClient() *http.Client {
+	return c.client
+}
+
+// Do sends the request and converts failed responses into tower.Error. See Client for details.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	return c.do(req, tower.GetCaller(2))
+}
+
+// Get issues a GET request to the url.
+func (c *Client) Get(url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, c.tower.Wrap(err).Caller(tower.GetCaller(2)).Freeze()
+	}
+	return c.do(req, tower.GetCaller(2))
+}
+
+// Post issues a POST request to the url with the body.
+func (c *Client) Post(url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return nil, c.tower.Wrap(err).Caller(tower.GetCaller(2)).Freeze()
+	}
+	req.Header.Set("Content-Type", contentType)
+	return c.do(req, tower.GetCaller(2))
+}
+
+func (c *Client) do(req *http.Request, caller tower.Caller) (*http.Response, error) {
+	start := time.Now()
+	res, err := c.client.Do(req)
+	latency := time.Since(start)
+	fields := tower.F{
+		"method":  req.Method,
+		"url":     redactedURL(req.URL),
+		"latency": latency.String(),
+	}
+	if err != nil {
+		return res, c.tower.Wrap(err).
+			Message("%s %s", req.Method, redactedURL(req.URL)).
+			Caller(caller).
+			Context(fields).
+			Freeze()
+	}
+	if !c.isError(res) {
+		return res, nil
+	}
+
+	body, truncated := c.readErrorBody(res)
+	fields["status"] = res.StatusCode
+	contentType := res.Header.Get("Content-Type")
+	switch {
+	case len(body) == 0:
+	case truncated:
+		fields["body"] = fmt.Sprintf("%s (truncated)", body)
+	case isJSONContentType(contentType) && isJson(body):
+		fields["body"] = json.RawMessage(body)
+	default:
+		fields["body"] = string(body)
+	}
+
+	code, message := res.StatusCode, ""
+	if !truncated && isJSONContentType(contentType) {
+		code, message = decodeUpstreamError(body, res.StatusCode)
+	}
+	if message == "" {
+		message = fmt.Sprintf("%s %s: %s", req.Method, redactedURL(req.URL), res.Status)
+	}
+	return res, c.tower.Bail(message).
+		Code(code).
+		Caller(caller).
+		Context(fields).
+		Freeze()
+}
+
+// readErrorBody reads the response body up to the limit, and replaces the body with the read bytes followed by the
+// unread rest, so the caller can still read the whole body.
+func (c *Client) readErrorBody(res *http.Response) ([]byte, bool) {
+	if res.Body == nil || res.Body == http.NoBody {
+		return nil, false
+	}
+	limit := int64(c.bodyLimit)
+	if limit < 0 {
+		b, _ := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(b))
+		return b, false
+	}
+	b, _ := io.ReadAll(io.LimitReader(res.Body, limit+1))
+	truncated := int64(len(b)) > limit
+	res.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(b), res.Body), res.Body}
+	if truncated {
+		b = b[:limit]
+	}
+	return b, truncated
+}
+
+// decodeUpstreamError decodes problem details or the shape of SimpleErrorTransformer to get the code and message
+// reported by the upstream service.
+func decodeUpstreamError(body []byte, status int) (code int, message string) {
+	var payload struct {
+		Title  string          `json:"title"`
+		Detail string          `json:"detail"`
+		Code   json.Number     `json:"code"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return status, ""
+	}
+	code = status
+	if c, err := payload.Code.Int64(); err == nil && c > 0 {
+		code = int(c)
+	}
+	switch {
+	case payload.Detail != "":
+		return code, payload.Detail
+	case payload.Title != "":
+		return code, payload.Title
+	case len(payload.Error) == 0:
+		return code, ""
+	}
+	var s string
+	if err := json.Unmarshal(payload.Error, &s); err == nil {
+		return code, s
+	}
+	var node struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(payload.Error, &node); err == nil {
+		if node.Code > 0 {
+			code = node.Code
+		}
+		return code, node.Message
+	}
+	return code, ""
+}
+
+// redactedURL returns the url without the password.
+func redactedURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return u.Redacted()
+}
+
+type ClientOption interface {
+	apply(*Client)
+}
+
+type (
+	ClientOptionBuilder []ClientOption
+	clientOptionFunc    func(*Client)
+)
+
+func (c clientOptionFunc) apply(client *Client) {
+	c(client)
+}
+
+func (c ClientOptionBuilder) apply(client *Client) {
+	for _, v := range c {
+		v.apply(client)
+	}
+}
+
+// Tower sets the tower instance used to create the errors. Defaults to the global tower instance.
+func (c ClientOptionBuilder) Tower(t *tower.Tower) ClientOptionBuilder {
+	return append(c, clientOptionFunc(func(client *Client) {
+		client.tower = t
+	}))
+}
+
+// ReadErrorBodyLimit limits the number of bytes of failed response body read into the error. Defaults to 64KB.
+// Negative value reads all the body.
+func (c ClientOptionBuilder) ReadErrorBodyLimit(limit int) ClientOptionBuilder {
+	return append(c, clientOptionFunc(func(client *Client) {
+		client.bodyLimit = limit
+	}))
+}
+
+// IsError sets the filter to decide whether the response is an error. Defaults to responses with non-2xx status.
+func (c ClientOptionBuilder) IsError(filter ClientErrorFilter) ClientOptionBuilder {
+	return append(c, clientOptionFunc(func(client *Client) {
+		client.isError = filter
+	}))
+}
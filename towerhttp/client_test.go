@@ -0,0 +1,91 @@
+package towerhttp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+func TestClient_Do(t *testing.T) {
+	upstream := NewResponder()
+	problem := NewResponder()
+	problem.SetErrorTransformer(NewProblemDetailsTransformer())
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			upstream.Respond(rw, r, map[string]string{"ok": "ok"})
+		case "/simple":
+			upstream.RespondError(rw, r, tower.Bail("user not found").Code(http.StatusNotFound).Freeze())
+		case "/problem":
+			problem.RespondError(rw, r, tower.Bail("out of credit").Code(1403).Freeze())
+		case "/plain":
+			rw.WriteHeader(http.StatusBadGateway)
+			_, _ = io.WriteString(rw, strings.Repeat("x", 100))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		code    int
+		message string
+		body    string
+		client  *Client
+	}{
+		{name: "ok", path: "/ok"},
+		{name: "simple error transformer", path: "/simple", code: http.StatusNotFound, message: "user not found"},
+		{name: "problem details", path: "/problem", code: 1403, message: "out of credit"},
+		{name: "plain text", path: "/plain", code: http.StatusBadGateway, message: "GET " + server.URL + "/plain: 502 Bad Gateway", body: strings.Repeat("x", 100), client: NewClient(nil, Option.Client().ReadErrorBodyLimit(10))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := tt.client
+			if client == nil {
+				client = NewClient(nil)
+			}
+			res, err := client.Get(server.URL + tt.path)
+			if res == nil {
+				t.Fatalf("expected response, got error %v", err)
+			}
+			defer res.Body.Close()
+			if tt.code == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			var towerErr tower.Error
+			if !errors.As(err, &towerErr) {
+				t.Fatalf("expected tower.Error, got %T: %v", err, err)
+			}
+			if towerErr.Code() != tt.code {
+				t.Errorf("expected code %d, got %d", tt.code, towerErr.Code())
+			}
+			if towerErr.Message() != tt.message {
+				t.Errorf("expected message %q, got %q", tt.message, towerErr.Message())
+			}
+			if !strings.Contains(towerErr.Caller().File(), "client_test.go") {
+				t.Errorf("expected caller to be client_test.go, got %s", towerErr.Caller().File())
+			}
+			fields, _ := towerErr.Context()[0].(tower.F)
+			if fields["method"] != http.MethodGet || fields["status"] == nil || fields["latency"] == nil {
+				t.Errorf("unexpected context %v", fields)
+			}
+			if tt.body != "" {
+				if fields["body"] != "xxxxxxxxxx (truncated)" {
+					t.Errorf("expected truncated body in context, got %v", fields["body"])
+				}
+				body, _ := io.ReadAll(res.Body)
+				if string(body) != tt.body {
+					t.Errorf("expected full body to be readable, got %q", body)
+				}
+			}
+		})
+	}
+}
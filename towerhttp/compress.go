@@ -1,7 +1,10 @@
 package towerhttp
 
 import (
+	"bytes"
 	"io"
+
+	"github.com/tigorlazuardi/tower/pool"
 )
 
 type ContentEncodingHint interface {
@@ -31,6 +34,20 @@ type StreamCompressor interface {
 	StreamCompress(contentType string, origin io.Reader) (io.Reader, bool)
 }
 
+// compressionMinimumLength is the minimum length of bytes before compression is applied.
+//
+// 1500 is the max size of ethernet frame, 60 is the maximum range of TCP Header.
+//
+// The tradeoff between compression and cpu usage is not worth it if the size is less than MTU.
+//
+// Since the cost is the same: 1 IP packet.
+const compressionMinimumLength = 1500 - 60
+
+// compressionBufferPool holds buffers for compressors to write compressed bytes to.
+var compressionBufferPool = pool.New(func() *bytes.Buffer {
+	return &bytes.Buffer{}
+})
+
 var (
 	_ Compressor       = (*NoCompression)(nil)
 	_ StreamCompressor = (*NoCompression)(nil)
@@ -0,0 +1,77 @@
+package towerhttp
+
+import (
+	"io"
+
+	"github.com/andybalholm/brotli"
+	"github.com/tigorlazuardi/tower/pool"
+)
+
+var (
+	_ Compressor       = (*BrotliCompression)(nil)
+	_ StreamCompressor = (*BrotliCompression)(nil)
+)
+
+// BrotliCompression compresses with "br" content coding.
+type BrotliCompression struct {
+	writers *pool.Pool[*brotli.Writer]
+}
+
+// NewBrotliCompression creates a new BrotliCompression.
+func NewBrotliCompression() *BrotliCompression {
+	return NewBrotliCompressionWithLevel(brotli.DefaultCompression)
+}
+
+// NewBrotliCompressionWithLevel creates a new BrotliCompression with specified compression level.
+// Level ranges from brotli.BestSpeed (0) to brotli.BestCompression (11).
+func NewBrotliCompressionWithLevel(lvl int) *BrotliCompression {
+	return &BrotliCompression{
+		writers: pool.New(func() *brotli.Writer {
+			return brotli.NewWriterLevel(io.Discard, lvl)
+		}),
+	}
+}
+
+// ContentEncoding implements towerhttp.ContentEncodingHint.
+func (b BrotliCompression) ContentEncoding() string {
+	return "br"
+}
+
+// Compress implements towerhttp.Compressor.
+func (b BrotliCompression) Compress(in []byte) ([]byte, bool, error) {
+	if len(in) < compressionMinimumLength {
+		return in, false, nil
+	}
+	buf := compressionBufferPool.Get()
+	buf.Reset()
+	defer compressionBufferPool.Put(buf)
+	w := b.writers.Get()
+	defer b.writers.Put(w)
+	w.Reset(buf)
+	if _, err := w.Write(in); err != nil {
+		return in, false, err
+	}
+	if err := w.Close(); err != nil {
+		return in, false, err
+	}
+	return copyBuffer(buf), true, nil
+}
+
+// StreamCompress implements towerhttp.StreamCompressor.
+func (b BrotliCompression) StreamCompress(contentType string, origin io.Reader) (io.Reader, bool) {
+	if !isHumanReadable(contentType) {
+		return origin, false
+	}
+	pr, pw := io.Pipe()
+	w := b.writers.Get()
+	w.Reset(pw)
+	go func() {
+		_, err := io.Copy(w, origin)
+		if errClose := w.Close(); err == nil {
+			err = errClose
+		}
+		b.writers.Put(w)
+		_ = pw.CloseWithError(err)
+	}()
+	return pr, true
+}
@@ -0,0 +1,102 @@
+package towerhttp
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+
+	"github.com/tigorlazuardi/tower/pool"
+)
+
+var (
+	_ Compressor       = (*DeflateCompression)(nil)
+	_ StreamCompressor = (*DeflateCompression)(nil)
+)
+
+// DeflateCompression compresses with "deflate" content coding, which is the zlib format as defined by RFC 1950.
+type DeflateCompression struct {
+	level   int
+	writers *pool.Pool[*zlib.Writer]
+}
+
+// NewDeflateCompression creates a new DeflateCompression.
+func NewDeflateCompression() *DeflateCompression {
+	return NewDeflateCompressionWithLevel(zlib.DefaultCompression)
+}
+
+// NewDeflateCompressionWithLevel creates a new DeflateCompression with specified compression level.
+func NewDeflateCompressionWithLevel(lvl int) *DeflateCompression {
+	return &DeflateCompression{
+		level: lvl,
+		writers: pool.New(func() *zlib.Writer {
+			// Invalid level is reported on Compress.
+			w, _ := zlib.NewWriterLevel(io.Discard, lvl)
+			return w
+		}),
+	}
+}
+
+// ContentEncoding implements towerhttp.ContentEncodingHint.
+func (d DeflateCompression) ContentEncoding() string {
+	return "deflate"
+}
+
+func (d DeflateCompression) getWriter(w io.Writer) (*zlib.Writer, error) {
+	zw := d.writers.Get()
+	if zw == nil {
+		return nil, fmt.Errorf("zlib: invalid compression level: %d", d.level)
+	}
+	zw.Reset(w)
+	return zw, nil
+}
+
+// Compress implements towerhttp.Compressor.
+func (d DeflateCompression) Compress(b []byte) ([]byte, bool, error) {
+	if len(b) < compressionMinimumLength {
+		return b, false, nil
+	}
+	buf := compressionBufferPool.Get()
+	buf.Reset()
+	defer compressionBufferPool.Put(buf)
+	w, err := d.getWriter(buf)
+	if err != nil {
+		return b, false, err
+	}
+	defer d.writers.Put(w)
+	if _, err = w.Write(b); err != nil {
+		return b, false, err
+	}
+	if err = w.Close(); err != nil {
+		return b, false, err
+	}
+	return copyBuffer(buf), true, nil
+}
+
+// StreamCompress implements towerhttp.StreamCompressor.
+func (d DeflateCompression) StreamCompress(contentType string, origin io.Reader) (io.Reader, bool) {
+	if !isHumanReadable(contentType) {
+		return origin, false
+	}
+	pr, pw := io.Pipe()
+	w, err := d.getWriter(pw)
+	if err != nil {
+		return origin, false
+	}
+	go func() {
+		_, err := io.Copy(w, origin)
+		if errClose := w.Close(); err == nil {
+			err = errClose
+		}
+		d.writers.Put(w)
+		_ = pw.CloseWithError(err)
+	}()
+	return pr, true
+}
+
+// copyBuffer copies the content of pooled buffer, since the underlying array will be reused by the pool.
+func copyBuffer(buf *bytes.Buffer) []byte {
+	c := make([]byte, buf.Len())
+	copy(c, buf.Bytes())
+	return c
+}
@@ -1,11 +1,8 @@
 package towerhttp
 
 import (
-	"bytes"
 	"compress/gzip"
 	"io"
-
-	"github.com/tigorlazuardi/tower/pool"
 )
 
 var (
@@ -13,10 +10,6 @@ var (
 	_ StreamCompressor = (*GzipCompression)(nil)
 )
 
-var gzipPool = pool.New(func() *bytes.Buffer {
-	return &bytes.Buffer{}
-})
-
 type GzipCompression struct {
 	level int
 }
@@ -38,16 +31,10 @@ func (g GzipCompression) ContentEncoding() string {
 
 // Compress implements towerhttp.Compressor.
 func (g GzipCompression) Compress(b []byte) ([]byte, bool, error) {
-	// 1500 is the max size of ethernet frame, 60 is the maximum range of TCP Header.
-	//
-	// The tradeoff between compression and cpu usage is not worth it if the size is less than MTU.
-	//
-	// Since the cost is the same: 1 IP packet.
-	const minimumLength = 1500 - 60
-	if len(b) < minimumLength {
+	if len(b) < compressionMinimumLength {
 		return b, false, nil
 	}
-	buf := gzipPool.Get()
+	buf := compressionBufferPool.Get()
 	buf.Reset()
 	w, err := gzip.NewWriterLevel(buf, g.level)
 	if err != nil {
@@ -62,7 +49,7 @@ func (g GzipCompression) Compress(b []byte) ([]byte, bool, error) {
 	// bytes.Buffer bytes method points to an array that will be reused by the pool.
 	// So we need to copy the bytes to a new array.
 	copy(c, buf.Bytes())
-	gzipPool.Put(buf)
+	compressionBufferPool.Put(buf)
 	return c, true, err
 }
 
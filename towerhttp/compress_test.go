@@ -1,8 +1,14 @@
 package towerhttp
 
 import (
+	"bytes"
+	"compress/zlib"
+	"io"
 	"reflect"
 	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
 )
 
 func TestNoCompression_Compress(t *testing.T) {
@@ -63,3 +69,73 @@ func TestNoCompression_ContentEncoding(t *testing.T) {
 		})
 	}
 }
+
+func TestCompressions_RoundTrip(t *testing.T) {
+	input := bytes.Repeat([]byte("hello world "), 200)
+	tests := []struct {
+		name       string
+		compressor interface {
+			Compressor
+			StreamCompressor
+		}
+		decompress func(r io.Reader) (io.Reader, error)
+	}{
+		{
+			name:       "deflate",
+			compressor: NewDeflateCompression(),
+			decompress: func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) },
+		},
+		{
+			name:       "brotli",
+			compressor: NewBrotliCompression(),
+			decompress: func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil },
+		},
+		{
+			name:       "zstd",
+			compressor: NewZstdCompression(),
+			decompress: func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Run twice to make sure pooled writers are reset properly.
+			for i := 0; i < 2; i++ {
+				compressed, ok, err := tt.compressor.Compress(input)
+				if err != nil || !ok {
+					t.Fatalf("Compress() ok = %v, err = %v", ok, err)
+				}
+				if len(compressed) >= len(input) {
+					t.Errorf("Compress() expected compressed size to be smaller than %d, got %d", len(input), len(compressed))
+				}
+				r, err := tt.decompress(bytes.NewReader(compressed))
+				if err != nil {
+					t.Fatalf("failed to create decompressor: %v", err)
+				}
+				got, err := io.ReadAll(r)
+				if err != nil || !bytes.Equal(got, input) {
+					t.Fatalf("Compress() round trip failed: err = %v", err)
+				}
+
+				stream, ok := tt.compressor.StreamCompress("application/json", bytes.NewReader(input))
+				if !ok {
+					t.Fatal("StreamCompress() expected to compress human readable content")
+				}
+				r, err = tt.decompress(stream)
+				if err != nil {
+					t.Fatalf("failed to create decompressor: %v", err)
+				}
+				got, err = io.ReadAll(r)
+				if err != nil || !bytes.Equal(got, input) {
+					t.Fatalf("StreamCompress() round trip failed: err = %v", err)
+				}
+			}
+			small, ok, _ := tt.compressor.Compress([]byte("hello"))
+			if ok || string(small) != "hello" {
+				t.Error("Compress() expected small data to not be compressed")
+			}
+			if _, ok := tt.compressor.StreamCompress("image/png", bytes.NewReader(input)); ok {
+				t.Error("StreamCompress() expected binary content to not be compressed")
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package towerhttp
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/tigorlazuardi/tower/pool"
+)
+
+var (
+	_ Compressor       = (*ZstdCompression)(nil)
+	_ StreamCompressor = (*ZstdCompression)(nil)
+)
+
+// ZstdCompression compresses with "zstd" content coding.
+type ZstdCompression struct {
+	writers *pool.Pool[*zstd.Encoder]
+}
+
+// NewZstdCompression creates a new ZstdCompression.
+func NewZstdCompression() *ZstdCompression {
+	return NewZstdCompressionWithLevel(zstd.SpeedDefault)
+}
+
+// NewZstdCompressionWithLevel creates a new ZstdCompression with specified compression level.
+func NewZstdCompressionWithLevel(lvl zstd.EncoderLevel) *ZstdCompression {
+	return &ZstdCompression{
+		writers: pool.New(func() *zstd.Encoder {
+			// Only fails on invalid options, which are fixed here.
+			w, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(lvl), zstd.WithEncoderConcurrency(1))
+			return w
+		}),
+	}
+}
+
+// ContentEncoding implements towerhttp.ContentEncodingHint.
+func (z ZstdCompression) ContentEncoding() string {
+	return "zstd"
+}
+
+// Compress implements towerhttp.Compressor.
+func (z ZstdCompression) Compress(b []byte) ([]byte, bool, error) {
+	if len(b) < compressionMinimumLength {
+		return b, false, nil
+	}
+	w := z.writers.Get()
+	defer z.writers.Put(w)
+	return w.EncodeAll(b, make([]byte, 0, len(b)/2)), true, nil
+}
+
+// StreamCompress implements towerhttp.StreamCompressor.
+func (z ZstdCompression) StreamCompress(contentType string, origin io.Reader) (io.Reader, bool) {
+	if !isHumanReadable(contentType) {
+		return origin, false
+	}
+	pr, pw := io.Pipe()
+	w := z.writers.Get()
+	w.Reset(pw)
+	go func() {
+		_, err := io.Copy(w, origin)
+		if errClose := w.Close(); err == nil {
+			err = errClose
+		}
+		z.writers.Put(w)
+		_ = pw.CloseWithError(err)
+	}()
+	return pr, true
+}
@@ -0,0 +1,97 @@
+package towerhttp
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// curlBodyFile is referenced by the curl command when the body is not fully available, so the user can put the whole
+// body in the file to reproduce the request.
+const curlBodyFile = "@request_body"
+
+var defaultCurlMaskHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "X-Api-Key", "X-Auth-Token"}
+
+// CurlCommand builds a single line curl command that reproduces the request.
+//
+// Values of the headers that may hold secrets (Authorization, Proxy-Authorization, Cookie, X-Api-Key, X-Auth-Token, and
+// maskHeaders) are replaced with "***". The authorization scheme, like "Bearer", is kept.
+//
+// The body is taken from the cloned body. If the body is truncated, not cloned, or not valid UTF-8, the command
+// references the file "request_body" instead, which the user has to fill with the actual body.
+//
+// Incoming server requests have relative URL, so the scheme and the Host header are used to build the absolute URL.
+func CurlCommand(req *http.Request, body ClonedBody, maskHeaders ...string) string {
+	masked := make(map[string]bool, len(defaultCurlMaskHeaders)+len(maskHeaders))
+	for _, name := range defaultCurlMaskHeaders {
+		masked[http.CanonicalHeaderKey(name)] = true
+	}
+	for _, name := range maskHeaders {
+		masked[http.CanonicalHeaderKey(name)] = true
+	}
+
+	s := &strings.Builder{}
+	s.WriteString("curl")
+	hasBody := req.ContentLength != 0 || (body != nil && body.Len() > 0)
+	if req.Method != "" && (req.Method != http.MethodGet || hasBody) {
+		s.WriteString(" -X ")
+		s.WriteString(req.Method)
+	}
+	s.WriteByte(' ')
+	s.WriteString(shellQuote(curlURL(req)))
+
+	keys := make([]string, 0, len(req.Header))
+	for k := range req.Header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		// curl computes these headers itself.
+		if k == "Content-Length" || k == "Host" {
+			continue
+		}
+		for _, v := range req.Header[k] {
+			if masked[http.CanonicalHeaderKey(k)] {
+				v = maskHeaderValue(v)
+			}
+			s.WriteString(" -H ")
+			s.WriteString(shellQuote(k + ": " + v))
+		}
+	}
+
+	if !hasBody {
+		return s.String()
+	}
+	s.WriteString(" --data-binary ")
+	switch {
+	case body == nil || body.Len() == 0 || body.Truncated() || !utf8.Valid(body.Bytes()):
+		s.WriteString(curlBodyFile)
+	default:
+		s.WriteString(shellQuote(body.String()))
+	}
+	return s.String()
+}
+
+func curlURL(req *http.Request) string {
+	if req.URL.IsAbs() {
+		return redactedURL(req.URL)
+	}
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + req.Host + req.URL.RequestURI()
+}
+
+func maskHeaderValue(v string) string {
+	if scheme, _, ok := strings.Cut(v, " "); ok {
+		return scheme + " ***"
+	}
+	return "***"
+}
+
+// shellQuote quotes s with single quotes for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
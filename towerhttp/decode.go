@@ -0,0 +1,242 @@
+package towerhttp
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/tigorlazuardi/tower"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Validator is implemented by decode destinations that validate themselves. Decode calls Validate after the body is
+// decoded.
+//
+// Return FieldErrors to report which fields are invalid. Returned tower.Error is passed through as is, so it can set
+// its own code and message.
+type Validator interface {
+	Validate() error
+}
+
+// FieldError is the error of a single field of the request body.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (f FieldError) Error() string {
+	return f.Field + ": " + f.Message
+}
+
+// FieldErrors is a list of FieldError.
+type FieldErrors []FieldError
+
+func (f FieldErrors) Error() string {
+	s := make([]string, len(f))
+	for i, e := range f {
+		s[i] = e.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Decode decodes the request body into dst based on the request's Content-Type header.
+//
+// Supported content types are JSON (application/json and +json suffix), XML (application/xml, text/xml, and +xml
+// suffix), form (application/x-www-form-urlencoded), multipart form (multipart/form-data), and MessagePack
+// (application/msgpack). Form fields are decoded into struct fields named by the `form` tag, falling back to the `json`
+// tag, then the field name. Multipart files are decoded into *multipart.FileHeader or []*multipart.FileHeader fields.
+//
+// If dst implements Validator, Validate is called after the body is decoded.
+//
+// The returned error is a tower.Error with the following codes, so it can be passed directly to Responder.RespondError:
+//
+// - http.StatusUnsupportedMediaType: the Content-Type is not supported.
+//
+// - http.StatusRequestEntityTooLarge: the body is larger than the limit set by MaxBodySize DecodeOption.
+//
+// - http.StatusBadRequest: the body is empty, malformed, or invalid.
+//
+// Field level details are added to the error context as Public{"fields": FieldErrors}, which are exposed to the
+// client by ProblemDetailsTransformer.
+func Decode(request *http.Request, dst any, opts ...DecodeOption) error {
+	opt := &DecodeContext{
+		MaxBodySize:        10 << 20,
+		MaxMemory:          32 << 20,
+		DefaultContentType: "application/json",
+		Tower:              tower.Global.Tower(),
+		Caller:             tower.GetCaller(2),
+	}
+	for _, o := range opts {
+		o.apply(opt)
+	}
+
+	contentType := request.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = opt.DefaultContentType
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return opt.Tower.Wrap(err).
+			Code(http.StatusUnsupportedMediaType).
+			Message("invalid Content-Type %q", contentType).
+			Caller(opt.Caller).
+			Freeze()
+	}
+	if opt.MaxBodySize >= 0 && request.ContentLength > opt.MaxBodySize {
+		return opt.bodyTooLarge(&http.MaxBytesError{Limit: opt.MaxBodySize})
+	}
+	if request.Body == nil {
+		request.Body = http.NoBody
+	}
+	if opt.MaxBodySize >= 0 {
+		request.Body = http.MaxBytesReader(nil, request.Body, opt.MaxBodySize)
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		err = decodeJSON(request.Body, dst, opt)
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		err = xml.NewDecoder(request.Body).Decode(dst)
+	case mediaType == "application/msgpack" || mediaType == "application/x-msgpack" || mediaType == "application/vnd.msgpack":
+		err = decodeMessagePack(request.Body, dst, opt)
+	case mediaType == "application/x-www-form-urlencoded":
+		if err = request.ParseForm(); err == nil {
+			err = decodeForm(request.PostForm, nil, dst)
+		}
+	case mediaType == "multipart/form-data":
+		if err = request.ParseMultipartForm(opt.MaxMemory); err == nil {
+			err = decodeForm(request.MultipartForm.Value, request.MultipartForm.File, dst)
+		}
+	default:
+		return opt.Tower.Bail("unsupported Content-Type %q", mediaType).
+			Code(http.StatusUnsupportedMediaType).
+			Caller(opt.Caller).
+			Freeze()
+	}
+	if err != nil {
+		return opt.decodeError(err)
+	}
+
+	if v, ok := dst.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return opt.validationError(err)
+		}
+	}
+	return nil
+}
+
+func decodeJSON(body io.Reader, dst any, opt *DecodeContext) error {
+	dec := json.NewDecoder(body)
+	if opt.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(dst); err != nil {
+		return err
+	}
+	// Rejects bodies with trailing data like `{"a":1}{"b":2}`.
+	if dec.More() {
+		return errors.New("request body must only contain a single JSON value")
+	}
+	return nil
+}
+
+func decodeMessagePack(body io.Reader, dst any, opt *DecodeContext) error {
+	dec := msgpack.GetDecoder()
+	defer msgpack.PutDecoder(dec)
+	dec.Reset(body)
+	dec.SetCustomStructTag("json")
+	dec.DisallowUnknownFields(opt.DisallowUnknownFields)
+	return dec.Decode(dst)
+}
+
+func (opt *DecodeContext) bodyTooLarge(err *http.MaxBytesError) error {
+	return opt.Tower.Wrap(err).
+		Code(http.StatusRequestEntityTooLarge).
+		Message("request body is larger than %d bytes", err.Limit).
+		Caller(opt.Caller).
+		Freeze()
+}
+
+// decodeError translates decoding errors into tower.Error with field details where possible.
+func (opt *DecodeContext) decodeError(err error) error {
+	var (
+		maxBytesErr  *http.MaxBytesError
+		syntaxErr    *json.SyntaxError
+		typeErr      *json.UnmarshalTypeError
+		fieldErrors  FieldErrors
+		message      = "failed to decode request body"
+		fields       FieldErrors
+		unknownField = unknownFieldName(err)
+	)
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return opt.bodyTooLarge(maxBytesErr)
+	case errors.Is(err, io.EOF):
+		message = "request body is empty"
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		message = "request body is incomplete"
+	case errors.As(err, &syntaxErr):
+		message = fmt.Sprintf("request body is malformed at offset %d", syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		message = "request body has invalid field"
+		fields = FieldErrors{{Field: typeErr.Field, Message: fmt.Sprintf("must be of type %s", typeErr.Type)}}
+	case unknownField != "":
+		message = "request body has unknown field"
+		fields = FieldErrors{{Field: unknownField, Message: "unknown field"}}
+	case errors.As(err, &fieldErrors):
+		message = "request body has invalid field"
+		fields = fieldErrors
+	}
+	builder := opt.Tower.Wrap(err).
+		Code(http.StatusBadRequest).
+		Message(message).
+		Caller(opt.Caller)
+	if len(fields) > 0 {
+		builder = builder.Context(Public{"fields": fields})
+	}
+	return builder.Freeze()
+}
+
+// validationError translates errors returned by Validator into tower.Error.
+func (opt *DecodeContext) validationError(err error) error {
+	var towerErr tower.Error
+	if errors.As(err, &towerErr) {
+		return err
+	}
+	builder := opt.Tower.Wrap(err).
+		Code(http.StatusBadRequest).
+		Message("request body is invalid").
+		Caller(opt.Caller)
+	var (
+		fieldErrors FieldErrors
+		fieldError  FieldError
+	)
+	switch {
+	case errors.As(err, &fieldErrors):
+		builder = builder.Context(Public{"fields": fieldErrors})
+	case errors.As(err, &fieldError):
+		builder = builder.Context(Public{"fields": FieldErrors{fieldError}})
+	}
+	return builder.Freeze()
+}
+
+// unknownFieldName extracts the field name from unknown field errors of encoding/json and msgpack, which have no
+// dedicated error types.
+func unknownFieldName(err error) string {
+	const marker = `unknown field "`
+	msg := err.Error()
+	i := strings.Index(msg, marker)
+	if i < 0 {
+		return ""
+	}
+	name := msg[i+len(marker):]
+	if j := strings.IndexByte(name, '"'); j >= 0 {
+		return name[:j]
+	}
+	return ""
+}
@@ -0,0 +1,161 @@
+package towerhttp
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	fileHeaderType      = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeaderSliceType = reflect.TypeOf([]*multipart.FileHeader(nil))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// decodeForm decodes form values and files into dst.
+//
+// dst must be a pointer to a struct, url.Values, or map[string][]string. Struct fields are named by the `form` tag,
+// falling back to the `json` tag, then the field name. Fields tagged with "-" are skipped.
+func decodeForm(values url.Values, files map[string][]*multipart.FileHeader, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("decode destination must be a non-nil pointer, got %T", dst)
+	}
+	switch d := dst.(type) {
+	case *url.Values:
+		*d = values
+		return nil
+	case *map[string][]string:
+		*d = values
+		return nil
+	}
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("form can only be decoded into a struct, url.Values, or map[string][]string, got %T", dst)
+	}
+	var fieldErrors FieldErrors
+	decodeFormStruct(rv, values, files, &fieldErrors)
+	if len(fieldErrors) > 0 {
+		return fieldErrors
+	}
+	return nil
+}
+
+func decodeFormStruct(rv reflect.Value, values url.Values, files map[string][]*multipart.FileHeader, fieldErrors *FieldErrors) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fv := rv.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("form") == "" {
+			decodeFormStruct(fv, values, files, fieldErrors)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name := formFieldName(field)
+		if name == "-" {
+			continue
+		}
+		switch field.Type {
+		case fileHeaderType:
+			if fhs := files[name]; len(fhs) > 0 {
+				fv.Set(reflect.ValueOf(fhs[0]))
+			}
+			continue
+		case fileHeaderSliceType:
+			if fhs := files[name]; len(fhs) > 0 {
+				fv.Set(reflect.ValueOf(fhs))
+			}
+			continue
+		}
+		vs, ok := values[name]
+		if !ok || len(vs) == 0 {
+			continue
+		}
+		if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8 && !fv.Addr().Type().Implements(textUnmarshalerType) {
+			slice := reflect.MakeSlice(fv.Type(), len(vs), len(vs))
+			for j, s := range vs {
+				if err := setFormValue(slice.Index(j), s); err != nil {
+					*fieldErrors = append(*fieldErrors, FieldError{Field: name, Message: err.Error()})
+					break
+				}
+			}
+			fv.Set(slice)
+			continue
+		}
+		if err := setFormValue(fv, vs[0]); err != nil {
+			*fieldErrors = append(*fieldErrors, FieldError{Field: name, Message: err.Error()})
+		}
+	}
+}
+
+func formFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"form", "json"} {
+		if name, _, _ := strings.Cut(field.Tag.Get(tag), ","); name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func setFormValue(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setFormValue(v.Elem(), s)
+	}
+	if v.CanAddr() {
+		if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return u.UnmarshalText([]byte(s))
+		}
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		// Checkboxes send "on" when checked.
+		if s == "on" {
+			v.SetBool(true)
+			return nil
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return errors.New("must be a boolean")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("must be a non-negative integer")
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte(s))
+			return nil
+		}
+		fallthrough
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package towerhttp
+
+import "github.com/tigorlazuardi/tower"
+
+type DecodeOption interface {
+	apply(*DecodeContext)
+}
+
+type (
+	DecodeOptionBuilder []DecodeOption
+	decodeOptionFunc    func(*DecodeContext)
+)
+
+func (d decodeOptionFunc) apply(o *DecodeContext) {
+	d(o)
+}
+
+func (d DecodeOptionBuilder) apply(o *DecodeContext) {
+	for _, v := range d {
+		v.apply(o)
+	}
+}
+
+// DecodeContext is the configuration of Decode.
+type DecodeContext struct {
+	// MaxBodySize is the maximum number of bytes of the request body. Negative value disables the limit.
+	MaxBodySize int64
+	// MaxMemory is the maximum number of bytes of multipart form stored in memory. The rest is stored in temporary
+	// files.
+	MaxMemory int64
+	// DisallowUnknownFields rejects JSON and MessagePack bodies with fields that do not exist in the destination.
+	DisallowUnknownFields bool
+	// DefaultContentType is used when the request has no Content-Type header. Empty value rejects such requests.
+	DefaultContentType string
+	// Tower is the tower instance used to create the errors.
+	Tower *tower.Tower
+	// Caller is the caller of Decode, used as the caller of the errors.
+	Caller tower.Caller
+}
+
+// MaxBodySize limits the number of bytes of the request body. Requests with larger body are rejected with
+// http.StatusRequestEntityTooLarge. Defaults to 10MB. Negative value disables the limit.
+func (d DecodeOptionBuilder) MaxBodySize(size int64) DecodeOptionBuilder {
+	return append(d, decodeOptionFunc(func(o *DecodeContext) {
+		o.MaxBodySize = size
+	}))
+}
+
+// MaxMemory sets the maximum number of bytes of multipart form stored in memory. Defaults to 32MB.
+func (d DecodeOptionBuilder) MaxMemory(size int64) DecodeOptionBuilder {
+	return append(d, decodeOptionFunc(func(o *DecodeContext) {
+		o.MaxMemory = size
+	}))
+}
+
+// DisallowUnknownFields rejects JSON and MessagePack bodies with fields that do not exist in the destination.
+func (d DecodeOptionBuilder) DisallowUnknownFields() DecodeOptionBuilder {
+	return append(d, decodeOptionFunc(func(o *DecodeContext) {
+		o.DisallowUnknownFields = true
+	}))
+}
+
+// DefaultContentType sets the content type for requests without Content-Type header. Defaults to "application/json".
+// Empty value rejects such requests with http.StatusUnsupportedMediaType.
+func (d DecodeOptionBuilder) DefaultContentType(contentType string) DecodeOptionBuilder {
+	return append(d, decodeOptionFunc(func(o *DecodeContext) {
+		o.DefaultContentType = contentType
+	}))
+}
+
+// Tower sets the tower instance used to create the errors. Defaults to the global tower instance.
+func (d DecodeOptionBuilder) Tower(t *tower.Tower) DecodeOptionBuilder {
+	return append(d, decodeOptionFunc(func(o *DecodeContext) {
+		o.Tower = t
+	}))
+}
@@ -0,0 +1,140 @@
+package towerhttp
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/kinbiko/jsonassert"
+	"github.com/tigorlazuardi/tower"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type decodeUser struct {
+	Name  string   `json:"name" xml:"name"`
+	Age   int      `json:"age" xml:"age"`
+	Tags  []string `json:"tags" form:"tag" xml:"tag"`
+	Admin bool     `json:"admin" xml:"admin"`
+}
+
+func (d decodeUser) Validate() error {
+	if d.Name == "" {
+		return FieldErrors{{Field: "name", Message: "is required"}}
+	}
+	return nil
+}
+
+func TestDecode(t *testing.T) {
+	want := decodeUser{Name: "john", Age: 30, Tags: []string{"a", "b"}, Admin: true}
+	msgpackBody, _ := NewMessagePackEncoder().Encode(want)
+	multipartBody := &bytes.Buffer{}
+	mw := multipart.NewWriter(multipartBody)
+	_ = mw.WriteField("name", "john")
+	_ = mw.WriteField("age", "30")
+	_ = mw.WriteField("tag", "a")
+	_ = mw.WriteField("tag", "b")
+	_ = mw.WriteField("admin", "on")
+	_ = mw.Close()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+	}{
+		{"json", "application/json; charset=utf-8", []byte(`{"name":"john","age":30,"tags":["a","b"],"admin":true}`)},
+		{"no content type", "", []byte(`{"name":"john","age":30,"tags":["a","b"],"admin":true}`)},
+		{"xml", "application/xml", []byte(`<user><name>john</name><age>30</age><tag>a</tag><tag>b</tag><admin>true</admin></user>`)},
+		{"form", "application/x-www-form-urlencoded", []byte(url.Values{"name": {"john"}, "age": {"30"}, "tag": {"a", "b"}, "admin": {"true"}}.Encode())},
+		{"multipart", mw.FormDataContentType(), multipartBody.Bytes()},
+		{"msgpack", "application/msgpack", msgpackBody},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			var got decodeUser
+			if err := Decode(req, &got); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if got.Name != want.Name || got.Age != want.Age || got.Admin != want.Admin || strings.Join(got.Tags, ",") != "a,b" {
+				t.Errorf("Decode() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		opts        []DecodeOption
+		code        int
+		fields      string
+	}{
+		{name: "unsupported", contentType: "text/csv", body: "a,b", code: http.StatusUnsupportedMediaType},
+		{name: "no content type rejected", body: "{}", opts: []DecodeOption{Option.Decode().DefaultContentType("")}, code: http.StatusUnsupportedMediaType},
+		{name: "too large", contentType: "application/json", body: `{"name":"john"}`, opts: []DecodeOption{Option.Decode().MaxBodySize(4)}, code: http.StatusRequestEntityTooLarge},
+		{name: "empty", contentType: "application/json", body: "", code: http.StatusBadRequest},
+		{name: "malformed", contentType: "application/json", body: `{"name":`, code: http.StatusBadRequest},
+		{name: "wrong type", contentType: "application/json", body: `{"name":"john","age":"old"}`, code: http.StatusBadRequest, fields: `[{"field":"age","message":"must be of type int"}]`},
+		{name: "unknown field", contentType: "application/json", body: `{"name":"john","role":"x"}`, opts: []DecodeOption{Option.Decode().DisallowUnknownFields()}, code: http.StatusBadRequest, fields: `[{"field":"role","message":"unknown field"}]`},
+		{name: "invalid form field", contentType: "application/x-www-form-urlencoded", body: "name=john&age=old", code: http.StatusBadRequest, fields: `[{"field":"age","message":"must be an integer"}]`},
+		{name: "validation", contentType: "application/json", body: `{"age":1}`, code: http.StatusBadRequest, fields: `[{"field":"name","message":"is required"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			var got decodeUser
+			err := Decode(req, &got, tt.opts...)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			var towerErr tower.Error
+			if !errors.As(err, &towerErr) {
+				t.Fatalf("expected tower.Error, got %T", err)
+			}
+			if code := towerErr.HTTPCode(); code != tt.code {
+				t.Errorf("expected code %d, got %d", tt.code, code)
+			}
+			if !strings.Contains(towerErr.Caller().File(), "decode_test.go") {
+				t.Errorf("expected caller to be decode_test.go, got %s", towerErr.Caller().File())
+			}
+			var fields any
+			for _, c := range towerErr.Context() {
+				if p, ok := c.(Public); ok {
+					fields = p["fields"]
+				}
+			}
+			if tt.fields == "" {
+				if fields != nil {
+					t.Errorf("expected no field details, got %v", fields)
+				}
+				return
+			}
+			b, _ := NewJSONEncoder().Encode(fields)
+			jsonassert.New(t).Assertf(string(b), tt.fields)
+		})
+	}
+}
+
+func TestDecode_MessagePackUnknownField(t *testing.T) {
+	body, _ := msgpack.Marshal(map[string]any{"name": "john", "role": "x"})
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/msgpack")
+	var got decodeUser
+	err := Decode(req, &got, Option.Decode().DisallowUnknownFields())
+	if code := tower.Query.GetHTTPCode(err); code != http.StatusBadRequest {
+		t.Errorf("expected code 400, got %d: %v", code, err)
+	}
+}
@@ -0,0 +1,37 @@
+package towerhttp
+
+import (
+	"github.com/fxamacker/cbor/v2"
+)
+
+var _ Encoder = (*CBOREncoder)(nil)
+
+type CBOREncoder struct {
+	mode cbor.EncMode
+}
+
+// NewCBOREncoder Creates a new CBOREncoder.
+//
+// Struct fields are named using the `cbor` tag, falling back to the `json` tag.
+func NewCBOREncoder() *CBOREncoder {
+	mode, _ := cbor.EncOptions{Time: cbor.TimeRFC3339Nano}.EncMode()
+	return &CBOREncoder{mode: mode}
+}
+
+// SetEncOptions Sets the options of the underlying CBOR encoder.
+func (c *CBOREncoder) SetEncOptions(opts cbor.EncOptions) error {
+	mode, err := opts.EncMode()
+	if err != nil {
+		return err
+	}
+	c.mode = mode
+	return nil
+}
+
+func (c *CBOREncoder) ContentType() string {
+	return "application/cbor"
+}
+
+func (c *CBOREncoder) Encode(input any) ([]byte, error) {
+	return c.mode.Marshal(input)
+}
@@ -0,0 +1,57 @@
+package towerhttp
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+var _ Encoder = (*MessagePackEncoder)(nil)
+
+type MessagePackEncoder struct {
+	tag  string
+	pool *sync.Pool
+}
+
+// NewMessagePackEncoder Creates a new MessagePackEncoder.
+//
+// Struct fields are named using the `json` tag by default, so the same types produce the same keys as JSONEncoder.
+func NewMessagePackEncoder() *MessagePackEncoder {
+	return &MessagePackEncoder{
+		tag: "json",
+		pool: &sync.Pool{
+			New: func() interface{} {
+				return &bytes.Buffer{}
+			},
+		},
+	}
+}
+
+// SetStructTag Sets the struct tag used to name struct fields. Empty value uses the `msgpack` tag.
+func (m *MessagePackEncoder) SetStructTag(tag string) {
+	m.tag = tag
+}
+
+func (m *MessagePackEncoder) ContentType() string {
+	return "application/msgpack"
+}
+
+func (m *MessagePackEncoder) Encode(input any) ([]byte, error) {
+	buf := m.pool.Get().(*bytes.Buffer) //nolint
+	buf.Reset()
+	defer m.pool.Put(buf)
+	enc := msgpack.GetEncoder()
+	defer msgpack.PutEncoder(enc)
+	enc.Reset(buf)
+	if m.tag != "" {
+		enc.SetCustomStructTag(m.tag)
+	}
+	if err := enc.Encode(input); err != nil {
+		return nil, err
+	}
+	b := buf.Bytes()
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c, nil
+}
@@ -0,0 +1,65 @@
+package towerhttp
+
+import (
+	"encoding"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var _ Encoder = (*PlainTextEncoder)(nil)
+
+// PlainTextEncoder encodes values into human-readable text.
+//
+// Strings and byte slices are written as is. Values implementing encoding.TextMarshaler, fmt.Stringer, or error use
+// those methods. Maps with string keys are written as "key: value" lines sorted by key. Other values are formatted
+// with fmt's %v verb.
+type PlainTextEncoder struct{}
+
+// NewPlainTextEncoder Creates a new PlainTextEncoder.
+func NewPlainTextEncoder() *PlainTextEncoder {
+	return &PlainTextEncoder{}
+}
+
+func (p *PlainTextEncoder) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+func (p *PlainTextEncoder) Encode(input any) ([]byte, error) {
+	switch v := input.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		c := make([]byte, len(v))
+		copy(c, v)
+		return c, nil
+	case encoding.TextMarshaler:
+		return v.MarshalText()
+	case fmt.Stringer:
+		return []byte(v.String()), nil
+	case error:
+		return []byte(v.Error()), nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		s := &strings.Builder{}
+		for i, k := range keys {
+			if i > 0 {
+				s.WriteString("\n")
+			}
+			s.WriteString(k)
+			s.WriteString(": ")
+			b, err := p.Encode(v[k])
+			if err != nil {
+				return nil, err
+			}
+			s.Write(b)
+		}
+		return []byte(s.String()), nil
+	default:
+		return []byte(fmt.Sprintf("%v", v)), nil
+	}
+}
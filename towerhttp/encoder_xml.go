@@ -0,0 +1,108 @@
+package towerhttp
+
+import (
+	"bytes"
+	"encoding/xml"
+	"sort"
+	"sync"
+)
+
+var _ Encoder = (*XMLEncoder)(nil)
+
+type XMLEncoder struct {
+	indent   string
+	prefix   string
+	rootName string
+	header   bool
+	pool     *sync.Pool
+}
+
+// NewXMLEncoder Creates a new XMLEncoder.
+//
+// Maps with string keys, which encoding/xml does not support, are encoded as an element named "response" whose
+// children are the map entries sorted by key.
+func NewXMLEncoder() *XMLEncoder {
+	return &XMLEncoder{
+		rootName: "response",
+		header:   true,
+		pool: &sync.Pool{
+			New: func() interface{} {
+				return &bytes.Buffer{}
+			},
+		},
+	}
+}
+
+// SetIndent Sets the indent for every level line. Used for pretty print XML. Empty value disable indentation.
+func (x *XMLEncoder) SetIndent(indent string) {
+	x.indent = indent
+}
+
+// SetPrefix Sets the prefix of the output for every line. Empty value disable prefix.
+func (x *XMLEncoder) SetPrefix(prefix string) {
+	x.prefix = prefix
+}
+
+// SetRootName Sets the root element name for map values. Defaults to "response".
+func (x *XMLEncoder) SetRootName(name string) {
+	x.rootName = name
+}
+
+// SetHeader Sets whether the standard XML header is written before the output. Defaults to true.
+func (x *XMLEncoder) SetHeader(header bool) {
+	x.header = header
+}
+
+func (x *XMLEncoder) ContentType() string {
+	return "application/xml; charset=utf-8"
+}
+
+func (x *XMLEncoder) Encode(input any) ([]byte, error) {
+	buf := x.pool.Get().(*bytes.Buffer) //nolint
+	buf.Reset()
+	defer x.pool.Put(buf)
+	if x.header {
+		buf.WriteString(xml.Header)
+	}
+	enc := xml.NewEncoder(buf)
+	enc.Indent(x.prefix, x.indent)
+	if m, ok := input.(map[string]any); ok {
+		input = xmlMap{name: x.rootName, value: m}
+	}
+	if err := enc.Encode(input); err != nil {
+		return nil, err
+	}
+	b := buf.Bytes()
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c, nil
+}
+
+type xmlMap struct {
+	name  string
+	value map[string]any
+}
+
+func (m xmlMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = m.name
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(m.value))
+	for k := range m.value {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var err error
+		if v, ok := m.value[k].(map[string]any); ok {
+			err = e.Encode(xmlMap{name: k, value: v})
+		} else {
+			err = e.EncodeElement(m.value[k], xml.StartElement{Name: xml.Name{Local: k}})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
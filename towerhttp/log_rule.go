@@ -0,0 +1,172 @@
+package towerhttp
+
+import (
+	"math/rand"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// LogRule decides whether a request is logged by the logger hook or the access log middleware, and how much of the
+// bodies are captured.
+//
+// Requests with 4xx or 5xx response status, and requests slower than the threshold set by SlowerThan, are always logged
+// regardless of Skip and Sample. Sample only applies to requests with 2xx response status.
+//
+// LogRule replaces the FilterRequest and ReadRequestBodyLimit options of the respond hook. Matched rules inherit the
+// request body filter and limit they do not set from the hook's defaults.
+//
+// Example:
+//
+//	towerhttp.NewLoggerHook(Option.RespondHook().Rules(
+//		towerhttp.NewLogRule("/healthz").Skip(),
+//		towerhttp.NewLogRule("/metrics", http.MethodGet).Skip(),
+//		towerhttp.NewLogRule("/api/search").Sample(0.1).SlowerThan(time.Second),
+//		towerhttp.NewLogRule("/api/files/**").RequestBodyLimit(0).ResponseBodyLimit(0),
+//	))
+type LogRule struct {
+	pattern          string
+	methods          []string
+	skip             bool
+	sample           float64
+	slow             time.Duration
+	requestFilter    FilterRequest
+	requestLimit     int
+	hasRequestLimit  bool
+	responseLimit    int
+	hasResponseLimit bool
+}
+
+// NewLogRule creates a rule for requests whose path matches the pattern and whose method is one of the methods.
+//
+// The pattern uses path.Match syntax. A pattern ending with "/**" matches the prefix and every path below it. Empty
+// pattern matches every path. Empty methods match every method.
+func NewLogRule(pattern string, methods ...string) LogRule {
+	upper := make([]string, len(methods))
+	for i, method := range methods {
+		upper[i] = strings.ToUpper(method)
+	}
+	return LogRule{pattern: pattern, methods: upper, sample: 1}
+}
+
+// Skip does not log matched requests with successful responses.
+func (l LogRule) Skip() LogRule {
+	l.skip = true
+	return l
+}
+
+// Sample logs only a fraction of matched requests with 2xx responses. Rate is between 0 and 1, e.g. 0.1 logs 10% of the
+// requests. Requests with other response status are not sampled.
+func (l LogRule) Sample(rate float64) LogRule {
+	l.sample = rate
+	return l
+}
+
+// SlowerThan always logs matched requests that take longer than the threshold, even when they are skipped or not
+// sampled. The latency is measured from the time the request enters the RequestBodyCloner middleware or the access log
+// middleware.
+func (l LogRule) SlowerThan(threshold time.Duration) LogRule {
+	l.slow = threshold
+	return l
+}
+
+// RequestBodyLimit overrides the number of bytes of request body being cloned for matched requests. Zero disables
+// cloning. Negative value clones all the body.
+func (l LogRule) RequestBodyLimit(limit int) LogRule {
+	l.requestLimit = limit
+	l.hasRequestLimit = true
+	return l
+}
+
+// RequestBodyFilter clones the request body of matched requests only when the filter returns true. The default logger
+// hook only clones human readable content types.
+func (l LogRule) RequestBodyFilter(filter FilterRequest) LogRule {
+	l.requestFilter = filter
+	return l
+}
+
+// ResponseBodyLimit overrides the number of bytes of response stream body being cloned for matched requests. Zero
+// disables cloning. Negative value clones all the body.
+func (l LogRule) ResponseBodyLimit(limit int) LogRule {
+	l.responseLimit = limit
+	l.hasResponseLimit = true
+	return l
+}
+
+// Match checks if the rule applies to the request.
+func (l LogRule) Match(r *http.Request) bool {
+	if len(l.methods) > 0 {
+		found := false
+		for _, method := range l.methods {
+			if method == r.Method {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return matchPathPattern(l.pattern, r.URL.Path)
+}
+
+func matchPathPattern(pattern, p string) bool {
+	if pattern == "" {
+		return true
+	}
+	if prefix := strings.TrimSuffix(pattern, "/**"); prefix != pattern {
+		return p == prefix || strings.HasPrefix(p, prefix+"/")
+	}
+	ok, _ := path.Match(pattern, p)
+	return ok
+}
+
+// ShouldLog decides whether the request with the response status and latency is logged.
+func (l LogRule) ShouldLog(status int, latency time.Duration) bool {
+	if status >= http.StatusBadRequest {
+		return true
+	}
+	if l.slow > 0 && latency >= l.slow {
+		return true
+	}
+	if l.skip {
+		return false
+	}
+	if status < http.StatusOK || status >= http.StatusMultipleChoices || l.sample >= 1 {
+		return true
+	}
+	return rand.Float64() < l.sample //nolint:gosec // sampling does not need secure random.
+}
+
+// requestBodySize returns the number of bytes of request body to clone. Settings the rule does not set are taken from
+// the parent rule.
+func (l LogRule) requestBodySize(r *http.Request, parent LogRule) int {
+	filter := l.requestFilter
+	if filter == nil {
+		filter = parent.requestFilter
+	}
+	if filter != nil && !filter(r) {
+		return 0
+	}
+	if l.hasRequestLimit {
+		return l.requestLimit
+	}
+	if parent.hasRequestLimit {
+		return parent.requestLimit
+	}
+	return 0
+}
+
+// LogRules is a list of LogRule. The first rule that matches the request is used.
+type LogRules []LogRule
+
+// Find returns the first rule that matches the request. Returns nil if no rule matches.
+func (rules LogRules) Find(r *http.Request) *LogRule {
+	for i := range rules {
+		if rules[i].Match(r) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package towerhttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+func TestLogRule_Match(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   LogRule
+		method string
+		path   string
+		want   bool
+	}{
+		{"empty pattern", NewLogRule(""), http.MethodGet, "/anything", true},
+		{"exact", NewLogRule("/healthz"), http.MethodGet, "/healthz", true},
+		{"exact mismatch", NewLogRule("/healthz"), http.MethodGet, "/healthz/live", false},
+		{"wildcard", NewLogRule("/users/*/avatar"), http.MethodGet, "/users/1/avatar", true},
+		{"prefix", NewLogRule("/static/**"), http.MethodGet, "/static/js/app.js", true},
+		{"prefix itself", NewLogRule("/static/**"), http.MethodGet, "/static", true},
+		{"prefix mismatch", NewLogRule("/static/**"), http.MethodGet, "/staticfiles", false},
+		{"method", NewLogRule("/metrics", "get"), http.MethodGet, "/metrics", true},
+		{"method mismatch", NewLogRule("/metrics", http.MethodGet), http.MethodPost, "/metrics", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			if got := tt.rule.Match(r); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogRule_ShouldLog(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    LogRule
+		status  int
+		latency time.Duration
+		want    bool
+	}{
+		{"default", NewLogRule(""), http.StatusOK, 0, true},
+		{"skip", NewLogRule("").Skip(), http.StatusOK, 0, false},
+		{"skip client error", NewLogRule("").Skip(), http.StatusNotFound, 0, true},
+		{"skip server error", NewLogRule("").Skip(), http.StatusServiceUnavailable, 0, true},
+		{"skip slow", NewLogRule("").Skip().SlowerThan(time.Second), http.StatusOK, time.Second * 2, true},
+		{"skip fast", NewLogRule("").Skip().SlowerThan(time.Second), http.StatusOK, time.Millisecond, false},
+		{"sample none", NewLogRule("").Sample(0), http.StatusOK, 0, false},
+		{"sample all", NewLogRule("").Sample(1), http.StatusOK, 0, true},
+		{"sample none no content", NewLogRule("").Sample(0), http.StatusNoContent, 0, false},
+		{"sample none redirect", NewLogRule("").Sample(0), http.StatusFound, 0, true},
+		{"sample none not modified", NewLogRule("").Sample(0), http.StatusNotModified, 0, true},
+		{"sample none switching protocols", NewLogRule("").Sample(0), http.StatusSwitchingProtocols, 0, true},
+		{"sample none client error", NewLogRule("").Sample(0), http.StatusBadRequest, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.ShouldLog(tt.status, tt.latency); got != tt.want {
+				t.Errorf("ShouldLog() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLoggerHook_Rules(t *testing.T) {
+	logger := tower.NewTestingJSONLogger()
+	tow := tower.NewTower(tower.Service{Name: "test", Environment: "test", Type: "test"})
+	tow.SetLogger(logger)
+	responder := NewResponder()
+	responder.SetTower(tow)
+	responder.RegisterHook(NewLoggerHook(Option.RespondHook().Rules(
+		NewLogRule("/healthz").Skip(),
+		NewLogRule("/upload", http.MethodPost).RequestBodyLimit(0),
+	)))
+	handler := responder.RequestBodyCloner()(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("fail") != "" {
+			responder.RespondError(rw, r, errors.New("database down"))
+			return
+		}
+		responder.Respond(rw, r, map[string]string{"status": "ok"})
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if logger.String() != "" {
+		t.Fatalf("expected health check to be skipped, got %s", logger.String())
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz?fail=1", nil))
+	if !strings.Contains(logger.String(), "database down") {
+		t.Fatalf("expected failed health check to be logged, got %s", logger.String())
+	}
+	logger.Reset()
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("secret file content"))
+	req.Header.Set("Content-Type", "text/plain")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if logs := logger.String(); logs == "" || strings.Contains(logs, "secret file content") {
+		t.Fatalf("expected upload to be logged without body, got %s", logs)
+	}
+}
+
+func TestRespondHook_AcceptRequestBodySize(t *testing.T) {
+	textOnly := func(r *http.Request) bool { return r.Header.Get("Content-Type") == "text/plain" }
+	tests := []struct {
+		name        string
+		opts        RespondHookOptionBuilder
+		path        string
+		contentType string
+		want        int
+	}{
+		{"no options", Option.RespondHook(), "/", "text/plain", 0},
+		{"deprecated limit", Option.RespondHook().ReadRequestBodyLimit(10), "/", "image/png", 10},
+		{"deprecated filter rejects", Option.RespondHook().FilterRequest(textOnly).ReadRequestBodyLimit(10), "/", "image/png", 0},
+		{"deprecated filter accepts", Option.RespondHook().FilterRequest(textOnly).ReadRequestBodyLimit(10), "/", "text/plain", 10},
+		{
+			"rule inherits deprecated filter",
+			Option.RespondHook().FilterRequest(textOnly).Rules(NewLogRule("/upload").RequestBodyLimit(-1)),
+			"/upload", "image/png", 0,
+		},
+		{
+			"rule inherits deprecated limit",
+			Option.RespondHook().ReadRequestBodyLimit(10).Rules(NewLogRule("/upload").RequestBodyFilter(textOnly)),
+			"/upload", "text/plain", 10,
+		},
+		{
+			"rule overrides deprecated options",
+			Option.RespondHook().FilterRequest(textOnly).ReadRequestBodyLimit(10).
+				Rules(NewLogRule("/upload").RequestBodyFilter(func(*http.Request) bool { return true }).RequestBodyLimit(-1)),
+			"/upload", "image/png", -1,
+		},
+		{
+			"unmatched request uses defaults",
+			Option.RespondHook().ReadRequestBodyLimit(10).Rules(NewLogRule("/upload").RequestBodyLimit(0)),
+			"/other", "text/plain", 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			req.Header.Set("Content-Type", tt.contentType)
+			if got := NewRespondHook(tt.opts).AcceptRequestBodySize(req); got != tt.want {
+				t.Errorf("AcceptRequestBodySize() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLoggerHook_RulesKeepHumanReadableFilter(t *testing.T) {
+	hook := NewLoggerHook(Option.RespondHook().Rules(NewLogRule("/upload").RequestBodyLimit(-1)))
+	req := httptest.NewRequest(http.MethodPost, "/upload", nil)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if got := hook.AcceptRequestBodySize(req); got != 0 {
+		t.Errorf("expected binary body to not be cloned, got limit %d", got)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if got := hook.AcceptRequestBodySize(req); got != -1 {
+		t.Errorf("expected rule limit -1, got %d", got)
+	}
+}
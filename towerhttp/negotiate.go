@@ -0,0 +1,238 @@
+package towerhttp
+
+import (
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// acceptRange is a media range of Accept header.
+type acceptRange struct {
+	mainType string
+	subType  string
+	params   map[string]string
+	quality  float64
+}
+
+// specificity returns how specific the media range is. Higher is more specific.
+func (a acceptRange) specificity() int {
+	switch {
+	case a.mainType == "*":
+		return 0
+	case a.subType == "*":
+		return 1
+	default:
+		return 2 + len(a.params)
+	}
+}
+
+func (a acceptRange) matches(mainType, subType string, params map[string]string) bool {
+	if a.mainType != "*" && a.mainType != mainType {
+		return false
+	}
+	if a.subType != "*" && a.subType != subType {
+		return false
+	}
+	for k, v := range a.params {
+		if !strings.EqualFold(params[k], v) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseAccept parses the value of Accept header. Media ranges are sorted by their quality, highest first.
+// Invalid media ranges are skipped.
+//
+// See https://www.rfc-editor.org/rfc/rfc9110#section-12.5.1 for details.
+func parseAccept(header string) []acceptRange {
+	ranges := make([]acceptRange, 0, 4)
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+		mainType, subType, ok := strings.Cut(mediaType, "/")
+		if !ok || (mainType == "*" && subType != "*") {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+			delete(params, "q")
+		}
+		ranges = append(ranges, acceptRange{
+			mainType: mainType,
+			subType:  subType,
+			params:   params,
+			quality:  quality,
+		})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+	return ranges
+}
+
+// qualityOf returns the quality the client gives to the content type. The most specific matching media range
+// decides the quality. Returns 0 if no media range matches.
+func qualityOf(ranges []acceptRange, contentType string) float64 {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return 0
+	}
+	mainType, subType, _ := strings.Cut(mediaType, "/")
+	var (
+		quality     float64
+		specificity = -1
+	)
+	for _, r := range ranges {
+		if !r.matches(mainType, subType, params) {
+			continue
+		}
+		if s := r.specificity(); s > specificity {
+			specificity = s
+			quality = r.quality
+		}
+	}
+	return quality
+}
+
+// negotiateEncoder picks the encoder the client prefers the most based on Accept header value. On equal preference,
+// the encoder that comes first wins.
+//
+// If accept is empty, the first encoder is returned. Returns false if the client accepts none of the encoders.
+func negotiateEncoder(accept string, encoders []Encoder) (Encoder, bool) {
+	if len(encoders) == 0 {
+		return nil, false
+	}
+	if strings.TrimSpace(accept) == "" {
+		return encoders[0], true
+	}
+	ranges := parseAccept(accept)
+	if len(ranges) == 0 {
+		// Unparsable header. Treat it as if the client accepts anything.
+		return encoders[0], true
+	}
+	var (
+		best        Encoder
+		bestQuality float64
+	)
+	for _, enc := range encoders {
+		if q := qualityOf(ranges, enc.ContentType()); q > bestQuality {
+			best = enc
+			bestQuality = q
+		}
+	}
+	return best, best != nil
+}
+
+// acceptCoding is a content coding of Accept-Encoding header.
+type acceptCoding struct {
+	coding  string
+	quality float64
+}
+
+// parseAcceptEncoding parses the value of Accept-Encoding header. Invalid codings are skipped.
+//
+// See https://www.rfc-editor.org/rfc/rfc9110#section-12.5.3 for details.
+func parseAcceptEncoding(header string) []acceptCoding {
+	codings := make([]acceptCoding, 0, 4)
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = normalizeCoding(coding)
+		if coding == "" {
+			continue
+		}
+		quality := 1.0
+		if params = strings.TrimSpace(params); params != "" {
+			key, value, _ := strings.Cut(params, "=")
+			if !strings.EqualFold(strings.TrimSpace(key), "q") {
+				continue
+			}
+			var err error
+			quality, err = strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+		}
+		codings = append(codings, acceptCoding{coding: coding, quality: quality})
+	}
+	return codings
+}
+
+func normalizeCoding(coding string) string {
+	coding = strings.ToLower(strings.TrimSpace(coding))
+	switch coding {
+	case "x-gzip":
+		return "gzip"
+	case "x-compress":
+		return "compress"
+	}
+	return coding
+}
+
+// codingQuality returns the quality the client gives to the coding and whether the coding is listed explicitly or by
+// the "*" wildcard.
+func codingQuality(codings []acceptCoding, coding string) (float64, bool) {
+	var (
+		wildcard      float64
+		wildcardFound bool
+	)
+	for _, c := range codings {
+		if c.coding == coding {
+			return c.quality, true
+		}
+		if c.coding == "*" {
+			wildcard = c.quality
+			wildcardFound = true
+		}
+	}
+	return wildcard, wildcardFound
+}
+
+// negotiateEncoding picks the content coding the client prefers the most based on Accept-Encoding header values.
+// On equal preference, the candidate that comes first wins. Candidates with empty content encoding are ignored.
+//
+// If the client accepts none of the candidates, identity is returned if the client does not reject it with
+// "identity;q=0" (or "*;q=0" without listing identity). Otherwise, it returns false.
+//
+// If the header is not sent at all, the first candidate is returned.
+func negotiateEncoding[T ContentEncodingHint](header []string, candidates []T, identity T) (T, bool) {
+	if header == nil {
+		if len(candidates) == 0 {
+			return identity, true
+		}
+		return candidates[0], true
+	}
+	codings := parseAcceptEncoding(strings.Join(header, ","))
+	var (
+		best        = identity
+		bestQuality float64
+	)
+	for _, candidate := range candidates {
+		encoding := normalizeCoding(candidate.ContentEncoding())
+		if encoding == "" || encoding == "identity" {
+			continue
+		}
+		if q, _ := codingQuality(codings, encoding); q > bestQuality {
+			best = candidate
+			bestQuality = q
+		}
+	}
+	if bestQuality > 0 {
+		return best, true
+	}
+	if q, listed := codingQuality(codings, "identity"); listed && q == 0 {
+		return identity, false
+	}
+	return identity, true
+}
@@ -0,0 +1,222 @@
+package towerhttp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kinbiko/jsonassert"
+	"github.com/tigorlazuardi/tower"
+)
+
+func Test_negotiateEncoder(t *testing.T) {
+	jsonEncoder := NewJSONEncoder()
+	xmlEncoder := NewXMLEncoder()
+	textEncoder := NewPlainTextEncoder()
+	encoders := []Encoder{jsonEncoder, xmlEncoder, textEncoder}
+	tests := []struct {
+		name   string
+		accept string
+		want   Encoder
+		wantOk bool
+	}{
+		{name: "empty accept uses first encoder", accept: "", want: jsonEncoder, wantOk: true},
+		{name: "exact match", accept: "application/xml", want: xmlEncoder, wantOk: true},
+		{name: "wildcard uses first encoder", accept: "*/*", want: jsonEncoder, wantOk: true},
+		{name: "subtype wildcard", accept: "text/*", want: textEncoder, wantOk: true},
+		{name: "highest quality wins", accept: "application/json;q=0.5, text/plain;q=0.9", want: textEncoder, wantOk: true},
+		{name: "specific range overrides wildcard", accept: "application/*;q=0.8, application/json;q=0.1", want: xmlEncoder, wantOk: true},
+		{name: "zero quality excludes", accept: "application/json;q=0, */*;q=0.1", want: xmlEncoder, wantOk: true},
+		{name: "browser accept", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", want: xmlEncoder, wantOk: true},
+		{name: "nothing matches", accept: "image/png", want: nil, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := negotiateEncoder(tt.accept, encoders)
+			if ok != tt.wantOk {
+				t.Fatalf("negotiateEncoder() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got != tt.want {
+				t.Errorf("negotiateEncoder() = %T, want %T", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponder_RespondNegotiation(t *testing.T) {
+	logger := tower.NewTestingJSONLogger()
+	tow := tower.NewTower(tower.Service{Name: "responder-test", Environment: "testing", Type: "unit-test"})
+	tow.SetLogger(logger)
+	responder := NewResponder()
+	responder.SetTower(tow)
+	responder.AddEncoder(NewXMLEncoder(), NewPlainTextEncoder())
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		responder.Respond(rw, r, map[string]any{"message": "ok"})
+	}))
+	defer server.Close()
+
+	do := func(accept string) (*http.Response, string) {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return resp, string(b)
+	}
+
+	resp, body := do("")
+	if resp.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("expected default json content type, got %s", resp.Header.Get("Content-Type"))
+	}
+	jsonassert.New(t).Assertf(body, `{"message": "ok"}`)
+	if resp.Header.Get("Vary") != "Accept" {
+		t.Errorf("expected Vary: Accept header, got %q", resp.Header.Get("Vary"))
+	}
+
+	resp, body = do("application/xml")
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/xml") {
+		t.Errorf("expected xml content type, got %s", resp.Header.Get("Content-Type"))
+	}
+	if !strings.Contains(body, "<response><message>ok</message></response>") {
+		t.Errorf("unexpected xml body: %s", body)
+	}
+
+	_, body = do("text/plain")
+	if body != "message: ok" {
+		t.Errorf("unexpected text body: %s", body)
+	}
+
+	resp, body = do("image/png")
+	if resp.StatusCode != http.StatusNotAcceptable {
+		t.Errorf("expected status 406, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("expected not acceptable error to use default encoder, got %s", resp.Header.Get("Content-Type"))
+	}
+	jsonassert.New(t).Assertf(body, `{"error": "<<PRESENCE>>"}`)
+}
+
+func TestResponder_RespondNegotiationOverride(t *testing.T) {
+	responder := NewResponder()
+	responder.AddEncoder(NewXMLEncoder())
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		responder.Respond(rw, r, "ok", Option.Respond().Encoder(NewPlainTextEncoder()))
+	}))
+	defer server.Close()
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Accept", "image/png")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected encoder option to skip negotiation, got status %d", resp.StatusCode)
+	}
+}
+
+func TestMessagePackAndCBOREncoder(t *testing.T) {
+	type payload struct {
+		Message string `json:"message"`
+	}
+	for _, enc := range []Encoder{NewMessagePackEncoder(), NewCBOREncoder()} {
+		b, err := enc.Encode(payload{Message: "ok"})
+		if err != nil {
+			t.Fatalf("%T.Encode() error = %v", enc, err)
+		}
+		if !strings.Contains(string(b), "message") {
+			t.Errorf("%T.Encode() expected json tag to be used as key, got %q", enc, b)
+		}
+	}
+}
+
+func Test_negotiateEncoding(t *testing.T) {
+	gz := NewGzipCompression()
+	br := NewBrotliCompression()
+	zs := NewZstdCompression()
+	candidates := []Compressor{gz, br, zs}
+	tests := []struct {
+		name   string
+		header []string
+		want   Compressor
+		wantOk bool
+	}{
+		{name: "no header uses first candidate", header: nil, want: gz, wantOk: true},
+		{name: "empty header means identity", header: []string{""}, want: NoCompression{}, wantOk: true},
+		{name: "exact match", header: []string{"br"}, want: br, wantOk: true},
+		{name: "highest quality wins", header: []string{"gzip;q=0.5, zstd;q=0.8, br;q=0.7"}, want: zs, wantOk: true},
+		{name: "equal quality uses candidate order", header: []string{"zstd, br"}, want: br, wantOk: true},
+		{name: "multiple header values", header: []string{"deflate", "br;q=0.9"}, want: br, wantOk: true},
+		{name: "wildcard", header: []string{"*"}, want: gz, wantOk: true},
+		{name: "wildcard does not override explicit rejection", header: []string{"gzip;q=0, *;q=0.5"}, want: br, wantOk: true},
+		{name: "x-gzip alias", header: []string{"x-gzip"}, want: gz, wantOk: true},
+		{name: "unsupported coding falls back to identity", header: []string{"deflate"}, want: NoCompression{}, wantOk: true},
+		{name: "identity rejected", header: []string{"deflate, identity;q=0"}, want: NoCompression{}, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := negotiateEncoding[Compressor](tt.header, candidates, NoCompression{})
+			if ok != tt.wantOk {
+				t.Fatalf("negotiateEncoding() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got.ContentEncoding() != tt.want.ContentEncoding() {
+				t.Errorf("negotiateEncoding() = %q, want %q", got.ContentEncoding(), tt.want.ContentEncoding())
+			}
+		})
+	}
+}
+
+func TestResponder_RespondEncodingNegotiation(t *testing.T) {
+	responder := NewResponder()
+	responder.SetCompressor(NewGzipCompression())
+	responder.AddCompressor(NewBrotliCompression(), NewZstdCompression())
+	body := map[string]any{"message": strings.Repeat("hello world ", 200)}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		responder.Respond(rw, r, body)
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+
+	do := func(acceptEncoding string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	tests := []struct {
+		acceptEncoding string
+		wantStatus     int
+		wantEncoding   string
+	}{
+		{acceptEncoding: "gzip, br", wantStatus: http.StatusOK, wantEncoding: "gzip"},
+		{acceptEncoding: "br", wantStatus: http.StatusOK, wantEncoding: "br"},
+		{acceptEncoding: "zstd;q=1, gzip;q=0.1", wantStatus: http.StatusOK, wantEncoding: "zstd"},
+		{acceptEncoding: "deflate", wantStatus: http.StatusOK, wantEncoding: ""},
+		{acceptEncoding: "deflate, identity;q=0", wantStatus: http.StatusNotAcceptable, wantEncoding: ""},
+	}
+	for _, tt := range tests {
+		resp := do(tt.acceptEncoding)
+		if resp.StatusCode != tt.wantStatus {
+			t.Errorf("Accept-Encoding %q: expected status %d, got %d", tt.acceptEncoding, tt.wantStatus, resp.StatusCode)
+		}
+		if got := resp.Header.Get("Content-Encoding"); got != tt.wantEncoding {
+			t.Errorf("Accept-Encoding %q: expected Content-Encoding %q, got %q", tt.acceptEncoding, tt.wantEncoding, got)
+		}
+		if got := resp.Header.Get("Vary"); got != "Accept-Encoding" {
+			t.Errorf("Accept-Encoding %q: expected Vary: Accept-Encoding, got %q", tt.acceptEncoding, got)
+		}
+	}
+}
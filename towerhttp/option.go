@@ -13,6 +13,18 @@ func (option) RespondHook() RespondHookOptionBuilder {
 	return RespondHookOptionBuilder{}
 }
 
+func (option) AccessLog() AccessLogOptionBuilder {
+	return AccessLogOptionBuilder{}
+}
+
+func (option) Decode() DecodeOptionBuilder {
+	return DecodeOptionBuilder{}
+}
+
+func (option) Client() ClientOptionBuilder {
+	return ClientOptionBuilder{}
+}
+
 func (option) RoundTripHook() RoundTripHookOptionBuilder {
 	return RoundTripHookOptionBuilder{}
 }
@@ -20,3 +32,23 @@ func (option) RoundTripHook() RoundTripHookOptionBuilder {
 func (option) RoundTrip() RoundTripOptionBuilder {
 	return RoundTripOptionBuilder{}
 }
+
+func (option) Retry() RetryOptionBuilder {
+	return RetryOptionBuilder{}
+}
+
+func (option) CircuitBreaker() CircuitBreakerOptionBuilder {
+	return CircuitBreakerOptionBuilder{}
+}
+
+func (option) Cassette() CassetteOptionBuilder {
+	return CassetteOptionBuilder{}
+}
+
+func (option) RateLimit() RateLimitOptionBuilder {
+	return RateLimitOptionBuilder{}
+}
+
+func (option) WebSocket() WebSocketOptionBuilder {
+	return WebSocketOptionBuilder{}
+}
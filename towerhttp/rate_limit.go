@@ -0,0 +1,282 @@
+package towerhttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/cache"
+)
+
+// ErrRateLimited is the cause of the error responded by the RateLimit middleware when the client exceeds the limit.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimitKeyFunc returns the key to group the requests by. Requests with empty key are not limited.
+type RateLimitKeyFunc = func(r *http.Request) string
+
+// RateLimitAlgorithm is the algorithm to count the requests.
+type RateLimitAlgorithm uint8
+
+const (
+	// RateLimitSlidingWindow weights the count of the previous window by the time left of it, and adds the count of the
+	// current window. Counts are kept with cache.Increment, which is atomic for cache.Cacher that implements
+	// cache.Counter, so the limit is shared safely between application instances.
+	RateLimitSlidingWindow RateLimitAlgorithm = iota
+	// RateLimitTokenBucket refills the bucket of limit tokens evenly over the period, and takes a token for every
+	// request. This allows bursts up to the limit while keeping the average rate. Like the sliding window, the bucket is
+	// updated with cache.Increment only, so the limit is shared safely between application instances.
+	RateLimitTokenBucket
+)
+
+func (r RateLimitAlgorithm) String() string {
+	switch r {
+	case RateLimitSlidingWindow:
+		return "sliding-window"
+	case RateLimitTokenBucket:
+		return "token-bucket"
+	}
+	return "unknown"
+}
+
+// RateLimitByIP groups requests by the IP address of RemoteAddr.
+//
+// Behind a reverse proxy, RemoteAddr is the address of the proxy. Use RateLimitByHeader with the header set by the proxy,
+// like X-Real-Ip, or a custom RateLimitKeyFunc that knows the trusted proxies.
+func RateLimitByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// RateLimitByHeader groups requests by the value of the header, e.g. "X-Api-Key". Requests without the header are not
+// limited.
+func RateLimitByHeader(name string) RateLimitKeyFunc {
+	return func(r *http.Request) string {
+		return r.Header.Get(name)
+	}
+}
+
+type rateLimit struct {
+	responder Responder
+	caller    tower.Caller
+	limit     int64
+	period    time.Duration
+	cache     cache.Cacher
+	prefix    string
+	key       RateLimitKeyFunc
+	filter    FilterRequest
+	algorithm RateLimitAlgorithm
+}
+
+// rateLimitResult is the state of the limit after the request is counted.
+type rateLimitResult struct {
+	allowed    bool
+	remaining  int64
+	reset      time.Duration
+	retryAfter time.Duration
+}
+
+// RateLimit creates a middleware that limits the number of requests to limit per period.
+//
+// Requests are grouped by the IP address by default. See RateLimitByIP, RateLimitByHeader, and Key RateLimitOption.
+//
+// Every limited response has RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, and RateLimit-Policy headers as
+// described in the IETF RateLimit header fields draft. Requests over the limit are responded with RespondError using a
+// tower.Error caused by ErrRateLimited with http.StatusTooManyRequests code, and Retry-After header, so the rejections
+// are logged by the hooks like other errors.
+//
+// The state is kept in cache.LocalCache by default. Set a distributed cache.Cacher, like the Redis implementation, with
+// Cache RateLimitOption to share the limit between application instances. If the cache fails, the error is logged at
+// warn level and the request is let through.
+func (r Responder) RateLimit(limit int, period time.Duration, opts ...RateLimitOption) Middleware {
+	rl := &rateLimit{
+		responder: r,
+		caller:    tower.GetCaller(2),
+		limit:     int64(limit),
+		period:    period,
+		cache:     cache.NewLocalCache(),
+		prefix:    "towerhttp-ratelimit",
+		key:       RateLimitByIP,
+	}
+	for _, opt := range opts {
+		opt.apply(rl)
+	}
+	return rl.middleware
+}
+
+func (rl *rateLimit) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, request *http.Request) {
+		if rl.limit <= 0 || rl.period <= 0 || (rl.filter != nil && !rl.filter(request)) {
+			next.ServeHTTP(rw, request)
+			return
+		}
+		key := rl.key(request)
+		if key == "" {
+			next.ServeHTTP(rw, request)
+			return
+		}
+		ctx := request.Context()
+		var (
+			result rateLimitResult
+			err    error
+		)
+		switch rl.algorithm {
+		case RateLimitTokenBucket:
+			result, err = rl.tokenBucket(ctx, key, time.Now())
+		default:
+			result, err = rl.slidingWindow(ctx, key, time.Now())
+		}
+		if err != nil {
+			_ = rl.responder.tower.Wrap(err).
+				Message("rate limit: failed to count request, letting it through").
+				Caller(rl.caller).
+				Level(tower.WarnLevel).
+				Context(tower.F{"key": key, "algorithm": rl.algorithm.String()}).
+				Log(ctx)
+			next.ServeHTTP(rw, request)
+			return
+		}
+
+		header := rw.Header()
+		header.Set("RateLimit-Limit", strconv.FormatInt(rl.limit, 10))
+		header.Set("RateLimit-Remaining", strconv.FormatInt(result.remaining, 10))
+		header.Set("RateLimit-Reset", strconv.FormatInt(ceilSeconds(result.reset), 10))
+		header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", rl.limit, ceilSeconds(rl.period)))
+		if result.allowed {
+			next.ServeHTTP(rw, request)
+			return
+		}
+		retryAfter := ceilSeconds(result.retryAfter)
+		header.Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+		err = rl.responder.tower.Wrap(ErrRateLimited).
+			Code(http.StatusTooManyRequests).
+			Message("rate limit exceeded, retry after %d seconds", retryAfter).
+			Caller(rl.caller).
+			Context(tower.F{
+				"key":         key,
+				"limit":       rl.limit,
+				"period":      rl.period.String(),
+				"algorithm":   rl.algorithm.String(),
+				"retry_after": retryAfter,
+			}).
+			Freeze()
+		rl.responder.RespondError(rw, request, err)
+	})
+}
+
+func (rl *rateLimit) cacheKey(parts ...string) string {
+	sep := rl.cache.Separator()
+	return rl.prefix + sep + strings.Join(parts, sep)
+}
+
+func (rl *rateLimit) slidingWindow(ctx context.Context, key string, now time.Time) (rateLimitResult, error) {
+	window := now.UnixNano() / int64(rl.period)
+	elapsed := time.Duration(now.UnixNano() % int64(rl.period))
+	currentKey := rl.cacheKey(key, strconv.FormatInt(window, 10))
+	current, err := cache.Increment(ctx, rl.cache, currentKey, 1, 2*rl.period)
+	if err != nil {
+		return rateLimitResult{}, err
+	}
+	var previous int64
+	if b, err := rl.cache.Get(ctx, rl.cacheKey(key, strconv.FormatInt(window-1, 10))); err == nil {
+		// Memcached pads counters with spaces when a decrement shortens them.
+		previous, _ = strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+	}
+
+	weight := 1 - float64(elapsed)/float64(rl.period)
+	count := float64(previous)*weight + float64(current)
+	result := rateLimitResult{reset: rl.period - elapsed}
+	if count <= float64(rl.limit) {
+		result.allowed = true
+		result.remaining = int64(float64(rl.limit) - count)
+		return result, nil
+	}
+	// Rejected requests do not count, so clients that keep retrying are not locked out forever.
+	if _, err := cache.Increment(ctx, rl.cache, currentKey, -1, 2*rl.period); err != nil {
+		return rateLimitResult{}, err
+	}
+	current--
+	if current >= rl.limit {
+		// Wait for the next window, where the current count becomes the previous count weighted down to below the limit.
+		result.retryAfter = rl.period - elapsed + time.Duration(float64(rl.period)*(1-float64(rl.limit-1)/float64(current)))
+	} else {
+		// Wait until the previous window weighs little enough for one more request.
+		result.retryAfter = time.Duration(float64(rl.period)*(1-float64(rl.limit-current-1)/float64(previous))) - elapsed
+	}
+	if result.retryAfter < time.Second {
+		result.retryAfter = time.Second
+	}
+	return result, nil
+}
+
+// tokenBucket implements the token bucket as the generic cell rate algorithm. The cache keeps the theoretical arrival
+// time (TAT) of the bucket in Unix nanoseconds, which moves forward by the emission interval (period / limit) for every
+// request, so the TAT is only ever moved with cache.Increment. The bucket is full when the TAT is in the past.
+//
+// The key expires a period after the last request, which is never before the TAT, so caches that round the expiry to
+// whole seconds work too.
+func (rl *rateLimit) tokenBucket(ctx context.Context, key string, now time.Time) (rateLimitResult, error) {
+	bucketKey := rl.cacheKey(key)
+	interval := int64(rl.period) / rl.limit
+	if interval < 1 {
+		interval = 1
+	}
+	nanos := now.UnixNano()
+	tat, err := cache.Increment(ctx, rl.cache, bucketKey, interval, rl.period)
+	if err != nil {
+		return rateLimitResult{}, err
+	}
+	// debt is how far the TAT is ahead of now, or the time until the bucket is full again.
+	var debt int64
+	switch {
+	case tat == interval:
+		// This request creates the bucket. Anchor the TAT to now, keeping the intervals added by the racing requests.
+		if _, err := cache.Increment(ctx, rl.cache, bucketKey, nanos, rl.period); err != nil {
+			return rateLimitResult{}, err
+		}
+		debt = interval
+	case tat < nanos/2:
+		// A racing request is creating the bucket and anchors the TAT. tat is the position of this request in it.
+		debt = tat
+	case tat <= nanos:
+		// The TAT is in the past, so the bucket is full. The TAT can not be moved to now with increments without racing
+		// other requests, so the key is deleted and the request takes its token from a new bucket.
+		rl.cache.Delete(ctx, bucketKey)
+		return rl.tokenBucket(ctx, key, now)
+	default:
+		debt = tat - nanos
+	}
+
+	if debt <= int64(rl.period) {
+		return rateLimitResult{
+			allowed:   true,
+			remaining: (int64(rl.period) - debt) / interval,
+			reset:     time.Duration(debt),
+		}, nil
+	}
+	// Rejected requests do not take a token.
+	if _, err := cache.Increment(ctx, rl.cache, bucketKey, -interval, rl.period); err != nil {
+		return rateLimitResult{}, err
+	}
+	debt -= interval
+	return rateLimitResult{
+		reset:      time.Duration(debt),
+		retryAfter: time.Duration(debt + interval - int64(rl.period)),
+	}, nil
+}
+
+func ceilSeconds(d time.Duration) int64 {
+	if d <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(d.Seconds()))
+}
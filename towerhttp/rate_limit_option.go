@@ -0,0 +1,60 @@
+package towerhttp
+
+import "github.com/tigorlazuardi/tower/cache"
+
+type RateLimitOption interface {
+	apply(*rateLimit)
+}
+
+type (
+	RateLimitOptionBuilder []RateLimitOption
+	rateLimitOptionFunc    func(*rateLimit)
+)
+
+func (r rateLimitOptionFunc) apply(rl *rateLimit) {
+	r(rl)
+}
+
+func (r RateLimitOptionBuilder) apply(rl *rateLimit) {
+	for _, v := range r {
+		v.apply(rl)
+	}
+}
+
+// Cache sets the cache.Cacher to keep the state of the limits. Defaults to cache.LocalCache, which is not shared
+// between application instances.
+func (r RateLimitOptionBuilder) Cache(c cache.Cacher) RateLimitOptionBuilder {
+	return append(r, rateLimitOptionFunc(func(rl *rateLimit) {
+		rl.cache = c
+	}))
+}
+
+// Prefix sets the prefix of the cache keys. Defaults to "towerhttp-ratelimit". Use different prefixes for middlewares
+// that share the same cache.Cacher but have different limits.
+func (r RateLimitOptionBuilder) Prefix(prefix string) RateLimitOptionBuilder {
+	return append(r, rateLimitOptionFunc(func(rl *rateLimit) {
+		rl.prefix = prefix
+	}))
+}
+
+// Key sets the function to group the requests by. Defaults to RateLimitByIP.
+func (r RateLimitOptionBuilder) Key(key RateLimitKeyFunc) RateLimitOptionBuilder {
+	return append(r, rateLimitOptionFunc(func(rl *rateLimit) {
+		rl.key = key
+	}))
+}
+
+// Filter filters requests to be limited. Return false to let the request through without counting it. Defaults to
+// limit all requests.
+func (r RateLimitOptionBuilder) Filter(filter FilterRequest) RateLimitOptionBuilder {
+	return append(r, rateLimitOptionFunc(func(rl *rateLimit) {
+		rl.filter = filter
+	}))
+}
+
+// Algorithm sets the algorithm to count the requests. Defaults to RateLimitSlidingWindow.
+func (r RateLimitOptionBuilder) Algorithm(algorithm RateLimitAlgorithm) RateLimitOptionBuilder {
+	return append(r, rateLimitOptionFunc(func(rl *rateLimit) {
+		rl.algorithm = algorithm
+	}))
+}
@@ -0,0 +1,229 @@
+package towerhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/cache"
+)
+
+func TestResponder_RateLimit(t *testing.T) {
+	for _, algorithm := range []RateLimitAlgorithm{RateLimitSlidingWindow, RateLimitTokenBucket} {
+		t.Run(algorithm.String(), func(t *testing.T) {
+			responder := NewResponder()
+			var hookErr error
+			responder.RegisterHook(NewRespondHook(Option.RespondHook().OnRespondError(func(ctx *RespondErrorHookContext) {
+				hookErr = ctx.ResponseBody.PreEncoded
+			})))
+			handler := responder.RateLimit(2, time.Minute, Option.RateLimit().Algorithm(algorithm))(
+				http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+					rw.WriteHeader(http.StatusNoContent)
+				}),
+			)
+			do := func(remoteAddr string) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.RemoteAddr = remoteAddr
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+				return rec
+			}
+
+			for i, wantRemaining := range []string{"1", "0"} {
+				rec := do("10.0.0.1:1234")
+				if rec.Code != http.StatusNoContent {
+					t.Fatalf("request %d: expected status 204, got %d", i+1, rec.Code)
+				}
+				if got := rec.Header().Get("RateLimit-Remaining"); got != wantRemaining {
+					t.Errorf("request %d: expected RateLimit-Remaining %s, got %s", i+1, wantRemaining, got)
+				}
+			}
+
+			rec := do("10.0.0.1:5678")
+			if rec.Code != http.StatusTooManyRequests {
+				t.Fatalf("expected status 429, got %d", rec.Code)
+			}
+			if got := rec.Header().Get("RateLimit-Limit"); got != "2" {
+				t.Errorf("expected RateLimit-Limit 2, got %s", got)
+			}
+			if got := rec.Header().Get("RateLimit-Policy"); got != "2;w=60" {
+				t.Errorf("expected RateLimit-Policy 2;w=60, got %s", got)
+			}
+			retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+			if err != nil || retryAfter < 1 || retryAfter > 120 {
+				t.Errorf("expected Retry-After between 1 and 120 seconds, got %q", rec.Header().Get("Retry-After"))
+			}
+			if !strings.Contains(rec.Body.String(), "rate limit exceeded") {
+				t.Errorf("unexpected body: %s", rec.Body.String())
+			}
+			if tower.Query.GetHTTPCode(hookErr) != http.StatusTooManyRequests {
+				t.Errorf("expected hook to receive error with 429 code, got %v", hookErr)
+			}
+
+			if rec := do("10.0.0.2:1234"); rec.Code != http.StatusNoContent {
+				t.Errorf("expected other clients to be allowed, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestResponder_RateLimitKeyByHeader(t *testing.T) {
+	handler := NewResponder().RateLimit(1, time.Minute, Option.RateLimit().Key(RateLimitByHeader("X-Api-Key")))(
+		http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}),
+	)
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if rec.Code != http.StatusOK || rec.Header().Get("RateLimit-Limit") != "" {
+			t.Fatalf("expected requests without key not to be limited, got %d", rec.Code)
+		}
+	}
+	var codes []int
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Api-Key", "secret")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		codes = append(codes, rec.Code)
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("expected [200 429], got %v", codes)
+	}
+}
+
+func TestRateLimit_slidingWindow(t *testing.T) {
+	rl := &rateLimit{limit: 10, period: time.Minute, cache: cache.NewLocalCache(), prefix: "test"}
+	ctx := context.Background()
+	window := time.Unix(0, 0).Add(100 * time.Minute)
+
+	for i := 0; i < 10; i++ {
+		if res, _ := rl.slidingWindow(ctx, "k", window.Add(50*time.Second)); !res.allowed {
+			t.Fatalf("request %d: expected to be allowed", i+1)
+		}
+	}
+	res, _ := rl.slidingWindow(ctx, "k", window.Add(50*time.Second))
+	if res.allowed {
+		t.Fatal("expected to be rejected in the same window")
+	}
+	// Count is 10 in the next window, so one more request fits when the previous window weighs 90% or less, which is
+	// 6 seconds into the next window.
+	if got := ceilSeconds(res.retryAfter); got != 16 {
+		t.Errorf("expected retry after 16s, got %s", res.retryAfter)
+	}
+
+	// At 15 seconds into the next window, the previous window weighs 10 * 0.75 = 7.5.
+	next := window.Add(time.Minute + 15*time.Second)
+	for i := 0; i < 2; i++ {
+		if res, _ := rl.slidingWindow(ctx, "k", next); !res.allowed {
+			t.Fatalf("request %d in the next window: expected to be allowed", i+1)
+		}
+	}
+	res, _ = rl.slidingWindow(ctx, "k", next)
+	if res.allowed {
+		t.Fatal("expected to be rejected when the weighted count exceeds the limit")
+	}
+	if res.remaining != 0 || res.reset != 45*time.Second {
+		t.Errorf("expected remaining 0 and reset 45s, got %d and %s", res.remaining, res.reset)
+	}
+}
+
+func TestRateLimit_tokenBucket(t *testing.T) {
+	rl := &rateLimit{limit: 2, period: 10 * time.Second, cache: cache.NewLocalCache(), prefix: "test"}
+	ctx := context.Background()
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		if res, _ := rl.tokenBucket(ctx, "k", now); !res.allowed {
+			t.Fatalf("request %d: expected to be allowed", i+1)
+		}
+	}
+	res, _ := rl.tokenBucket(ctx, "k", now)
+	if res.allowed || res.retryAfter != 5*time.Second {
+		t.Fatalf("expected to be rejected with retry after 5s, got %+v", res)
+	}
+	if res, _ := rl.tokenBucket(ctx, "k", now.Add(5*time.Second)); !res.allowed {
+		t.Fatal("expected a token to be refilled after 5s")
+	}
+}
+
+func TestRateLimit_tokenBucketSharedCache(t *testing.T) {
+	shared := cache.NewLocalCache()
+	instances := []*rateLimit{
+		{limit: 20, period: time.Minute, cache: shared, prefix: "test"},
+		{limit: 20, period: time.Minute, cache: shared, prefix: "test"},
+	}
+	ctx := context.Background()
+	var (
+		wg      sync.WaitGroup
+		allowed int32
+	)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(rl *rateLimit) {
+			defer wg.Done()
+			if res, err := rl.tokenBucket(ctx, "k", time.Now()); err == nil && res.allowed {
+				atomic.AddInt32(&allowed, 1)
+			}
+		}(instances[i%2])
+	}
+	wg.Wait()
+	if allowed != 20 {
+		t.Errorf("expected 20 requests to be allowed between instances, got %d", allowed)
+	}
+}
+
+// wholeSecondCounter rounds expiries up to whole seconds, like memcached does.
+type wholeSecondCounter struct{ *cache.LocalCache }
+
+func (c wholeSecondCounter) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.LocalCache.Set(ctx, key, value, time.Duration(ceilSeconds(ttl))*time.Second)
+}
+
+func (c wholeSecondCounter) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	return c.LocalCache.Increment(ctx, key, delta, time.Duration(ceilSeconds(ttl))*time.Second)
+}
+
+func TestRateLimit_tokenBucketWholeSecondExpiry(t *testing.T) {
+	rl := &rateLimit{limit: 2, period: 200 * time.Millisecond, cache: wholeSecondCounter{cache.NewLocalCache()}, prefix: "test"}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if res, _ := rl.tokenBucket(ctx, "k", time.Now()); !res.allowed {
+			t.Fatalf("request %d: expected to be allowed", i+1)
+		}
+	}
+	if res, _ := rl.tokenBucket(ctx, "k", time.Now()); res.allowed {
+		t.Fatal("expected to be rejected")
+	}
+	// The key outlives the TAT, because the expiry is rounded up to a second.
+	time.Sleep(300 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		res, _ := rl.tokenBucket(ctx, "k", time.Now())
+		if !res.allowed || res.remaining != int64(1-i) {
+			t.Fatalf("request %d after the refill: expected to be allowed with %d remaining, got %+v", i+1, 1-i, res)
+		}
+	}
+	if res, _ := rl.tokenBucket(ctx, "k", time.Now()); res.allowed {
+		t.Fatal("expected to be rejected after the refilled bucket is empty")
+	}
+}
+
+func TestRateLimit_slidingWindowPaddedCounter(t *testing.T) {
+	rl := &rateLimit{limit: 10, period: time.Minute, cache: cache.NewLocalCache(), prefix: "test"}
+	ctx := context.Background()
+	window := time.Unix(0, 0).Add(100 * time.Minute)
+	// Memcached keeps the length of a counter on decrement and pads it with spaces.
+	previousKey := rl.cacheKey("k") + rl.cache.Separator() + strconv.FormatInt(window.UnixNano()/int64(rl.period)-1, 10)
+	_ = rl.cache.Set(ctx, previousKey, []byte("10 "), time.Hour)
+
+	if res, _ := rl.slidingWindow(ctx, "k", window); res.allowed {
+		t.Fatal("expected the padded previous window count to be used")
+	}
+}
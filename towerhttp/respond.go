@@ -3,20 +3,25 @@ package towerhttp
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/tigorlazuardi/tower"
 )
 
 // Responder handles the response and writing to http.ResponseWriter.
 type Responder struct {
-	encoder          Encoder
-	transformer      BodyTransformer
-	errorTransformer ErrorBodyTransformer
-	tower            *tower.Tower
-	compressor       Compressor
-	streamCompressor StreamCompressor
-	callerDepth      int
-	hooks            RespondHookList
+	encoder           Encoder
+	encoders          []Encoder
+	transformer       BodyTransformer
+	errorTransformer  ErrorBodyTransformer
+	tower             *tower.Tower
+	compressor        Compressor
+	compressors       []Compressor
+	streamCompressor  StreamCompressor
+	streamCompressors []StreamCompressor
+	callerDepth       int
+	hooks             RespondHookList
+	serverTiming      bool
 }
 
 // NewResponder creates a new Responder instance.
@@ -45,16 +50,48 @@ func NewResponder() *Responder {
 	}
 }
 
+// Tower returns the tower instance used by the Responder.
+func (r Responder) Tower() *tower.Tower {
+	return r.tower
+}
+
 // SetErrorTransformer sets the ErrorBodyTransformer to be used by the Responder.
 func (r *Responder) SetErrorTransformer(errorTransformer ErrorBodyTransformer) {
 	r.errorTransformer = errorTransformer
 }
 
-// SetEncoder sets the Encoder to be used by the Responder.
+// SetEncoder sets the default Encoder to be used by the Responder.
+//
+// The default Encoder is used when the request has no Accept header, and takes precedence over encoders registered by
+// AddEncoder when the client gives them equal preference.
 func (r *Responder) SetEncoder(encoder Encoder) {
 	r.encoder = encoder
 }
 
+// AddEncoder registers encoders the Responder can choose from based on the request's Accept header. Registered encoder
+// with the same media type as the given encoder is replaced.
+//
+// When the client accepts none of the encoders, including the default one, Respond responds with
+// http.StatusNotAcceptable using RespondError, which always uses the default Encoder.
+//
+// Encoder set by RespondOption takes precedence over the negotiated one.
+func (r *Responder) AddEncoder(encoders ...Encoder) {
+	for _, encoder := range encoders {
+		mediaType := baseMediaType(encoder.ContentType())
+		replaced := false
+		for i, registered := range r.encoders {
+			if baseMediaType(registered.ContentType()) == mediaType {
+				r.encoders[i] = encoder
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			r.encoders = append(r.encoders, encoder)
+		}
+	}
+}
+
 // SetBodyTransformer sets the BodyTransformer to be used by the Responder.
 func (r *Responder) SetBodyTransformer(transform BodyTransformer) {
 	r.transformer = transform
@@ -65,11 +102,57 @@ func (r *Responder) SetTower(t *tower.Tower) {
 	r.tower = t
 }
 
-// SetCompressor sets the compression to be used by the Responder.
+// SetCompressor sets the default compression to be used by the Responder.
+//
+// The default compression is used when the request has no Accept-Encoding header, and takes precedence over
+// compressions registered by AddCompressor when the client gives them equal preference. Like registered compressions,
+// it's not used when the client does not accept its content coding.
 func (r *Responder) SetCompressor(compressor Compressor) {
 	r.compressor = compressor
 }
 
+// AddCompressor registers compressions the Responder can choose from based on the request's Accept-Encoding header.
+// Registered compression with the same content coding as the given compression is replaced.
+//
+// If the compression also implements StreamCompressor, it's registered with AddStreamCompressor as well.
+//
+// If the client accepts none of the compressions, the response is not compressed. If the client also rejects
+// uncompressed response with "identity;q=0", Respond responds with http.StatusNotAcceptable using RespondError.
+func (r *Responder) AddCompressor(compressors ...Compressor) {
+	for _, compressor := range compressors {
+		r.compressors = addContentEncoding(r.compressors, compressor)
+		if sc, ok := compressor.(StreamCompressor); ok {
+			r.streamCompressors = addContentEncoding(r.streamCompressors, sc)
+		}
+	}
+}
+
+// AddStreamCompressor registers stream compressions the Responder can choose from based on the request's
+// Accept-Encoding header. Registered stream compression with the same content coding as the given one is replaced.
+func (r *Responder) AddStreamCompressor(compressors ...StreamCompressor) {
+	for _, compressor := range compressors {
+		r.streamCompressors = addContentEncoding(r.streamCompressors, compressor)
+	}
+}
+
+func addContentEncoding[T ContentEncodingHint](list []T, item T) []T {
+	for i, registered := range list {
+		if registered.ContentEncoding() == item.ContentEncoding() {
+			list[i] = item
+			return list
+		}
+	}
+	return append(list, item)
+}
+
+// SetServerTiming enables the Server-Timing response header. The header contains the time spent by the handler ("app"),
+// encoding the body ("encode"), and compressing the body ("compress").
+//
+// Do not enable this for public facing services if the timing information should not be exposed to clients.
+func (r *Responder) SetServerTiming(enabled bool) {
+	r.serverTiming = enabled
+}
+
 // SetCallerDepth sets the caller depth to be used to get caller function by the Responder.
 func (r *Responder) SetCallerDepth(depth int) {
 	r.callerDepth = depth
@@ -81,23 +164,126 @@ func (r *Responder) SetStreamCompressor(streamCompressor StreamCompressor) {
 }
 
 func (r Responder) buildOption(statusCode int, request *http.Request, opts ...RespondOption) *RespondContext {
+	encoder, acceptable := r.negotiateEncoder(request)
+	compressor, compressorAcceptable := r.negotiateCompressor(request)
+	streamCompressor, streamCompressorAcceptable := r.negotiateStreamCompressor(request)
 	opt := &RespondContext{
-		Encoder:              r.encoder,
-		BodyTransformer:      r.transformer,
-		Compressor:           r.compressor,
-		StatusCode:           statusCode,
-		ErrorBodyTransformer: r.errorTransformer,
-		CallerDepth:          r.callerDepth,
-		StreamCompressor:     r.streamCompressor,
+		Encoder:                 encoder,
+		BodyTransformer:         r.transformer,
+		Compressor:              compressor,
+		StatusCode:              statusCode,
+		ErrorBodyTransformer:    r.errorTransformer,
+		CallerDepth:             r.callerDepth,
+		StreamCompressor:        streamCompressor,
+		notAcceptable:           !acceptable,
+		compressorNotAcceptable: !compressorAcceptable,
+		streamNotAcceptable:     !streamCompressorAcceptable,
 	}
 	for _, o := range opts {
 		o.Apply(opt)
 	}
 	opt.Caller = tower.GetCaller(opt.CallerDepth + 1)
-	for _, hook := range r.hooks {
-		opt = hook.BeforeRespond(opt, request)
+	return r.hooks.beforeRespond(r.tower, opt, request)
+}
+
+// shortCircuit responds with the error set to RespondContext.ShortCircuit by BeforeRespond hooks. Returns false if
+// there is none.
+func (r Responder) shortCircuit(rw http.ResponseWriter, request *http.Request, opt *RespondContext) bool {
+	if opt.ShortCircuit == nil {
+		return false
+	}
+	r.respondError(rw, request, opt.ShortCircuit, opt)
+	return true
+}
+
+// negotiateEncoder picks the encoder based on the request's Accept header. If the client accepts none of the encoders,
+// the default encoder is returned alongside false.
+func (r Responder) negotiateEncoder(request *http.Request) (Encoder, bool) {
+	if len(r.encoders) == 0 || request == nil {
+		return r.encoder, true
+	}
+	encoders := make([]Encoder, 0, len(r.encoders)+1)
+	encoders = append(encoders, r.encoder)
+	defaultMediaType := baseMediaType(r.encoder.ContentType())
+	for _, encoder := range r.encoders {
+		if baseMediaType(encoder.ContentType()) != defaultMediaType {
+			encoders = append(encoders, encoder)
+		}
+	}
+	encoder, ok := negotiateEncoder(request.Header.Get("Accept"), encoders)
+	if !ok {
+		return r.encoder, false
+	}
+	return encoder, true
+}
+
+// negotiateCompressor picks the compressor based on the request's Accept-Encoding header. If the client rejects all
+// the compressors and identity, NoCompression is returned alongside false.
+func (r Responder) negotiateCompressor(request *http.Request) (Compressor, bool) {
+	if len(r.compressors) == 0 && !hasContentEncoding(r.compressor) {
+		return r.compressor, true
 	}
-	return opt
+	candidates := make([]Compressor, 0, len(r.compressors)+1)
+	if r.compressor != nil {
+		candidates = append(candidates, r.compressor)
+	}
+	candidates = append(candidates, r.compressors...)
+	return negotiateEncoding[Compressor](acceptEncodingHeader(request), candidates, NoCompression{})
+}
+
+// negotiateStreamCompressor is like negotiateCompressor but for StreamCompressor.
+func (r Responder) negotiateStreamCompressor(request *http.Request) (StreamCompressor, bool) {
+	if len(r.streamCompressors) == 0 && !hasContentEncoding(r.streamCompressor) {
+		return r.streamCompressor, true
+	}
+	candidates := make([]StreamCompressor, 0, len(r.streamCompressors)+1)
+	if r.streamCompressor != nil {
+		candidates = append(candidates, r.streamCompressor)
+	}
+	candidates = append(candidates, r.streamCompressors...)
+	return negotiateEncoding[StreamCompressor](acceptEncodingHeader(request), candidates, NoCompression{})
+}
+
+func hasContentEncoding(hint ContentEncodingHint) bool {
+	return hint != nil && hint.ContentEncoding() != ""
+}
+
+func acceptEncodingHeader(request *http.Request) []string {
+	if request == nil {
+		return nil
+	}
+	return request.Header.Values("Accept-Encoding")
+}
+
+// setVary adds the request headers the response varies on to the Vary header.
+func (r Responder) setVary(header http.Header, stream bool) {
+	if len(r.encoders) > 0 && !stream {
+		addVary(header, "Accept")
+	}
+	if stream {
+		if len(r.streamCompressors) > 0 || hasContentEncoding(r.streamCompressor) {
+			addVary(header, "Accept-Encoding")
+		}
+	} else if len(r.compressors) > 0 || hasContentEncoding(r.compressor) {
+		addVary(header, "Accept-Encoding")
+	}
+}
+
+func addVary(header http.Header, value string) {
+	for _, v := range header.Values("Vary") {
+		for _, field := range strings.Split(v, ",") {
+			field = strings.TrimSpace(field)
+			if field == "*" || strings.EqualFold(field, value) {
+				return
+			}
+		}
+	}
+	header.Add("Vary", value)
+}
+
+func baseMediaType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
 }
 
 var requestBodyKey = struct{ key int }{777}
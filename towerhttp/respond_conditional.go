@@ -0,0 +1,78 @@
+package towerhttp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// generateETag returns a strong ETag from the hash of the encoded body. The content coding is appended, because strong
+// validators must differ between representations.
+func generateETag(body []byte, contentEncoding string) string {
+	sum := sha256.Sum256(body)
+	tag := hex.EncodeToString(sum[:16])
+	if contentEncoding != "" {
+		tag += "-" + contentEncoding
+	}
+	return `"` + tag + `"`
+}
+
+// quoteETag quotes the tag if it is not quoted yet. Weak tags are kept as is.
+func quoteETag(tag string) string {
+	if strings.HasPrefix(tag, `"`) || strings.HasPrefix(tag, `W/"`) {
+		return tag
+	}
+	return `"` + tag + `"`
+}
+
+// etagWeakMatch reports whether the header, a list of entity tags like If-None-Match, contains the tag using the weak
+// comparison, where W/"a" matches "a".
+func etagWeakMatch(header, tag string) bool {
+	tag = strings.TrimPrefix(tag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// etagStrongMatch reports whether both tags are the same strong tag.
+func etagStrongMatch(a, b string) bool {
+	return a == b && !strings.HasPrefix(a, "W/") && a != ""
+}
+
+// setCacheHeaders sets Cache-Control, Last-Modified, and ETag headers from the RespondContext.
+func setCacheHeaders(header http.Header, opt *RespondContext, etag string) {
+	if opt.CacheControl != "" {
+		header.Set("Cache-Control", opt.CacheControl)
+	}
+	if !opt.LastModified.IsZero() {
+		header.Set("Last-Modified", opt.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if etag != "" {
+		header.Set("ETag", etag)
+	}
+}
+
+// notModified evaluates If-None-Match and If-Modified-Since headers of GET and HEAD requests as described in RFC 9110
+// section 13.2.2. If-Modified-Since is ignored when If-None-Match is present.
+func notModified(request *http.Request, etag string, lastModified time.Time) bool {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		return false
+	}
+	if inm := request.Header.Get("If-None-Match"); inm != "" {
+		return etag != "" && etagWeakMatch(inm, etag)
+	}
+	if lastModified.IsZero() {
+		return false
+	}
+	ims, err := http.ParseTime(request.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(ims)
+}
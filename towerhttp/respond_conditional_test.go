@@ -0,0 +1,131 @@
+package towerhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResponder_RespondConditional(t *testing.T) {
+	modified := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	body := map[string]string{"ok": "ok"}
+	etag := generateETag([]byte(`{"ok":"ok"}`+"\n"), "")
+
+	tests := []struct {
+		name         string
+		method       string
+		header       http.Header
+		opts         RespondOptionBuilder
+		status       int
+		etag         string
+		lastModified string
+		cacheControl string
+	}{
+		{
+			name:   "generated etag without condition",
+			method: http.MethodGet,
+			opts:   Option.Respond().GenerateETag().CacheControl("private, max-age=60"),
+			status: http.StatusOK, etag: etag, cacheControl: "private, max-age=60",
+		},
+		{
+			name:   "generated etag matches If-None-Match",
+			method: http.MethodGet,
+			header: http.Header{"If-None-Match": {`"other", ` + etag}},
+			opts:   Option.Respond().GenerateETag(),
+			status: http.StatusNotModified, etag: etag,
+		},
+		{
+			name:   "weak comparison of explicit etag",
+			method: http.MethodGet,
+			header: http.Header{"If-None-Match": {`W/"v1"`}},
+			opts:   Option.Respond().ETag("v1"),
+			status: http.StatusNotModified, etag: `"v1"`,
+		},
+		{
+			name:   "etag mismatch",
+			method: http.MethodGet,
+			header: http.Header{"If-None-Match": {`"v2"`}},
+			opts:   Option.Respond().ETag("v1"),
+			status: http.StatusOK, etag: `"v1"`,
+		},
+		{
+			name:   "not modified since",
+			method: http.MethodGet,
+			header: http.Header{"If-Modified-Since": {modified.Format(http.TimeFormat)}},
+			opts:   Option.Respond().LastModified(modified.Add(500 * time.Millisecond)),
+			status: http.StatusNotModified, lastModified: modified.Format(http.TimeFormat),
+		},
+		{
+			name:   "modified since",
+			method: http.MethodGet,
+			header: http.Header{"If-Modified-Since": {modified.Add(-time.Hour).Format(http.TimeFormat)}},
+			opts:   Option.Respond().LastModified(modified),
+			status: http.StatusOK, lastModified: modified.Format(http.TimeFormat),
+		},
+		{
+			name:   "If-None-Match takes precedence over If-Modified-Since",
+			method: http.MethodGet,
+			header: http.Header{"If-None-Match": {`"v2"`}, "If-Modified-Since": {modified.Format(http.TimeFormat)}},
+			opts:   Option.Respond().ETag("v1").LastModified(modified),
+			status: http.StatusOK, etag: `"v1"`, lastModified: modified.Format(http.TimeFormat),
+		},
+		{
+			name:   "unsafe method is not conditional",
+			method: http.MethodPost,
+			header: http.Header{"If-None-Match": {"*"}},
+			opts:   Option.Respond().ETag("v1"),
+			status: http.StatusOK, etag: `"v1"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responder := NewResponder()
+			var hookStatus int
+			var hookBody []byte
+			responder.RegisterHook(NewRespondHook(Option.RespondHook().OnRespond(func(ctx *RespondHookContext) {
+				hookStatus = ctx.ResponseStatus
+				hookBody = ctx.ResponseBody.PostEncoded
+			})))
+			req := httptest.NewRequest(tt.method, "/", nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			rec := httptest.NewRecorder()
+			responder.Respond(rec, req, body, tt.opts)
+
+			if rec.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			if hookStatus != tt.status {
+				t.Errorf("expected hook to see status %d, got %d", tt.status, hookStatus)
+			}
+			if got := rec.Header().Get("ETag"); got != tt.etag {
+				t.Errorf("expected ETag %q, got %q", tt.etag, got)
+			}
+			if got := rec.Header().Get("Last-Modified"); got != tt.lastModified {
+				t.Errorf("expected Last-Modified %q, got %q", tt.lastModified, got)
+			}
+			if got := rec.Header().Get("Cache-Control"); got != tt.cacheControl {
+				t.Errorf("expected Cache-Control %q, got %q", tt.cacheControl, got)
+			}
+			if tt.status == http.StatusNotModified {
+				if rec.Body.Len() != 0 || len(hookBody) != 0 {
+					t.Errorf("expected no body, got %q and hook body %q", rec.Body.String(), hookBody)
+				}
+				if rec.Header().Get("Content-Type") != "" {
+					t.Errorf("expected no Content-Type, got %q", rec.Header().Get("Content-Type"))
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateETag(t *testing.T) {
+	if generateETag([]byte("a"), "") == generateETag([]byte("a"), "gzip") {
+		t.Error("expected different tags for different content codings")
+	}
+	if generateETag([]byte("a"), "") != generateETag([]byte("a"), "") {
+		t.Error("expected stable tags")
+	}
+}
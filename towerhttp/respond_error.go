@@ -3,7 +3,7 @@ package towerhttp
 import (
 	"io"
 	"net/http"
-	"strconv"
+	"time"
 
 	"github.com/tigorlazuardi/tower"
 )
@@ -28,19 +28,30 @@ const errInternalServerError errString = "Internal Server Error"
 // assumes that you mishandled the method and to prevent sending empty values, a generic Internal Server Error message
 // will be sent instead. If you wish to send an empty response, use Respond with http.NoBody as body.
 func (r Responder) RespondError(rw http.ResponseWriter, request *http.Request, errPayload error, opts ...RespondOption) {
+	opt := r.buildOption(tower.Query.GetHTTPCode(errPayload), request, opts...)
+	r.respondError(rw, request, errPayload, opt)
+}
+
+// respondError writes the error with the RespondContext that BeforeRespond hooks already ran on, so the responders
+// can respond with an error without running the hooks again.
+func (r Responder) respondError(rw http.ResponseWriter, request *http.Request, errPayload error, opt *RespondContext) {
 	var (
 		ctx            = request.Context()
 		encodedBody    []byte
 		err            error
-		statusCode     = tower.Query.GetHTTPCode(errPayload)
 		compressedBody []byte
+		timing         = newRespondTiming(request)
 	)
 	if errPayload == nil {
 		errPayload = errInternalServerError
 	}
-	opt := r.buildOption(statusCode, request, opts...)
+	if opt.ShortCircuit != nil {
+		errPayload = opt.ShortCircuit
+		opt.StatusCode = tower.Query.GetHTTPCode(errPayload)
+	}
 	if len(r.hooks) > 0 {
 		defer func() {
+			timing.finish()
 			var requestBody ClonedBody = NoopCloneBody{}
 			if b, ok := request.Body.(ClonedBody); ok {
 				requestBody = b
@@ -56,6 +67,7 @@ func (r Responder) RespondError(rw http.ResponseWriter, request *http.Request, e
 					ResponseHeader: rw.Header(),
 					Tower:          r.tower,
 					Error:          err,
+					Timing:         timing,
 				},
 				ResponseBody: RespondErrorBody{
 					PreEncoded:     errPayload,
@@ -63,17 +75,25 @@ func (r Responder) RespondError(rw http.ResponseWriter, request *http.Request, e
 					PostCompressed: compressedBody,
 				},
 			}
-			for _, hook := range r.hooks {
-				hook.RespondErrorHookContext(hookContext)
-			}
+			r.hooks.respondError(r.tower, hookContext)
 		}()
 	}
-	body := r.errorTransformer.ErrorBodyTransform(ctx, errPayload)
+	if opt.ErrorExposure != ExposureAuto {
+		ctx = ContextWithErrorExposure(ctx, opt.ErrorExposure)
+	}
+	ctx, incident := contextWithIncidentRecord(ctx)
+	body := opt.ErrorBodyTransformer.ErrorBodyTransform(ctx, errPayload)
+	if incident.id != "" {
+		errPayload = r.tower.Wrap(errPayload).Caller(opt.Caller).Context(tower.F{"incident_id": incident.id}).Freeze()
+	}
 	if body == nil {
+		r.setServerTiming(rw.Header(), timing)
 		rw.WriteHeader(opt.StatusCode)
 		return
 	}
+	encodeStart := time.Now()
 	encodedBody, err = opt.Encoder.Encode(body)
+	timing.Encode = time.Since(encodeStart)
 	if err != nil {
 		const errMsg = "ENCODING ERROR"
 		rw.Header().Set("Content-Type", "text/plain; charset=utf-8")
@@ -83,26 +103,28 @@ func (r Responder) RespondError(rw http.ResponseWriter, request *http.Request, e
 		return
 	}
 	contentType := opt.Encoder.ContentType()
+	if ct, ok := opt.ErrorBodyTransformer.(ErrorContentTypeTransformer); ok {
+		if override := ct.ErrorContentType(contentType); override != "" {
+			contentType = override
+		}
+	}
 	if contentType != "" {
 		rw.Header().Set("Content-Type", contentType)
 	}
+	r.setVary(rw.Header(), false)
+	compressStart := time.Now()
 	compressedBody, ok, err := opt.Compressor.Compress(encodedBody)
+	timing.Compress = time.Since(compressStart)
 	if err != nil {
 		_ = r.tower.Wrap(err).Caller(opt.Caller).Level(tower.WarnLevel).Log(ctx)
-		rw.Header().Set("Content-Length", strconv.Itoa(len(encodedBody)))
-		rw.WriteHeader(opt.StatusCode)
-		_, err = rw.Write(encodedBody)
+		err = r.writeBody(rw, opt.StatusCode, encodedBody, timing)
 		return
 	}
 	if ok {
 		contentEncoding := opt.Compressor.ContentEncoding()
 		rw.Header().Set("Content-Encoding", contentEncoding)
-		rw.Header().Set("Content-Length", strconv.Itoa(len(compressedBody)))
-		rw.WriteHeader(opt.StatusCode)
-		_, err = rw.Write(compressedBody)
+		err = r.writeBody(rw, opt.StatusCode, compressedBody, timing)
 		return
 	}
-	rw.Header().Set("Content-Length", strconv.Itoa(len(encodedBody)))
-	rw.WriteHeader(opt.StatusCode)
-	_, err = rw.Write(encodedBody)
+	err = r.writeBody(rw, opt.StatusCode, encodedBody, timing)
 }
@@ -129,6 +129,7 @@ func TestResponder_RespondError(t *testing.T) {
 						"type": "unit-test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
@@ -217,6 +218,7 @@ func TestResponder_RespondError(t *testing.T) {
 						"type": "unit-test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
@@ -241,7 +243,8 @@ func TestResponder_RespondError(t *testing.T) {
 								],
 								"Content-Type": [
 									"application/json"
-								]
+								],
+								"Vary": ["Accept-Encoding"]
 							},
 							"status": 500
 						}
@@ -307,6 +310,7 @@ func TestResponder_RespondError(t *testing.T) {
 							"type": "unit-test"
 						},
 						"context": {
+							"duration_ms": "<<PRESENCE>>",
 							"request": {
 								"body": {
 									"foo": "bar"
@@ -401,6 +405,7 @@ func TestResponder_RespondError(t *testing.T) {
 						"type": "unit-test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
@@ -489,6 +494,7 @@ func TestResponder_RespondError(t *testing.T) {
 						"type": "unit-test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
@@ -575,6 +581,7 @@ func TestResponder_RespondError(t *testing.T) {
 						"type": "unit-test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
@@ -651,6 +658,7 @@ func TestResponder_RespondError(t *testing.T) {
 						"type": "unit-test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
@@ -762,6 +770,7 @@ func TestResponder_RespondError(t *testing.T) {
 						"type": "unit-test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
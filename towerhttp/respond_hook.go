@@ -1,7 +1,9 @@
 package towerhttp
 
 import (
+	"fmt"
 	"net/http"
+	"runtime/debug"
 
 	"github.com/tigorlazuardi/tower"
 )
@@ -14,6 +16,11 @@ type baseHook struct {
 	ResponseHeader http.Header
 	Tower          *tower.Tower
 	Error          error
+	// Timing is the timing of the response.
+	Timing *RespondTiming
+	// Curl is the curl command that reproduces the request. Empty unless the hook is created with Curl
+	// RespondHookOption.
+	Curl string
 }
 
 type RespondBody struct {
@@ -41,6 +48,8 @@ type RespondErrorHookContext struct {
 type RespondStreamBody struct {
 	Value       ClonedBody
 	ContentType string
+	// Summary is the summary of the stream sent by RespondSSE or RespondNDJSON. Nil for RespondStream.
+	Summary *StreamSummary
 }
 
 type RespondStreamHookContext struct {
@@ -81,6 +90,71 @@ func (r RespondHookList) CountMaximumRespondBodyRead(contentType string, request
 	return count
 }
 
+// beforeRespond calls BeforeRespond of the hooks in order. A hook that returns nil or panics keeps the RespondContext
+// returned by the previous hook.
+func (r RespondHookList) beforeRespond(t *tower.Tower, ctx *RespondContext, request *http.Request) *RespondContext {
+	for _, hook := range r {
+		safeCallHook(t, request, hook, "BeforeRespond", func() {
+			if next := hook.BeforeRespond(ctx, request); next != nil {
+				ctx = next
+			}
+		})
+	}
+	return ctx
+}
+
+func (r RespondHookList) respond(t *tower.Tower, ctx *RespondHookContext) {
+	for _, hook := range r {
+		safeCallHook(t, ctx.Request, hook, "RespondHook", func() {
+			hook.RespondHook(ctx)
+		})
+	}
+}
+
+func (r RespondHookList) respondError(t *tower.Tower, ctx *RespondErrorHookContext) {
+	for _, hook := range r {
+		safeCallHook(t, ctx.Request, hook, "RespondErrorHookContext", func() {
+			hook.RespondErrorHookContext(ctx)
+		})
+	}
+}
+
+func (r RespondHookList) respondStream(t *tower.Tower, ctx *RespondStreamHookContext) {
+	for _, hook := range r {
+		safeCallHook(t, ctx.Request, hook, "RespondStreamHookContext", func() {
+			hook.RespondStreamHookContext(ctx)
+		})
+	}
+}
+
+// safeCallHook calls f, and recovers and logs the panic, so a faulty hook does not crash the request or prevent the
+// other hooks from being called.
+func safeCallHook(t *tower.Tower, request *http.Request, hook RespondHook, method string, f func()) {
+	defer func() {
+		p := recover()
+		if p == nil {
+			return
+		}
+		err, ok := p.(error)
+		if !ok {
+			err = fmt.Errorf("%v", p)
+		}
+		name := hookName(hook)
+		if name == "" {
+			name = fmt.Sprintf("%T", hook)
+		}
+		_ = t.Wrap(err).
+			Message("respond hook %s panicked in %s: %v", name, method, p).
+			Context(tower.F{
+				"hook":   name,
+				"method": method,
+				"stack":  string(debug.Stack()),
+			}).
+			Log(request.Context())
+	}()
+	f()
+}
+
 type RespondHook interface {
 	AcceptRequestBodySize(r *http.Request) int
 	AcceptResponseBodyStreamSize(respondContentType string, request *http.Request) int
@@ -91,6 +165,33 @@ type RespondHook interface {
 	RespondStreamHookContext(ctx *RespondStreamHookContext)
 }
 
+// NamedHook is an optional interface for RespondHook to be identified by name. Registering a hook with the name of a
+// registered hook replaces it, and RemoveHook removes the hook by name.
+type NamedHook interface {
+	HookName() string
+}
+
+// PrioritizedHook is an optional interface for RespondHook to set the order it's called. Hooks with lower priority are
+// called first. Hooks that do not implement PrioritizedHook have priority 0. Hooks with the same priority are called in
+// the order they are registered.
+type PrioritizedHook interface {
+	HookPriority() int
+}
+
+func hookName(hook RespondHook) string {
+	if named, ok := hook.(NamedHook); ok {
+		return named.HookName()
+	}
+	return ""
+}
+
+func hookPriority(hook RespondHook) int {
+	if prioritized, ok := hook.(PrioritizedHook); ok {
+		return prioritized.HookPriority()
+	}
+	return 0
+}
+
 type (
 	BeforeRespondFunc      = func(ctx *RespondContext, request *http.Request) *RespondContext
 	ResponseHookFunc       = func(ctx *RespondHookContext)
@@ -98,45 +199,118 @@ type (
 	ResponseStreamHookFunc = func(ctx *RespondStreamHookContext)
 )
 
+// RegisterHook adds the hook to the Responder. The hooks are ordered by PrioritizedHook, and a hook that implements
+// NamedHook replaces the registered hook with the same name.
+//
+// A panic in a hook is recovered and logged, and the other hooks are still called.
 func (r *Responder) RegisterHook(hook RespondHook) {
-	r.hooks = append(r.hooks, hook)
+	name, priority := hookName(hook), hookPriority(hook)
+	hooks := make(RespondHookList, 0, len(r.hooks)+1)
+	for _, h := range r.hooks {
+		if name != "" && hookName(h) == name {
+			continue
+		}
+		hooks = append(hooks, h)
+	}
+	i := len(hooks)
+	for j, h := range hooks {
+		if hookPriority(h) > priority {
+			i = j
+			break
+		}
+	}
+	hooks = append(hooks, nil)
+	copy(hooks[i+1:], hooks[i:])
+	hooks[i] = hook
+	r.hooks = hooks
+}
+
+// RemoveHook removes the hook with the given name. Returns false if there is no such hook.
+func (r *Responder) RemoveHook(name string) bool {
+	hooks := make(RespondHookList, 0, len(r.hooks))
+	for _, h := range r.hooks {
+		if hookName(h) != name {
+			hooks = append(hooks, h)
+		}
+	}
+	if len(hooks) == len(r.hooks) {
+		return false
+	}
+	r.hooks = hooks
+	return true
+}
+
+// Hooks returns the registered hooks in the order they are called.
+func (r Responder) Hooks() RespondHookList {
+	hooks := make(RespondHookList, len(r.hooks))
+	copy(hooks, r.hooks)
+	return hooks
 }
 
 var _ RespondHook = (*respondHook)(nil)
 
 type respondHook struct {
-	readRequestLimit    int
+	name                string
+	priority            int
+	defaultRule         LogRule
 	readRespondLimit    int
-	filterRequest       FilterRequest
 	filterRespondStream FilterRespond
+	rules               LogRules
 	beforeRespond       BeforeRespondFunc
 	onRespond           ResponseHookFunc
 	onRespondError      ResponseErrorHookFunc
 	onRespondStream     ResponseStreamHookFunc
+	curl                bool
+	curlMaskHeaders     []string
 }
 
 func NewRespondHook(opts ...RespondHookOption) RespondHook {
-	r := &respondHook{}
+	r := &respondHook{defaultRule: NewLogRule("")}
 	for _, opt := range opts {
 		opt.apply(r)
 	}
 	return r
 }
 
+// HookName implements NamedHook.
+func (r2 respondHook) HookName() string {
+	return r2.name
+}
+
+// HookPriority implements PrioritizedHook.
+func (r2 respondHook) HookPriority() int {
+	return r2.priority
+}
+
 func (r2 respondHook) AcceptRequestBodySize(r *http.Request) int {
-	if r2.filterRequest != nil && r2.filterRequest(r) {
-		return r2.readRequestLimit
+	if rule := r2.rules.Find(r); rule != nil {
+		return rule.requestBodySize(r, r2.defaultRule)
 	}
-	return 0
+	return r2.defaultRule.requestBodySize(r, r2.defaultRule)
 }
 
 func (r2 respondHook) AcceptResponseBodyStreamSize(contentType string, request *http.Request) int {
-	if r2.filterRequest != nil && r2.filterRespondStream(contentType, request) {
+	if r2.filterRespondStream != nil && !r2.filterRespondStream(contentType, request) {
+		return 0
+	}
+	if rule := r2.rules.Find(request); rule != nil && rule.hasResponseLimit {
+		return rule.responseLimit
+	}
+	if r2.filterRespondStream != nil {
 		return r2.readRespondLimit
 	}
 	return 0
 }
 
+// shouldCall checks the rules whether the hook should be called for the response.
+func (r2 respondHook) shouldCall(request *http.Request, status int) bool {
+	rule := r2.rules.Find(request)
+	if rule == nil {
+		return true
+	}
+	return rule.ShouldLog(status, requestLatency(request))
+}
+
 func (r2 respondHook) BeforeRespond(ctx *RespondContext, request *http.Request) *RespondContext {
 	if r2.beforeRespond == nil {
 		return ctx
@@ -145,19 +319,29 @@ func (r2 respondHook) BeforeRespond(ctx *RespondContext, request *http.Request)
 }
 
 func (r2 respondHook) RespondHook(ctx *RespondHookContext) {
-	if r2.onRespond != nil {
-		r2.onRespond(ctx)
+	if r2.onRespond != nil && r2.shouldCall(ctx.Request, ctx.ResponseStatus) {
+		r2.onRespond(&RespondHookContext{baseHook: r2.withCurl(ctx.baseHook), ResponseBody: ctx.ResponseBody})
 	}
 }
 
 func (r2 respondHook) RespondErrorHookContext(ctx *RespondErrorHookContext) {
-	if r2.onRespondError != nil {
-		r2.onRespondError(ctx)
+	if r2.onRespondError != nil && r2.shouldCall(ctx.Request, ctx.ResponseStatus) {
+		r2.onRespondError(&RespondErrorHookContext{baseHook: r2.withCurl(ctx.baseHook), ResponseBody: ctx.ResponseBody})
 	}
 }
 
 func (r2 respondHook) RespondStreamHookContext(ctx *RespondStreamHookContext) {
-	if r2.onRespondStream != nil {
-		r2.onRespondStream(ctx)
+	if r2.onRespondStream != nil && r2.shouldCall(ctx.Request, ctx.ResponseStatus) {
+		r2.onRespondStream(&RespondStreamHookContext{baseHook: r2.withCurl(ctx.baseHook), ResponseBody: ctx.ResponseBody})
+	}
+}
+
+// withCurl returns a copy of the base hook with the curl command, so it does not leak to other hooks.
+func (r2 respondHook) withCurl(base *baseHook) *baseHook {
+	if !r2.curl {
+		return base
 	}
+	c := *base
+	c.Curl = CurlCommand(base.Request, base.RequestBody, r2.curlMaskHeaders...)
+	return &c
 }
@@ -27,14 +27,31 @@ type FilterRequest = func(*http.Request) bool
 
 type FilterRespond = func(respondContentType string, r *http.Request) bool
 
+// Name sets the name of the hook. Registering a hook with the name of a registered hook replaces it, and
+// Responder.RemoveHook removes the hook by name.
+func (hook RespondHookOptionBuilder) Name(name string) RespondHookOptionBuilder {
+	return append(hook, respondHookOptionFunc(func(r *respondHook) {
+		r.name = name
+	}))
+}
+
+// Priority sets the order the hook is called. Hooks with lower priority are called first. Defaults to 0.
+func (hook RespondHookOptionBuilder) Priority(priority int) RespondHookOptionBuilder {
+	return append(hook, respondHookOptionFunc(func(r *respondHook) {
+		r.priority = priority
+	}))
+}
+
 // ReadRequestBodyLimit limits the number of bytes body being cloned. Defaults to 1MB.
 //
 // Negative value will make the hook clones all the body.
 //
-// Body will not be read if FilterRequest returns false.
+// The limit is the default of every LogRule that does not set RequestBodyLimit.
+//
+// Deprecated: use Rules with LogRule.RequestBodyLimit instead, e.g. a catch-all NewLogRule("") as the last rule.
 func (hook RespondHookOptionBuilder) ReadRequestBodyLimit(limit int) RespondHookOptionBuilder {
 	return append(hook, respondHookOptionFunc(func(r *respondHook) {
-		r.readRequestLimit = limit
+		r.defaultRule = r.defaultRule.RequestBodyLimit(limit)
 	}))
 }
 
@@ -42,7 +59,8 @@ func (hook RespondHookOptionBuilder) ReadRequestBodyLimit(limit int) RespondHook
 //
 // Negative value will make the hook clones all the body.
 //
-// Body will not be read if FilterResponds returns false.
+// Body will not be read if FilterRespondStream returns false. LogRule with ResponseBodyLimit overrides this limit for
+// the matched requests.
 func (hook RespondHookOptionBuilder) ReadRespondBodyStreamLimit(limit int) RespondHookOptionBuilder {
 	return append(hook, respondHookOptionFunc(func(r *respondHook) {
 		r.readRespondLimit = limit
@@ -50,9 +68,13 @@ func (hook RespondHookOptionBuilder) ReadRespondBodyStreamLimit(limit int) Respo
 }
 
 // FilterRequest filter requests whose body are going to be cloned. Defaults to filter only human readable content type.
+//
+// The filter is the default of every LogRule that does not set RequestBodyFilter.
+//
+// Deprecated: use Rules with LogRule.RequestBodyFilter instead, e.g. a catch-all NewLogRule("") as the last rule.
 func (hook RespondHookOptionBuilder) FilterRequest(filter FilterRequest) RespondHookOptionBuilder {
 	return append(hook, respondHookOptionFunc(func(r *respondHook) {
-		r.filterRequest = filter
+		r.defaultRule = r.defaultRule.RequestBodyFilter(filter)
 	}))
 }
 
@@ -63,11 +85,45 @@ func (hook RespondHookOptionBuilder) FilterRespondStream(filter FilterRespond) R
 	}))
 }
 
+// Rules sets the rules to decide whether the OnRespond, OnRespondError, and OnRespondStream callbacks are called, and how
+// much of the bodies are cloned per route. The first rule that matches the request is used. Requests that match no rule
+// are always passed to the callbacks. Rules that do not set RequestBodyFilter or RequestBodyLimit use the defaults of the
+// hook, which for NewLoggerHook clone up to 1MB of human readable request bodies.
+//
+// Rules with SlowerThan threshold require the RequestBodyCloner middleware to measure the latency.
+//
+// See LogRule for details.
+func (hook RespondHookOptionBuilder) Rules(rules ...LogRule) RespondHookOptionBuilder {
+	return append(hook, respondHookOptionFunc(func(r *respondHook) {
+		r.rules = append(r.rules, rules...)
+	}))
+}
+
+// defaultRule sets the rule whose request body settings are used by requests that match no rule, and inherited by
+// matched rules that do not set them.
+func (hook RespondHookOptionBuilder) defaultRule(rule LogRule) RespondHookOptionBuilder {
+	return append(hook, respondHookOptionFunc(func(r *respondHook) {
+		r.defaultRule = rule
+	}))
+}
+
+// Curl adds the curl command that reproduces the incoming request to the hook context, which the logger hook adds to
+// the log context. Values of headers that may hold secrets and maskHeaders are masked. See CurlCommand for details.
+func (hook RespondHookOptionBuilder) Curl(maskHeaders ...string) RespondHookOptionBuilder {
+	return append(hook, respondHookOptionFunc(func(r *respondHook) {
+		r.curl = true
+		r.curlMaskHeaders = maskHeaders
+	}))
+}
+
 // BeforeRespond provides callback to be run before Responder calls transform on the body. You have full access on how to modify how towerhttp.Responder behave by using this api.
 //
 // You may change the transformers, compressions to use, etc.
 //
 // Do be careful when using this api, especially when working with other people in a team. Their responds may change suddenly without their knowing.
+//
+// Set RespondContext.ShortCircuit to respond with the error instead, e.g. for maintenance mode. BeforeRespond is not
+// called again for the short-circuited response.
 func (hook RespondHookOptionBuilder) BeforeRespond(before BeforeRespondFunc) RespondHookOptionBuilder {
 	return append(hook, respondHookOptionFunc(func(r *respondHook) {
 		r.beforeRespond = before
@@ -0,0 +1,252 @@
+package towerhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+func TestResponder_RegisterHook(t *testing.T) {
+	responder := NewResponder()
+	var calls []string
+	hook := func(name string, opts ...RespondHookOption) RespondHook {
+		return NewRespondHook(append(opts, Option.RespondHook().OnRespond(func(*RespondHookContext) {
+			calls = append(calls, name)
+		}))...)
+	}
+	responder.RegisterHook(hook("unnamed"))
+	responder.RegisterHook(hook("late", Option.RespondHook().Name("late").Priority(10)))
+	responder.RegisterHook(hook("early", Option.RespondHook().Name("early").Priority(-10)))
+	responder.RegisterHook(hook("audit", Option.RespondHook().Name("audit")))
+	responder.RegisterHook(hook("audit-v2", Option.RespondHook().Name("audit").Priority(-10)))
+
+	respond := func() []string {
+		calls = nil
+		responder.Respond(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), "ok")
+		return calls
+	}
+	if got, want := respond(), []string{"early", "audit-v2", "unnamed", "late"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected hooks to be called in order %v, got %v", want, got)
+	}
+
+	if !responder.RemoveHook("early") {
+		t.Error("expected hook early to be removed")
+	}
+	if responder.RemoveHook("missing") {
+		t.Error("expected no hook to be removed")
+	}
+	if got, want := respond(), []string{"audit-v2", "unnamed", "late"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected hooks to be called in order %v, got %v", want, got)
+	}
+	if got := len(responder.Hooks()); got != 3 {
+		t.Errorf("expected 3 hooks, got %d", got)
+	}
+}
+
+func TestResponder_HookPanic(t *testing.T) {
+	responder := NewResponder()
+	tow := tower.NewTower(tower.Service{Name: "TestResponder_HookPanic", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	responder.SetTower(tow)
+
+	var called bool
+	responder.RegisterHook(NewRespondHook(Option.RespondHook().
+		Name("faulty").
+		BeforeRespond(func(*RespondContext, *http.Request) *RespondContext {
+			panic("before boom")
+		}).
+		OnRespond(func(*RespondHookContext) {
+			panic("respond boom")
+		}),
+	))
+	responder.RegisterHook(NewRespondHook(Option.RespondHook().OnRespond(func(*RespondHookContext) {
+		called = true
+	})))
+
+	rec := httptest.NewRecorder()
+	responder.Respond(rec, httptest.NewRequest(http.MethodGet, "/", nil), map[string]string{"ok": "ok"})
+
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"ok"`) {
+		t.Errorf("expected the response to be written, got %d %s", rec.Code, rec.Body.String())
+	}
+	if !called {
+		t.Error("expected the next hook to be called after the panic")
+	}
+	out := logger.String()
+	for _, want := range []string{
+		"respond hook faulty panicked in BeforeRespond: before boom",
+		"respond hook faulty panicked in RespondHook: respond boom",
+		`"stack":`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in log, got %s", want, out)
+		}
+	}
+}
+
+func TestResponder_BeforeRespondShortCircuit(t *testing.T) {
+	responder := NewResponder()
+	maintenance := tower.Bail("under maintenance").Code(http.StatusServiceUnavailable).Freeze()
+	var (
+		hookStatus int
+		hookErr    error
+		okCalled   bool
+	)
+	responder.RegisterHook(NewRespondHook(Option.RespondHook().
+		Name("maintenance").
+		BeforeRespond(func(ctx *RespondContext, request *http.Request) *RespondContext {
+			if request.URL.Path != "/health" {
+				ctx.ShortCircuit = maintenance
+			}
+			return ctx
+		}).
+		OnRespond(func(*RespondHookContext) {
+			okCalled = true
+		}).
+		OnRespondStream(func(*RespondStreamHookContext) {
+			okCalled = true
+		}).
+		OnRespondError(func(ctx *RespondErrorHookContext) {
+			hookStatus = ctx.ResponseStatus
+			hookErr = ctx.ResponseBody.PreEncoded
+		}),
+	))
+
+	tests := []struct {
+		name    string
+		respond func(rw http.ResponseWriter, r *http.Request)
+	}{
+		{
+			name: "Respond",
+			respond: func(rw http.ResponseWriter, r *http.Request) {
+				responder.Respond(rw, r, "ok", Option.Respond().StatusCode(http.StatusCreated))
+			},
+		},
+		{
+			name: "RespondStream",
+			respond: func(rw http.ResponseWriter, r *http.Request) {
+				responder.RespondStream(rw, r, "text/plain", strings.NewReader("ok"))
+			},
+		},
+		{
+			name: "RespondError",
+			respond: func(rw http.ResponseWriter, r *http.Request) {
+				responder.RespondError(rw, r, tower.Bail("not found").Code(http.StatusNotFound).Freeze())
+			},
+		},
+		{
+			name: "RespondNDJSON",
+			respond: func(rw http.ResponseWriter, r *http.Request) {
+				responder.RespondNDJSON(rw, r, IterateSlice([]string{"ok"}))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hookStatus, hookErr, okCalled = 0, nil, false
+			rec := httptest.NewRecorder()
+			tt.respond(rec, httptest.NewRequest(http.MethodGet, "/users", nil))
+
+			if rec.Code != http.StatusServiceUnavailable {
+				t.Errorf("expected status 503, got %d", rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), "under maintenance") {
+				t.Errorf("expected maintenance message, got %s", rec.Body.String())
+			}
+			if okCalled {
+				t.Error("expected success hooks not to be called")
+			}
+			if hookStatus != http.StatusServiceUnavailable || hookErr != maintenance {
+				t.Errorf("expected error hook to see 503 with the maintenance error, got %d %v", hookStatus, hookErr)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	responder.Respond(rec, httptest.NewRequest(http.MethodGet, "/health", nil), "ok")
+	if rec.Code != http.StatusOK || !okCalled {
+		t.Errorf("expected requests without short-circuit to be responded, got %d", rec.Code)
+	}
+}
+
+func TestResponder_BeforeRespondOnce(t *testing.T) {
+	responder := NewResponder()
+	responder.AddEncoder(NewXMLEncoder())
+	var calls int
+	responder.RegisterHook(NewRespondHook(Option.RespondHook().
+		Name("counter").
+		BeforeRespond(func(ctx *RespondContext, request *http.Request) *RespondContext {
+			calls++
+			if request.URL.Path == "/maintenance" {
+				ctx.ShortCircuit = tower.Bail("under maintenance").Code(http.StatusServiceUnavailable).Freeze()
+			}
+			return ctx
+		}),
+	))
+
+	tests := []struct {
+		name    string
+		path    string
+		header  http.Header
+		respond func(rw http.ResponseWriter, r *http.Request)
+		status  int
+	}{
+		{
+			name:   "not acceptable",
+			path:   "/",
+			header: http.Header{"Accept": {"image/png"}},
+			respond: func(rw http.ResponseWriter, r *http.Request) {
+				responder.Respond(rw, r, "ok")
+			},
+			status: http.StatusNotAcceptable,
+		},
+		{
+			name: "encode error",
+			path: "/",
+			respond: func(rw http.ResponseWriter, r *http.Request) {
+				responder.Respond(rw, r, map[string]any{"fn": func() {}})
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "range not satisfiable",
+			path:   "/",
+			header: http.Header{"Range": {"bytes=100-"}},
+			respond: func(rw http.ResponseWriter, r *http.Request) {
+				responder.RespondStream(rw, r, "text/plain", strings.NewReader("ok"))
+			},
+			status: http.StatusRequestedRangeNotSatisfiable,
+		},
+		{
+			name: "short circuit",
+			path: "/maintenance",
+			respond: func(rw http.ResponseWriter, r *http.Request) {
+				responder.Respond(rw, r, "ok")
+			},
+			status: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = 0
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			tt.respond(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			if calls != 1 {
+				t.Errorf("expected BeforeRespond to be called once, got %d", calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package towerhttp
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// StreamSummary is the summary of a live stream response sent by RespondSSE or RespondNDJSON.
+type StreamSummary struct {
+	// Items is the number of events or items written to the client. Heartbeats are not counted.
+	Items int64
+	// Bytes is the number of bytes written to the client, including heartbeats.
+	Bytes int64
+	// Duration is the time between the response is started and the stream is ended.
+	Duration time.Duration
+	// Canceled is true when the stream is ended by the client disconnecting.
+	Canceled bool
+}
+
+// liveStreamWriter writes to the http.ResponseWriter and flushes after every write, so the client receives the data
+// immediately.
+type liveStreamWriter struct {
+	rw      http.ResponseWriter
+	flusher http.Flusher
+	summary *StreamSummary
+}
+
+func newLiveStreamWriter(rw http.ResponseWriter, summary *StreamSummary) *liveStreamWriter {
+	flusher, _ := rw.(http.Flusher)
+	return &liveStreamWriter{rw: rw, flusher: flusher, summary: summary}
+}
+
+func (w *liveStreamWriter) Write(b []byte) (int, error) {
+	n, err := w.rw.Write(b)
+	w.summary.Bytes += int64(n)
+	if err != nil {
+		return n, err
+	}
+	w.flush()
+	return n, nil
+}
+
+func (w *liveStreamWriter) flush() {
+	if w.flusher != nil {
+		w.flusher.Flush()
+	}
+}
+
+// pumpLiveStream writes items until the channel is closed, the request is canceled, or writing fails. Heartbeat is
+// written when there is no item for the heartbeat duration. The heartbeat timer restarts after every write.
+func pumpLiveStream[T any](ctx context.Context, w *liveStreamWriter, items <-chan T, heartbeat time.Duration, heartbeatMessage []byte, write func(T) error) error {
+	var (
+		timer *time.Timer
+		tick  <-chan time.Time
+	)
+	if heartbeat > 0 {
+		timer = time.NewTimer(heartbeat)
+		defer timer.Stop()
+		tick = timer.C
+	}
+	for {
+		var (
+			err   error
+			fired bool
+		)
+		select {
+		case <-ctx.Done():
+			w.summary.Canceled = true
+			return nil
+		case <-tick:
+			fired = true
+			_, err = w.Write(heartbeatMessage)
+		case item, ok := <-items:
+			if !ok {
+				return nil
+			}
+			if err = write(item); err == nil {
+				w.summary.Items++
+			}
+		}
+		if timer != nil {
+			if !fired && !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(heartbeat)
+		}
+		if err != nil {
+			// Writing to a disconnected client fails. That's not an error of the stream.
+			if ctx.Err() != nil {
+				w.summary.Canceled = true
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// callLiveStreamHooks calls the stream hooks with the summary of the live stream.
+func (r Responder) callLiveStreamHooks(rw http.ResponseWriter, request *http.Request, opt *RespondContext, contentType string, summary *StreamSummary, timing *RespondTiming, err error) {
+	var requestBody ClonedBody = NoopCloneBody{}
+	if b, ok := request.Body.(ClonedBody); ok {
+		requestBody = b
+	} else if c := clonedBodyFromContext(request.Context()); c != nil {
+		requestBody = c
+	}
+	hookContext := &RespondStreamHookContext{
+		baseHook: &baseHook{
+			Context:        opt,
+			Request:        request,
+			RequestBody:    requestBody,
+			ResponseStatus: opt.StatusCode,
+			ResponseHeader: rw.Header(),
+			Tower:          r.tower,
+			Error:          err,
+			Timing:         timing,
+		},
+		ResponseBody: RespondStreamBody{
+			Value:       NoopCloneBody{},
+			ContentType: contentType,
+			Summary:     summary,
+		},
+	}
+	r.hooks.respondStream(r.tower, hookContext)
+}
+
+func setLiveStreamHeader(header http.Header, contentType string) {
+	header.Set("Content-Type", contentType)
+	header.Set("Cache-Control", "no-cache")
+	// Prevents reverse proxies like nginx from buffering the stream.
+	header.Set("X-Accel-Buffering", "no")
+	header.Del("Content-Length")
+}
@@ -0,0 +1,206 @@
+package towerhttp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+func newLiveStreamResponder() (*Responder, *tower.TestingJSONLogger) {
+	logger := tower.NewTestingJSONLogger()
+	tow := tower.NewTower(tower.Service{Name: "test", Environment: "test", Type: "test"})
+	tow.SetLogger(logger)
+	responder := NewResponder()
+	responder.SetTower(tow)
+	responder.RegisterHook(NewLoggerHook())
+	return responder, logger
+}
+
+func TestResponder_RespondSSE(t *testing.T) {
+	responder, logger := newLiveStreamResponder()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		events := make(chan Event, 2)
+		events <- Event{ID: "2", Event: "update", Data: map[string]int{"count": 2}}
+		events <- Event{Data: "line 1\nline 2", Retry: time.Second}
+		close(events)
+		responder.RespondSSE(rw, r, events, Option.Respond().SSEResume(func(ctx context.Context, lastEventID string) []Event {
+			if lastEventID != "0" {
+				t.Errorf("expected last event id to be 0, got %s", lastEventID)
+			}
+			return []Event{{ID: "1", Data: "missed"}}
+		}))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Last-Event-ID", "0")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected content type text/event-stream, got %s", ct)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	want := "id: 1\ndata: missed\n\n" +
+		"id: 2\nevent: update\ndata: {\"count\":2}\n\n" +
+		"retry: 1000\ndata: line 1\ndata: line 2\n\n"
+	if string(body) != want {
+		t.Errorf("unexpected body:\n%q\nwant:\n%q", body, want)
+	}
+	logs := logger.String()
+	if !strings.Contains(logs, `"items":3`) {
+		t.Errorf("expected log to contain stream summary, got %s", logs)
+	}
+}
+
+func TestResponder_RespondSSE_Heartbeat(t *testing.T) {
+	responder, logger := newLiveStreamResponder()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		responder.RespondSSE(rw, r, make(chan Event), Option.Respond().Heartbeat(time.Millisecond*10))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line != ": heartbeat\n" {
+		t.Errorf("expected heartbeat, got %q", line)
+	}
+	cancel()
+	_ = resp.Body.Close()
+
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(logger.String(), `"canceled":true`) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected stream to be canceled, got %s", logger.String())
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func TestResponder_RespondNDJSON(t *testing.T) {
+	responder, logger := newLiveStreamResponder()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		responder.RespondNDJSON(rw, r, IterateSlice([]map[string]int{{"a": 1}, {"b": 2}}))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("expected content type application/x-ndjson, got %s", ct)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if want := "{\"a\":1}\n{\"b\":2}\n"; string(body) != want {
+		t.Errorf("expected body %q, got %q", want, body)
+	}
+	if logs := logger.String(); !strings.Contains(logs, `"items":2`) {
+		t.Errorf("expected log to contain stream summary, got %s", logs)
+	}
+}
+
+func TestResponder_RespondNDJSON_IteratorError(t *testing.T) {
+	responder, logger := newLiveStreamResponder()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		sent := false
+		responder.RespondNDJSON(rw, r, IteratorFunc(func(ctx context.Context) (any, error) {
+			if sent {
+				return nil, errors.New("cursor closed")
+			}
+			sent = true
+			return 1, nil
+		}))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "1\n" {
+		t.Errorf("expected body %q, got %q", "1\n", body)
+	}
+	if logs := logger.String(); !strings.Contains(logs, "cursor closed") || !strings.Contains(logs, `"items":1`) {
+		t.Errorf("expected log to contain iterator error and summary, got %s", logs)
+	}
+}
+
+func TestResponder_RespondNDJSON_IgnoresEncoder(t *testing.T) {
+	responder, _ := newLiveStreamResponder()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		responder.RespondNDJSON(rw, r, IterateSlice([]map[string]int{{"a": 1}}), Option.Respond().Encoder(NewXMLEncoder()))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if want := "{\"a\":1}\n"; string(body) != want {
+		t.Errorf("expected JSON lines regardless of the encoder, got %q", body)
+	}
+}
+
+func TestResponder_RespondNDJSON_HeartbeatOnlyWhenIdle(t *testing.T) {
+	responder, _ := newLiveStreamResponder()
+	const items = 10
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			for i := 0; i < items; i++ {
+				time.Sleep(time.Millisecond * 20)
+				ch <- i
+			}
+			// Idle long enough for heartbeats.
+			time.Sleep(time.Millisecond * 150)
+		}()
+		responder.RespondNDJSON(rw, r, IterateChannel(ch), Option.Respond().Heartbeat(time.Millisecond*50))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+	var heartbeats int
+	for i, line := range lines {
+		if line != "" {
+			continue
+		}
+		heartbeats++
+		if i < items {
+			t.Errorf("expected no heartbeat while items are sent within the heartbeat duration, got one at line %d: %q", i, body)
+		}
+	}
+	if heartbeats == 0 {
+		t.Errorf("expected heartbeats when the stream is idle, got %q", body)
+	}
+}
@@ -4,21 +4,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/tigorlazuardi/tower"
 )
 
+// NewLoggerHook creates a hook that logs the request and response using the Responder's tower instance.
+//
+// Use Option.RespondHook().Rules to skip or sample noisy routes like health checks and metrics scrapes.
 func NewLoggerHook(opts ...RespondHookOption) RespondHook {
 	return NewRespondHook(append(defaultLoggerOptions(), opts...)...)
 }
 
 func defaultLoggerOptions() RespondHookOptionBuilder {
 	return Option.RespondHook().
-		FilterRequest(func(r *http.Request) bool {
-			return isHumanReadable(r.Header.Get("Content-Type"))
-		}).
-		ReadRequestBodyLimit(1024 * 1024).
+		defaultRule(NewLogRule("").
+			RequestBodyFilter(func(r *http.Request) bool {
+				return isHumanReadable(r.Header.Get("Content-Type"))
+			}).
+			RequestBodyLimit(1024 * 1024)).
 		ReadRespondBodyStreamLimit(1024 * 1024).
 		FilterRespondStream(func(respondContentType string, r *http.Request) bool {
 			return isHumanReadable(respondContentType)
@@ -50,6 +53,14 @@ func defaultLoggerRespondError(ctx *RespondErrorHookContext) {
 
 func defaultLoggerRespondStream(ctx *RespondStreamHookContext) {
 	fields := buildLoggerFields(ctx.baseHook, ctx.ResponseBody.Value.CloneBytes(), ctx.ResponseBody.Value.Truncated())
+	if summary := ctx.ResponseBody.Summary; summary != nil {
+		fields["stream"] = tower.F{
+			"items":    summary.Items,
+			"bytes":    summary.Bytes,
+			"duration": summary.Duration.String(),
+			"canceled": summary.Canceled,
+		}
+	}
 	message := fmt.Sprintf("%s %s %s", ctx.Request.Method, ctx.Request.URL.String(), ctx.Request.Proto)
 	if ctx.Error != nil {
 		_ = ctx.Tower.Wrap(ctx.Error).Level(tower.ErrorLevel).Code(ctx.ResponseStatus).Message(message).Caller(ctx.Context.Caller).Context(fields).Log(ctx.Request.Context())
@@ -64,6 +75,9 @@ func buildLoggerFields(hook *baseHook, respBody []byte, truncated bool) tower.F
 		"method": hook.Request.Method,
 		"url":    url,
 	}
+	if route := RouteFromContext(hook.Request.Context()); route != "" {
+		requestFields["route"] = route
+	}
 	if len(hook.Request.Header) > 0 {
 		requestFields["headers"] = hook.Request.Header
 	}
@@ -73,7 +87,7 @@ func buildLoggerFields(hook *baseHook, respBody []byte, truncated bool) tower.F
 		switch {
 		case hook.RequestBody.Truncated():
 			requestFields["body"] = fmt.Sprintf("%s (truncated)", hook.RequestBody.String()[0:hook.RequestBody.Limit()])
-		case strings.Contains(contentType, "application/json") && isJson(hook.RequestBody.Bytes()):
+		case isJSONContentType(contentType) && isJson(hook.RequestBody.Bytes()):
 			requestFields["body"] = json.RawMessage(hook.RequestBody.CloneBytes())
 		case contentType == "" && isJsonLite(hook.RequestBody.Bytes()) && isJson(hook.RequestBody.Bytes()):
 			requestFields["body"] = json.RawMessage(hook.RequestBody.CloneBytes())
@@ -92,8 +106,8 @@ func buildLoggerFields(hook *baseHook, respBody []byte, truncated bool) tower.F
 		contentType := hook.ResponseHeader.Get("Content-Type")
 		switch {
 		case truncated:
-			responseFields["body"] = fmt.Sprintf("%s (truncated)", hook.RequestBody.String()[0:hook.RequestBody.Limit()])
-		case strings.Contains(contentType, "application/json") && isJson(respBody):
+			responseFields["body"] = fmt.Sprintf("%s (truncated)", respBody)
+		case isJSONContentType(contentType) && isJson(respBody):
 			responseFields["body"] = json.RawMessage(respBody)
 		case contentType == "" && isJsonLite(respBody) && isJson(respBody):
 			responseFields["body"] = json.RawMessage(respBody)
@@ -102,10 +116,17 @@ func buildLoggerFields(hook *baseHook, respBody []byte, truncated bool) tower.F
 		}
 	}
 
-	return tower.F{
+	fields := tower.F{
 		"request":  requestFields,
 		"response": responseFields,
 	}
+	if hook.Curl != "" {
+		fields["curl"] = hook.Curl
+	}
+	if hook.Timing != nil {
+		fields["duration_ms"] = durationMillis(hook.Timing.Total)
+	}
+	return fields
 }
 
 func isJsonLite(b []byte) bool {
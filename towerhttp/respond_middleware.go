@@ -1,14 +1,22 @@
 package towerhttp
 
 import (
+	"fmt"
 	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
 )
 
 type Middleware func(http.Handler) http.Handler
 
+// RequestBodyCloner creates a middleware that clones the request body for the hooks to read, and records the time the
+// request enters the middleware to measure the latency.
 func (r Responder) RequestBodyCloner() Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			request = request.WithContext(contextWithRequestStart(request.Context(), time.Now()))
 			size := r.hooks.CountMaximumRequestBodyRead(request)
 			if size != 0 {
 				cloner := wrapBodyCloner(request.Body, size)
@@ -20,3 +28,62 @@ func (r Responder) RequestBodyCloner() Middleware {
 		})
 	}
 }
+
+// WrittenWriter is an http.ResponseWriter that reports whether the response is written. Framework adapters, whose
+// handlers write with the framework's writer instead of the one given to the next handler, give it to Recover, so
+// Recover does not respond to a panic after the response is written.
+type WrittenWriter interface {
+	http.ResponseWriter
+	Written() bool
+}
+
+// Recover creates a middleware that recovers panics in the next handler and responds with RespondError using
+// http.StatusInternalServerError code and a generic message. The panic value and the stack trace are added to the error
+// context, so they are logged but not exposed to the client.
+//
+// If the next handler has already written the response, the error is only logged, since the status and the headers
+// can no longer be changed. Writes are detected through the writer given to the next handler, or by WrittenWriter.
+//
+// http.ErrAbortHandler is panicked again, so the server can abort the response as intended.
+//
+// Put the middleware after RequestBodyCloner, so the hooks can read the request body of the panicking request.
+func (r Responder) Recover() Middleware {
+	caller := tower.GetCaller(2)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			w := &recoverWriter{ResponseWriter: writer}
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+				err, ok := p.(error)
+				if !ok {
+					err = fmt.Errorf("%v", p)
+				}
+				fields := tower.F{"panic": fmt.Sprint(p), "stack": string(debug.Stack())}
+				if ww, ok := writer.(WrittenWriter); w.committed || ok && ww.Written() {
+					fields["method"] = request.Method
+					fields["path"] = request.URL.Path
+					_ = r.tower.Wrap(err).
+						Code(http.StatusInternalServerError).
+						Message("panic after the response is written").
+						Caller(caller).
+						Context(fields).
+						Log(request.Context())
+					return
+				}
+				r.RespondError(writer, request, r.tower.Wrap(err).
+					Code(http.StatusInternalServerError).
+					Message(http.StatusText(http.StatusInternalServerError)).
+					Caller(caller).
+					Context(fields).
+					Freeze())
+			}()
+			next.ServeHTTP(wrapResponseWriter(w), request)
+		})
+	}
+}
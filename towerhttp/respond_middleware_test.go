@@ -0,0 +1,111 @@
+package towerhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+func TestResponder_Recover(t *testing.T) {
+	responder := NewResponder()
+	tow := tower.NewTower(tower.Service{Name: "TestResponder_Recover", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	responder.SetTower(tow)
+	responder.RegisterHook(NewLoggerHook())
+
+	handler := responder.RequestBodyCloner()(responder.Recover()(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	})))
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	req = req.WithContext(ContextWithRoute(req.Context(), func() string { return "/users/{id}" }))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "boom") {
+		t.Errorf("expected panic value to not be exposed in body, got %s", rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), http.StatusText(http.StatusInternalServerError)) {
+		t.Errorf("expected generic message in body, got %s", rec.Body.String())
+	}
+	out := logger.String()
+	if !strings.Contains(out, `"panic":"boom"`) {
+		t.Errorf("expected panic value in log, got %s", out)
+	}
+	if !strings.Contains(out, "respond_middleware_test.go") {
+		t.Errorf("expected caller to point to where the middleware is created, got %s", out)
+	}
+	if !strings.Contains(out, `"route":"/users/{id}"`) {
+		t.Errorf("expected route in log, got %s", out)
+	}
+	if !strings.Contains(out, `"stack":`) {
+		t.Errorf("expected stack in log, got %s", out)
+	}
+}
+
+func TestResponder_RecoverAbortHandler(t *testing.T) {
+	handler := NewResponder().Recover()(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		if p := recover(); p != http.ErrAbortHandler {
+			t.Errorf("expected http.ErrAbortHandler to be panicked again, got %v", p)
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestResponder_RecoverAfterWrite(t *testing.T) {
+	responder := NewResponder()
+	tow := tower.NewTower(tower.Service{Name: "TestResponder_RecoverAfterWrite", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	responder.SetTower(tow)
+	responder.RegisterHook(NewLoggerHook())
+
+	handler := responder.Recover()(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusAccepted)
+		_, _ = rw.Write([]byte("partial"))
+		panic("boom")
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("expected status 202, got %d", rec.Code)
+	}
+	if rec.Body.String() != "partial" {
+		t.Errorf("expected body to be untouched, got %q", rec.Body.String())
+	}
+	out := logger.String()
+	if !strings.Contains(out, "panic after the response is written") || !strings.Contains(out, `"panic":"boom"`) {
+		t.Errorf("expected panic to be logged, got %s", out)
+	}
+	if !strings.Contains(out, "respond_middleware_test.go") {
+		t.Errorf("expected caller to point to where the middleware is created, got %s", out)
+	}
+}
+
+func TestResponder_RecoverWriterInterfaces(t *testing.T) {
+	handler := NewResponder().Recover()(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		if _, ok := rw.(http.Flusher); !ok {
+			t.Error("expected writer to implement http.Flusher")
+		}
+		if _, ok := rw.(http.Hijacker); ok {
+			t.Error("expected writer to not implement http.Hijacker")
+		}
+		rw.(http.Flusher).Flush()
+		panic("boom")
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected flushed status 200 to be kept, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,130 @@
+package towerhttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Iterator yields the items for RespondNDJSON.
+type Iterator interface {
+	// Next returns the next item. Return io.EOF when there are no more items.
+	//
+	// ctx is canceled when the client disconnects.
+	Next(ctx context.Context) (any, error)
+}
+
+// IteratorFunc is a convenient function that implements Iterator.
+type IteratorFunc func(ctx context.Context) (any, error)
+
+func (i IteratorFunc) Next(ctx context.Context) (any, error) {
+	return i(ctx)
+}
+
+// IterateChannel creates an Iterator from a channel. The iterator ends when the channel is closed.
+func IterateChannel[T any](ch <-chan T) Iterator {
+	return IteratorFunc(func(ctx context.Context) (any, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case v, ok := <-ch:
+			if !ok {
+				return nil, io.EOF
+			}
+			return v, nil
+		}
+	})
+}
+
+// IterateSlice creates an Iterator from a slice.
+func IterateSlice[T any](s []T) Iterator {
+	i := 0
+	return IteratorFunc(func(ctx context.Context) (any, error) {
+		if i >= len(s) {
+			return nil, io.EOF
+		}
+		v := s[i]
+		i++
+		return v, nil
+	})
+}
+
+// ndjsonEncoder encodes NDJSON items regardless of the negotiated Encoder, because every line must be a JSON value.
+var ndjsonEncoder = NewJSONEncoder()
+
+type ndjsonItem struct {
+	value any
+	err   error
+}
+
+// RespondNDJSON writes the items from the iterator as newline delimited JSON (application/x-ndjson) until the iterator
+// ends or the client disconnects.
+//
+// Every item is encoded as JSON into a single line and flushed immediately. The Encoder RespondOption is ignored, since
+// the content type is always application/x-ndjson. If the Heartbeat RespondOption is set, an empty line is sent when
+// there is no item for the duration.
+//
+// Errors returned by the iterator end the stream. Because the status code is already sent, the error is only reported
+// to the stream hooks, along with the StreamSummary in RespondStreamBody.Summary.
+func (r Responder) RespondNDJSON(rw http.ResponseWriter, request *http.Request, iterator Iterator, opts ...RespondOption) {
+	const contentType = "application/x-ndjson"
+	var (
+		err     error
+		summary = &StreamSummary{}
+		start   = time.Now()
+		timing  = newRespondTiming(request)
+	)
+	opt := r.buildOption(http.StatusOK, request, opts...)
+	if r.shortCircuit(rw, request, opt) {
+		return
+	}
+	if len(r.hooks) > 0 {
+		defer func() {
+			summary.Duration = time.Since(start)
+			timing.Write = summary.Duration
+			timing.finish()
+			r.callLiveStreamHooks(rw, request, opt, contentType, summary, timing, err)
+		}()
+	}
+	setLiveStreamHeader(rw.Header(), contentType)
+	r.setServerTiming(rw.Header(), timing)
+	rw.WriteHeader(opt.StatusCode)
+	w := newLiveStreamWriter(rw, summary)
+	w.flush()
+
+	ctx, cancel := context.WithCancel(request.Context())
+	defer cancel()
+	items := make(chan ndjsonItem)
+	go func() {
+		defer close(items)
+		for {
+			value, err := iterator.Next(ctx)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			select {
+			case items <- ndjsonItem{value: value, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	err = pumpLiveStream(request.Context(), w, items, opt.Heartbeat, []byte("\n"), func(item ndjsonItem) error {
+		if item.err != nil {
+			return item.err
+		}
+		b, err := ndjsonEncoder.Encode(item.value)
+		if err != nil {
+			return err
+		}
+		b = append(bytes.TrimRight(b, "\r\n"), '\n')
+		_, err = w.Write(b)
+		return err
+	})
+}
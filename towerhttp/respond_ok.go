@@ -3,6 +3,7 @@ package towerhttp
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/tigorlazuardi/tower"
 )
@@ -21,12 +22,20 @@ import (
 //
 // Body of nil has different treatment with http.NoBody. if body is nil, the nil value is still passed to the BodyTransformer implementer,
 // therefore the final result body may not actually be empty.
+//
+// If the BodyTransformer implements HeaderTransformer, it's called with the body before the transformation to set
+// response headers, e.g. Link header of Paginated bodies.
+//
+// With ETag, GenerateETag, or LastModified RespondOption, successful responses to GET and HEAD requests are responded
+// with http.StatusNotModified without body when If-None-Match or If-Modified-Since header says the client has the
+// current representation. The hooks see the http.StatusNotModified status without body.
 func (r Responder) Respond(rw http.ResponseWriter, request *http.Request, body any, opts ...RespondOption) {
 	var (
 		statusCode  = http.StatusOK
 		err         error
 		rejectDefer bool
 		ctx         = request.Context()
+		timing      = newRespondTiming(request)
 	)
 	var (
 		encodedBody    []byte
@@ -39,9 +48,13 @@ func (r Responder) Respond(rw http.ResponseWriter, request *http.Request, body a
 
 	opt := r.buildOption(statusCode, request, opts...)
 	caller := tower.GetCaller(opt.CallerDepth)
+	if r.shortCircuit(rw, request, opt) {
+		return
+	}
 	if len(r.hooks) > 0 {
 		defer func() {
 			if !rejectDefer {
+				timing.finish()
 				var requestBody ClonedBody = NoopCloneBody{}
 				if b, ok := request.Body.(ClonedBody); ok {
 					requestBody = b
@@ -57,6 +70,7 @@ func (r Responder) Respond(rw http.ResponseWriter, request *http.Request, body a
 						ResponseHeader: rw.Header(),
 						Tower:          r.tower,
 						Error:          err,
+						Timing:         timing,
 					},
 					ResponseBody: RespondBody{
 						PreEncoded:     body,
@@ -64,56 +78,100 @@ func (r Responder) Respond(rw http.ResponseWriter, request *http.Request, body a
 						PostCompressed: compressedBody,
 					},
 				}
-				for _, hook := range r.hooks {
-					hook.RespondHook(hookContext)
-				}
+				r.hooks.respond(r.tower, hookContext)
 			}
 		}()
 	}
 
 	if body == http.NoBody {
+		setCacheHeaders(rw.Header(), opt, opt.ETag)
+		r.setServerTiming(rw.Header(), timing)
 		rw.WriteHeader(opt.StatusCode)
 		return
 	}
 
+	if ht, ok := opt.BodyTransformer.(HeaderTransformer); ok {
+		ht.TransformHeader(request, rw.Header(), body)
+	}
 	body = opt.BodyTransformer.BodyTransform(ctx, body)
 	if body == nil {
+		setCacheHeaders(rw.Header(), opt, opt.ETag)
+		r.setServerTiming(rw.Header(), timing)
 		rw.WriteHeader(opt.StatusCode)
 		return
 	}
 
+	if opt.notAcceptable || opt.compressorNotAcceptable {
+		if opt.notAcceptable {
+			err = r.tower.
+				Bail("none of the media types in Accept header %q is supported", request.Header.Get("Accept")).
+				Code(http.StatusNotAcceptable).
+				Caller(opt.Caller).
+				Freeze()
+		} else {
+			err = r.tower.
+				Bail("none of the content codings in Accept-Encoding header %q is supported", request.Header.Get("Accept-Encoding")).
+				Code(http.StatusNotAcceptable).
+				Caller(opt.Caller).
+				Freeze()
+		}
+		opt.StatusCode = http.StatusNotAcceptable
+		r.respondError(rw, request, err, opt)
+		rejectDefer = true
+		return
+	}
+
+	encodeStart := time.Now()
 	encodedBody, err = opt.Encoder.Encode(body)
+	timing.Encode = time.Since(encodeStart)
 	if err != nil {
-		opts := append(opts,
-			Option.Respond().StatusCode(http.StatusInternalServerError),
-			Option.Respond().AddCallerSkip(1),
-		)
-		r.RespondError(rw, request, err, opts...)
+		opt.StatusCode = http.StatusInternalServerError
+		r.respondError(rw, request, err, opt)
 		rejectDefer = true
 		return
 	}
+	r.setVary(rw.Header(), false)
+	etag := opt.ETag
+	if etag == "" && opt.GenerateETag {
+		etag = generateETag(encodedBody, opt.Compressor.ContentEncoding())
+	}
+	setCacheHeaders(rw.Header(), opt, etag)
+	if opt.StatusCode >= 200 && opt.StatusCode < 300 && notModified(request, etag, opt.LastModified) {
+		opt.StatusCode = http.StatusNotModified
+		encodedBody = nil
+		r.setServerTiming(rw.Header(), timing)
+		rw.WriteHeader(http.StatusNotModified)
+		return
+	}
 	contentType := opt.Encoder.ContentType()
 	if contentType != "" {
 		rw.Header().Set("Content-Type", contentType)
 	}
 
+	compressStart := time.Now()
 	compressedBody, ok, err := opt.Compressor.Compress(encodedBody)
+	timing.Compress = time.Since(compressStart)
 	if err != nil {
 		_ = r.tower.Wrap(err).Caller(caller).Level(tower.WarnLevel).Log(ctx)
-		rw.Header().Set("Content-Length", strconv.Itoa(len(encodedBody)))
-		rw.WriteHeader(opt.StatusCode)
-		_, err = rw.Write(encodedBody)
+		err = r.writeBody(rw, opt.StatusCode, encodedBody, timing)
 		return
 	}
 	if ok {
 		contentEncoding := opt.Compressor.ContentEncoding()
 		rw.Header().Set("Content-Encoding", contentEncoding)
-		rw.Header().Set("Content-Length", strconv.Itoa(len(compressedBody)))
-		rw.WriteHeader(opt.StatusCode)
-		_, err = rw.Write(compressedBody)
+		err = r.writeBody(rw, opt.StatusCode, compressedBody, timing)
 		return
 	}
-	rw.Header().Set("Content-Length", strconv.Itoa(len(encodedBody)))
-	rw.WriteHeader(opt.StatusCode)
-	_, err = rw.Write(encodedBody)
+	err = r.writeBody(rw, opt.StatusCode, encodedBody, timing)
+}
+
+// writeBody writes the status code and the body with Content-Length and Server-Timing headers.
+func (r Responder) writeBody(rw http.ResponseWriter, statusCode int, body []byte, timing *RespondTiming) error {
+	rw.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	r.setServerTiming(rw.Header(), timing)
+	rw.WriteHeader(statusCode)
+	writeStart := time.Now()
+	_, err := rw.Write(body)
+	timing.Write = time.Since(writeStart)
+	return err
 }
@@ -124,6 +124,7 @@ func TestResponder_Respond(t *testing.T) {
 					},
 					"caller": "<<PRESENCE>>",
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"method": "GET",
 							"url": "%s/",
@@ -198,6 +199,7 @@ func TestResponder_Respond(t *testing.T) {
 					"type": "unit-test"
 				  },
 				  "context": {
+				  	"duration_ms": "<<PRESENCE>>",
 					"request": {
 					  "headers": {
 						"Accept-Encoding": [
@@ -269,6 +271,7 @@ func TestResponder_Respond(t *testing.T) {
 					"type": "unit-test"
 				  },
 				  "context": {
+				  	"duration_ms": "<<PRESENCE>>",
 					"request": {
 					  "headers": {
 						"Accept-Encoding": [
@@ -345,6 +348,7 @@ func TestResponder_Respond(t *testing.T) {
 					"type": "unit-test"
 				  },
 				  "context": {
+				  	"duration_ms": "<<PRESENCE>>",
 					"request": {
 					  "headers": {
 						"Accept-Encoding": [
@@ -438,6 +442,7 @@ func TestResponder_Respond(t *testing.T) {
 					"type": "unit-test"
 				  },
 				  "context": {
+				  	"duration_ms": "<<PRESENCE>>",
 					"request": {
 					  "headers": {
 						"Accept-Encoding": [
@@ -523,6 +528,7 @@ func TestResponder_Respond(t *testing.T) {
 						"type": "unit-test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
@@ -546,7 +552,8 @@ func TestResponder_Respond(t *testing.T) {
 								],
 								"Content-Type": [
 									"application/json"
-								]
+								],
+								"Vary": ["Accept-Encoding"]
 							},
 							"status": 200
 						}
@@ -611,6 +618,7 @@ func TestResponder_Respond(t *testing.T) {
 						"type": "unit-test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
@@ -635,7 +643,8 @@ func TestResponder_Respond(t *testing.T) {
 								"Content-Encoding": [ "gzip" ],
 								"Content-Type": [
 									"application/json"
-								]
+								],
+								"Vary": ["Accept-Encoding"]
 							},
 							"status": 200
 						}
@@ -698,6 +707,7 @@ func TestResponder_Respond(t *testing.T) {
 						"type": "unit-test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
@@ -721,7 +731,8 @@ func TestResponder_Respond(t *testing.T) {
 								],
 								"Content-Type": [
 									"application/json"
-								]
+								],
+								"Vary": ["Accept-Encoding"]
 							},
 							"status": 201
 						}
@@ -784,6 +795,7 @@ func TestResponder_Respond(t *testing.T) {
 						"type": "unit-test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
@@ -807,7 +819,8 @@ func TestResponder_Respond(t *testing.T) {
 								],
 								"Content-Type": [
 									"application/json"
-								]
+								],
+								"Vary": ["Accept-Encoding"]
 							},
 							"status": 201
 						}
@@ -893,6 +906,7 @@ func TestResponder_Respond(t *testing.T) {
 						"type": "unit-test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
@@ -979,6 +993,7 @@ func TestResponder_Respond(t *testing.T) {
 						"type": "unit-test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
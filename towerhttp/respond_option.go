@@ -1,6 +1,10 @@
 package towerhttp
 
-import "github.com/tigorlazuardi/tower"
+import (
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+)
 
 type RespondOption interface {
 	Apply(*RespondContext)
@@ -30,12 +34,45 @@ type RespondContext struct {
 	ErrorBodyTransformer ErrorBodyTransformer
 	CallerDepth          int
 	Caller               tower.Caller
+	// ErrorExposure overrides the error exposure of the request. ExposureAuto keeps the exposure set in the request
+	// context, or the one derived by the ErrorBodyTransformer.
+	ErrorExposure ErrorExposure
+	// Heartbeat is the idle duration before a heartbeat is sent by RespondSSE and RespondNDJSON. Zero uses the default,
+	// which is 15 seconds for RespondSSE and disabled for RespondNDJSON. Negative value disables heartbeat.
+	Heartbeat time.Duration
+	// SSEResume is called by RespondSSE when the request has Last-Event-ID header.
+	SSEResume SSEResumeFunc
+	// ETag is the entity tag of the response. Takes precedence over GenerateETag.
+	ETag string
+	// GenerateETag makes Respond compute a strong ETag from the encoded body.
+	GenerateETag bool
+	// LastModified is sent as Last-Modified header and compared against If-Modified-Since header.
+	LastModified time.Time
+	// CacheControl is sent as Cache-Control header.
+	CacheControl string
+	// ContentLength is the size of the body given to RespondStream. Enables Range requests for bodies that can not
+	// seek. Zero means unknown.
+	ContentLength int64
+	// ShortCircuit is set by BeforeRespond hooks to respond with the error instead of the body, e.g. for maintenance
+	// mode. Respond, RespondStream, RespondSSE, and RespondNDJSON respond with RespondError, and RespondError responds with
+	// ShortCircuit in place of the given error. The status code is derived from the error.
+	ShortCircuit error
+
+	// notAcceptable is true when the client accepts none of the Responder's encoders and no RespondOption overrides the
+	// Encoder.
+	notAcceptable bool
+	// compressorNotAcceptable is true when the client rejects all the Responder's compressors and uncompressed response,
+	// and no RespondOption overrides the Compressor.
+	compressorNotAcceptable bool
+	// streamNotAcceptable is like compressorNotAcceptable but for StreamCompressor.
+	streamNotAcceptable bool
 }
 
 // Encoder overrides the Encoder to be used for encoding the response body.
 func (r RespondOptionBuilder) Encoder(encoder Encoder) RespondOptionBuilder {
 	return append(r, RespondOptionFunc(func(o *RespondContext) {
 		o.Encoder = encoder
+		o.notAcceptable = false
 	}))
 }
 
@@ -52,10 +89,74 @@ func (r RespondOptionBuilder) ErrorTransformer(transformer ErrorBodyTransformer)
 	}))
 }
 
+// ErrorExposure overrides the error exposure for the response. Only affects ErrorBodyTransformer that respects
+// ErrorExposureFromContext, like ErrorPolicyTransformer.
+func (r RespondOptionBuilder) ErrorExposure(exposure ErrorExposure) RespondOptionBuilder {
+	return append(r, RespondOptionFunc(func(o *RespondContext) {
+		o.ErrorExposure = exposure
+	}))
+}
+
+// Heartbeat sets the idle duration before a heartbeat is sent by RespondSSE and RespondNDJSON. Negative value disables
+// heartbeat.
+func (r RespondOptionBuilder) Heartbeat(d time.Duration) RespondOptionBuilder {
+	return append(r, RespondOptionFunc(func(o *RespondContext) {
+		o.Heartbeat = d
+	}))
+}
+
+// SSEResume sets the function to get the events the client missed when it reconnects with Last-Event-ID header.
+func (r RespondOptionBuilder) SSEResume(resume SSEResumeFunc) RespondOptionBuilder {
+	return append(r, RespondOptionFunc(func(o *RespondContext) {
+		o.SSEResume = resume
+	}))
+}
+
+// ETag sets the entity tag of the response. Unquoted tag is quoted. Respond responds with http.StatusNotModified when
+// the tag matches If-None-Match header.
+func (r RespondOptionBuilder) ETag(tag string) RespondOptionBuilder {
+	return append(r, RespondOptionFunc(func(o *RespondContext) {
+		o.ETag = quoteETag(tag)
+	}))
+}
+
+// GenerateETag makes Respond compute a strong ETag from the hash of the encoded body. Respond responds with
+// http.StatusNotModified when the tag matches If-None-Match header.
+func (r RespondOptionBuilder) GenerateETag() RespondOptionBuilder {
+	return append(r, RespondOptionFunc(func(o *RespondContext) {
+		o.GenerateETag = true
+	}))
+}
+
+// LastModified sets the Last-Modified header. Respond responds with http.StatusNotModified when the resource is not
+// modified since If-Modified-Since header, and the request has no If-None-Match header.
+func (r RespondOptionBuilder) LastModified(t time.Time) RespondOptionBuilder {
+	return append(r, RespondOptionFunc(func(o *RespondContext) {
+		o.LastModified = t
+	}))
+}
+
+// ContentLength sets the size of the body given to RespondStream, so Range requests can be served for bodies that do
+// not implement io.Seeker. Such bodies only serve ranges in ascending order. Bodies that implement io.Seeker have their
+// size computed when this option is not set.
+func (r RespondOptionBuilder) ContentLength(n int64) RespondOptionBuilder {
+	return append(r, RespondOptionFunc(func(o *RespondContext) {
+		o.ContentLength = n
+	}))
+}
+
+// CacheControl sets the Cache-Control header, e.g. "private, max-age=60" or "no-cache".
+func (r RespondOptionBuilder) CacheControl(value string) RespondOptionBuilder {
+	return append(r, RespondOptionFunc(func(o *RespondContext) {
+		o.CacheControl = value
+	}))
+}
+
 // Compressor overrides the Compressor to be used for compressing the response body.
 func (r RespondOptionBuilder) Compressor(compressor Compressor) RespondOptionBuilder {
 	return append(r, RespondOptionFunc(func(o *RespondContext) {
 		o.Compressor = compressor
+		o.compressorNotAcceptable = false
 	}))
 }
 
@@ -63,6 +164,7 @@ func (r RespondOptionBuilder) Compressor(compressor Compressor) RespondOptionBui
 func (r RespondOptionBuilder) StreamCompressor(compressor StreamCompressor) RespondOptionBuilder {
 	return append(r, RespondOptionFunc(func(o *RespondContext) {
 		o.StreamCompressor = compressor
+		o.streamNotAcceptable = false
 	}))
 }
 
@@ -83,6 +185,6 @@ func (r RespondOptionBuilder) CallerSkip(i int) RespondOptionBuilder {
 // AddCallerSkip adds the caller skip value to be used for the response to get the caller information.
 func (r RespondOptionBuilder) AddCallerSkip(i int) RespondOptionBuilder {
 	return append(r, RespondOptionFunc(func(o *RespondContext) {
-		o.CallerDepth += 1
+		o.CallerDepth += i
 	}))
 }
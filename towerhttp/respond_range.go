@@ -0,0 +1,271 @@
+package towerhttp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRanges is the maximum number of ranges served in a single response. Requests with more ranges are responded with
+// the whole body, to protect against many small ranges that cost more than the whole body.
+const maxRanges = 32
+
+var errRangeUnsatisfiable = errors.New("range not satisfiable")
+
+// byteRange is a satisfiable range of bytes.
+type byteRange struct {
+	start, length int64
+}
+
+func (b byteRange) contentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", b.start, b.start+b.length-1, size)
+}
+
+// rangeSource reads sections of the body.
+type rangeSource struct {
+	body io.Reader
+	// base is the offset of the body when RespondStream is called. Ranges are relative to it.
+	base int64
+	size int64
+	// pos is the read position of bodies that can not seek.
+	pos      int64
+	seekable bool
+}
+
+// newRangeSource returns the rangeSource of the body if it supports ranges, which are bodies that implement io.Seeker
+// or io.ReaderAt with known size, and any body with size set by ContentLength RespondOption. Bodies that can not seek
+// only support ranges in ascending order.
+func newRangeSource(body io.Reader, size int64) *rangeSource {
+	src := &rangeSource{body: body, size: size}
+	if seeker, ok := body.(io.Seeker); ok {
+		src.seekable = true
+		cur, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil
+		}
+		src.base = cur
+		if size <= 0 {
+			end, err := seeker.Seek(0, io.SeekEnd)
+			if err != nil {
+				return nil
+			}
+			if _, err := seeker.Seek(cur, io.SeekStart); err != nil {
+				return nil
+			}
+			src.size = end - cur
+		}
+	} else if _, ok := body.(io.ReaderAt); ok {
+		src.seekable = true
+	}
+	if src.size <= 0 {
+		return nil
+	}
+	return src
+}
+
+func (s *rangeSource) section(r byteRange) (io.Reader, error) {
+	if ra, ok := s.body.(io.ReaderAt); ok {
+		return io.NewSectionReader(ra, s.base+r.start, r.length), nil
+	}
+	if seeker, ok := s.body.(io.Seeker); ok {
+		if _, err := seeker.Seek(s.base+r.start, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return io.LimitReader(s.body, r.length), nil
+	}
+	if r.start < s.pos {
+		return nil, fmt.Errorf("range %d-%d is behind the read position %d", r.start, r.start+r.length-1, s.pos)
+	}
+	if _, err := io.CopyN(io.Discard, s.body, r.start-s.pos); err != nil {
+		return nil, err
+	}
+	s.pos = r.start + r.length
+	return io.LimitReader(s.body, r.length), nil
+}
+
+// parseRange parses the Range header as described in RFC 9110 section 14.1.2. Returns nil without error if the header
+// is invalid or should be ignored, in which case the whole body is sent. Returns errRangeUnsatisfiable if none of the
+// ranges overlaps the body.
+func parseRange(header string, size int64) ([]byteRange, error) {
+	const prefix = "bytes="
+	if !strings.HasPrefix(header, prefix) {
+		return nil, nil
+	}
+	var (
+		ranges  []byteRange
+		noMatch bool
+	)
+	for _, spec := range strings.Split(header[len(prefix):], ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		startStr, endStr, ok := strings.Cut(spec, "-")
+		if !ok {
+			return nil, nil
+		}
+		startStr, endStr = strings.TrimSpace(startStr), strings.TrimSpace(endStr)
+		var r byteRange
+		if startStr == "" {
+			// Suffix range, the last n bytes.
+			n, err := strconv.ParseInt(endStr, 10, 64)
+			if err != nil || n < 0 {
+				return nil, nil
+			}
+			if n == 0 {
+				noMatch = true
+				continue
+			}
+			if n > size {
+				n = size
+			}
+			r = byteRange{start: size - n, length: n}
+		} else {
+			start, err := strconv.ParseInt(startStr, 10, 64)
+			if err != nil || start < 0 {
+				return nil, nil
+			}
+			if start >= size {
+				noMatch = true
+				continue
+			}
+			end := size - 1
+			if endStr != "" {
+				end, err = strconv.ParseInt(endStr, 10, 64)
+				if err != nil || end < start {
+					return nil, nil
+				}
+				if end >= size {
+					end = size - 1
+				}
+			}
+			r = byteRange{start: start, length: end - start + 1}
+		}
+		ranges = append(ranges, r)
+	}
+	if len(ranges) == 0 {
+		if noMatch {
+			return nil, errRangeUnsatisfiable
+		}
+		return nil, nil
+	}
+	if len(ranges) > maxRanges {
+		return nil, nil
+	}
+	var total int64
+	for _, r := range ranges {
+		total += r.length
+	}
+	if total > size {
+		return nil, nil
+	}
+	return ranges, nil
+}
+
+// ascending reports whether the ranges are in ascending order without overlap, which is required by bodies that can not
+// seek.
+func ascending(ranges []byteRange) bool {
+	for i := 1; i < len(ranges); i++ {
+		if ranges[i].start < ranges[i-1].start+ranges[i-1].length {
+			return false
+		}
+	}
+	return true
+}
+
+// ifRangeMatch evaluates the If-Range header. The Range header is ignored if the validator does not match the current
+// representation.
+func ifRangeMatch(request *http.Request, etag string, lastModified time.Time) bool {
+	ifRange := request.Header.Get("If-Range")
+	if ifRange == "" {
+		return true
+	}
+	if strings.HasPrefix(ifRange, `"`) || strings.HasPrefix(ifRange, "W/") {
+		return etagStrongMatch(ifRange, etag)
+	}
+	t, err := http.ParseTime(ifRange)
+	if err != nil || lastModified.IsZero() {
+		return false
+	}
+	return lastModified.Truncate(time.Second).Equal(t)
+}
+
+// rangeResponse is the body and headers of a 206 Partial Content response.
+type rangeResponse struct {
+	body        io.Reader
+	contentType string
+	close       func()
+}
+
+// prepareRange returns the partial response of the body if the request asks for satisfiable ranges. Returns nil if
+// the whole body should be sent. Returns errRangeUnsatisfiable with the size of the body if none of the ranges is
+// satisfiable.
+func prepareRange(rw http.ResponseWriter, request *http.Request, opt *RespondContext, contentType string, body io.Reader) (*rangeResponse, int64, error) {
+	src := newRangeSource(body, opt.ContentLength)
+	if src == nil {
+		return nil, 0, nil
+	}
+	rw.Header().Set("Accept-Ranges", "bytes")
+	header := request.Header.Get("Range")
+	if header == "" || request.Method != http.MethodGet || opt.StatusCode != http.StatusOK {
+		return nil, src.size, nil
+	}
+	if !ifRangeMatch(request, opt.ETag, opt.LastModified) {
+		return nil, src.size, nil
+	}
+	ranges, err := parseRange(header, src.size)
+	if err != nil {
+		return nil, src.size, err
+	}
+	if len(ranges) == 0 || (!src.seekable && !ascending(ranges)) {
+		return nil, src.size, nil
+	}
+
+	if len(ranges) == 1 {
+		section, err := src.section(ranges[0])
+		if err != nil {
+			return nil, src.size, err
+		}
+		rw.Header().Set("Content-Range", ranges[0].contentRange(src.size))
+		rw.Header().Set("Content-Length", strconv.FormatInt(ranges[0].length, 10))
+		return &rangeResponse{body: section, contentType: contentType, close: func() {}}, src.size, nil
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		for _, r := range ranges {
+			partHeader := textproto.MIMEHeader{}
+			if contentType != "" {
+				partHeader.Set("Content-Type", contentType)
+			}
+			partHeader.Set("Content-Range", r.contentRange(src.size))
+			part, err := mw.CreatePart(partHeader)
+			if err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+			section, err := src.section(r)
+			if err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+			if _, err := io.Copy(part, section); err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+		}
+		_ = pw.CloseWithError(mw.Close())
+	}()
+	return &rangeResponse{
+		body:        pr,
+		contentType: "multipart/byteranges; boundary=" + mw.Boundary(),
+		close:       func() { _ = pr.Close() },
+	}, src.size, nil
+}
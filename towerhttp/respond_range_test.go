@@ -0,0 +1,233 @@
+package towerhttp
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    []byteRange
+		wantErr error
+	}{
+		{header: "bytes=0-4", want: []byteRange{{0, 5}}},
+		{header: "bytes=5-", want: []byteRange{{5, 5}}},
+		{header: "bytes=-3", want: []byteRange{{7, 3}}},
+		{header: "bytes=-20", want: []byteRange{{0, 10}}},
+		{header: "bytes=8-20", want: []byteRange{{8, 2}}},
+		{header: "bytes=0-1, 4-5", want: []byteRange{{0, 2}, {4, 2}}},
+		{header: "bytes=0-1, 20-30", want: []byteRange{{0, 2}}},
+		{header: "bytes=20-30", wantErr: errRangeUnsatisfiable},
+		{header: "bytes=-0", wantErr: errRangeUnsatisfiable},
+		{header: "bytes=5-4"},
+		{header: "bytes=abc"},
+		{header: "items=0-4"},
+		{header: "bytes=0-9, 0-9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, err := parseRange(tt.header, 10)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestResponder_RespondStreamRange(t *testing.T) {
+	const content = "0123456789abcdefghij"
+	modified := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		body         func() io.Reader
+		header       http.Header
+		opts         RespondOptionBuilder
+		status       int
+		contentRange string
+		want         string
+		wantParts    []string
+	}{
+		{
+			name:   "no range",
+			body:   func() io.Reader { return strings.NewReader(content) },
+			status: http.StatusOK,
+			want:   content,
+		},
+		{
+			name:         "single range",
+			body:         func() io.Reader { return strings.NewReader(content) },
+			header:       http.Header{"Range": {"bytes=2-5"}},
+			status:       http.StatusPartialContent,
+			contentRange: "bytes 2-5/20",
+			want:         "2345",
+		},
+		{
+			name:         "suffix range",
+			body:         func() io.Reader { return strings.NewReader(content) },
+			header:       http.Header{"Range": {"bytes=-3"}},
+			status:       http.StatusPartialContent,
+			contentRange: "bytes 17-19/20",
+			want:         "hij",
+		},
+		{
+			name:      "multiple ranges",
+			body:      func() io.Reader { return strings.NewReader(content) },
+			header:    http.Header{"Range": {"bytes=10-11, 0-1"}},
+			status:    http.StatusPartialContent,
+			wantParts: []string{"ab", "01"},
+		},
+		{
+			name:         "unsatisfiable range",
+			body:         func() io.Reader { return strings.NewReader(content) },
+			header:       http.Header{"Range": {"bytes=30-"}},
+			status:       http.StatusRequestedRangeNotSatisfiable,
+			contentRange: "bytes */20",
+		},
+		{
+			name:         "reader with content length",
+			body:         func() io.Reader { return io.MultiReader(strings.NewReader(content)) },
+			header:       http.Header{"Range": {"bytes=4-5"}},
+			opts:         Option.Respond().ContentLength(20),
+			status:       http.StatusPartialContent,
+			contentRange: "bytes 4-5/20",
+			want:         "45",
+		},
+		{
+			name:      "reader with content length in ascending order",
+			body:      func() io.Reader { return io.MultiReader(strings.NewReader(content)) },
+			header:    http.Header{"Range": {"bytes=0-1, 4-5"}},
+			opts:      Option.Respond().ContentLength(20),
+			status:    http.StatusPartialContent,
+			wantParts: []string{"01", "45"},
+		},
+		{
+			name:   "reader with content length in descending order sends whole body",
+			body:   func() io.Reader { return io.MultiReader(strings.NewReader(content)) },
+			header: http.Header{"Range": {"bytes=4-5, 0-1"}},
+			opts:   Option.Respond().ContentLength(20),
+			status: http.StatusOK,
+			want:   content,
+		},
+		{
+			name:   "reader without size sends whole body",
+			body:   func() io.Reader { return io.MultiReader(strings.NewReader(content)) },
+			header: http.Header{"Range": {"bytes=0-1"}},
+			status: http.StatusOK,
+			want:   content,
+		},
+		{
+			name:         "If-Range matches etag",
+			body:         func() io.Reader { return strings.NewReader(content) },
+			header:       http.Header{"Range": {"bytes=0-1"}, "If-Range": {`"v1"`}},
+			opts:         Option.Respond().ETag("v1"),
+			status:       http.StatusPartialContent,
+			contentRange: "bytes 0-1/20",
+			want:         "01",
+		},
+		{
+			name:   "If-Range does not match etag",
+			body:   func() io.Reader { return strings.NewReader(content) },
+			header: http.Header{"Range": {"bytes=0-1"}, "If-Range": {`"v2"`}},
+			opts:   Option.Respond().ETag("v1"),
+			status: http.StatusOK,
+			want:   content,
+		},
+		{
+			name:         "If-Range matches date",
+			body:         func() io.Reader { return strings.NewReader(content) },
+			header:       http.Header{"Range": {"bytes=0-1"}, "If-Range": {modified.Format(http.TimeFormat)}},
+			opts:         Option.Respond().LastModified(modified),
+			status:       http.StatusPartialContent,
+			contentRange: "bytes 0-1/20",
+			want:         "01",
+		},
+		{
+			name:   "If-Range with weak etag sends whole body",
+			body:   func() io.Reader { return strings.NewReader(content) },
+			header: http.Header{"Range": {"bytes=0-1"}, "If-Range": {`W/"v1"`}},
+			opts:   Option.Respond().ETag("v1"),
+			status: http.StatusOK,
+			want:   content,
+		},
+		{
+			name:   "If-None-Match responds not modified",
+			body:   func() io.Reader { return strings.NewReader(content) },
+			header: http.Header{"Range": {"bytes=0-1"}, "If-None-Match": {`"v1"`}},
+			opts:   Option.Respond().ETag("v1"),
+			status: http.StatusNotModified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responder := NewResponder()
+			var hookStatus int
+			responder.RegisterHook(NewRespondHook(Option.RespondHook().OnRespondStream(func(ctx *RespondStreamHookContext) {
+				hookStatus = ctx.ResponseStatus
+			}).OnRespondError(func(ctx *RespondErrorHookContext) {
+				hookStatus = ctx.ResponseStatus
+			})))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			rec := httptest.NewRecorder()
+			responder.RespondStream(rec, req, "text/plain", tt.body(), tt.opts)
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if hookStatus != tt.status {
+				t.Errorf("expected hook to see status %d, got %d", tt.status, hookStatus)
+			}
+			if got := rec.Header().Get("Content-Range"); got != tt.contentRange {
+				t.Errorf("expected Content-Range %q, got %q", tt.contentRange, got)
+			}
+			if tt.status == http.StatusPartialContent && rec.Header().Get("Content-Encoding") != "" {
+				t.Errorf("expected partial content to be uncompressed, got %q", rec.Header().Get("Content-Encoding"))
+			}
+			if tt.wantParts != nil {
+				mediaType, params, err := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+				if err != nil || mediaType != "multipart/byteranges" {
+					t.Fatalf("expected multipart/byteranges, got %q", rec.Header().Get("Content-Type"))
+				}
+				mr := multipart.NewReader(rec.Body, params["boundary"])
+				for _, want := range tt.wantParts {
+					part, err := mr.NextPart()
+					if err != nil {
+						t.Fatalf("failed to read part: %v", err)
+					}
+					if part.Header.Get("Content-Type") != "text/plain" {
+						t.Errorf("expected part Content-Type text/plain, got %q", part.Header.Get("Content-Type"))
+					}
+					b, _ := io.ReadAll(part)
+					if string(b) != want {
+						t.Errorf("expected part %q, got %q", want, b)
+					}
+				}
+				if _, err := mr.NextPart(); err != io.EOF {
+					t.Errorf("expected no more parts, got %v", err)
+				}
+				return
+			}
+			if tt.want != "" && rec.Body.String() != tt.want {
+				t.Errorf("expected body %q, got %q", tt.want, rec.Body.String())
+			}
+		})
+	}
+}
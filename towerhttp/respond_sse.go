@@ -0,0 +1,141 @@
+package towerhttp
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is a Server-Sent Event.
+//
+// See https://html.spec.whatwg.org/multipage/server-sent-events.html for details.
+type Event struct {
+	// ID is sent as the event ID. Clients send the last received ID in the Last-Event-ID header when reconnecting.
+	ID string
+	// Event is the event type. Empty value is treated as "message" by clients.
+	Event string
+	// Data is the payload of the event. string and []byte are written as is. Other values are encoded with the Encoder.
+	Data any
+	// Retry tells the client how long to wait before reconnecting. Zero value does not change the client's setting.
+	Retry time.Duration
+}
+
+// SSEResumeFunc returns the events the client missed since the event with lastEventID. The events are sent before the
+// events from the channel.
+type SSEResumeFunc = func(ctx context.Context, lastEventID string) []Event
+
+const defaultSSEHeartbeat = 15 * time.Second
+
+var sseHeartbeat = []byte(": heartbeat\n\n")
+
+// RespondSSE writes the events from the channel as Server-Sent Events (text/event-stream) until the channel is closed or
+// the client disconnects.
+//
+// Event data are encoded with the Encoder and flushed immediately. A comment is sent as heartbeat when there is no event
+// for the duration set by the Heartbeat RespondOption, defaulting to 15 seconds, to keep proxies from closing idle
+// connection.
+//
+// If the request has Last-Event-ID header and SSEResume RespondOption is set, the events returned by the resume function
+// are sent first.
+//
+// The response is never compressed. The stream hooks are called after the stream ends with the StreamSummary in
+// RespondStreamBody.Summary.
+func (r Responder) RespondSSE(rw http.ResponseWriter, request *http.Request, events <-chan Event, opts ...RespondOption) {
+	const contentType = "text/event-stream"
+	var (
+		err     error
+		summary = &StreamSummary{}
+		start   = time.Now()
+		timing  = newRespondTiming(request)
+	)
+	opt := r.buildOption(http.StatusOK, request, opts...)
+	if r.shortCircuit(rw, request, opt) {
+		return
+	}
+	if len(r.hooks) > 0 {
+		defer func() {
+			summary.Duration = time.Since(start)
+			timing.Write = summary.Duration
+			timing.finish()
+			r.callLiveStreamHooks(rw, request, opt, contentType, summary, timing, err)
+		}()
+	}
+	setLiveStreamHeader(rw.Header(), contentType)
+	r.setServerTiming(rw.Header(), timing)
+	rw.WriteHeader(opt.StatusCode)
+	w := newLiveStreamWriter(rw, summary)
+	w.flush()
+
+	ctx := request.Context()
+	write := func(event Event) error {
+		return writeSSEEvent(w, opt.Encoder, event)
+	}
+	if lastEventID := request.Header.Get("Last-Event-ID"); lastEventID != "" && opt.SSEResume != nil {
+		for _, event := range opt.SSEResume(ctx, lastEventID) {
+			if err = write(event); err != nil {
+				if ctx.Err() != nil {
+					summary.Canceled = true
+					err = nil
+				}
+				return
+			}
+			summary.Items++
+		}
+	}
+	heartbeat := opt.Heartbeat
+	if heartbeat == 0 {
+		heartbeat = defaultSSEHeartbeat
+	}
+	err = pumpLiveStream(ctx, w, events, heartbeat, sseHeartbeat, write)
+}
+
+func writeSSEEvent(w *liveStreamWriter, encoder Encoder, event Event) error {
+	var data []byte
+	switch v := event.Data.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		var err error
+		data, err = encoder.Encode(v)
+		if err != nil {
+			return err
+		}
+		data = bytes.TrimRight(data, "\r\n")
+	}
+	buf := &bytes.Buffer{}
+	buf.Grow(len(data) + 64)
+	if event.ID != "" {
+		buf.WriteString("id: ")
+		buf.WriteString(sanitizeSSEField(event.ID))
+		buf.WriteByte('\n')
+	}
+	if event.Event != "" {
+		buf.WriteString("event: ")
+		buf.WriteString(sanitizeSSEField(event.Event))
+		buf.WriteByte('\n')
+	}
+	if event.Retry > 0 {
+		buf.WriteString("retry: ")
+		buf.WriteString(strconv.FormatInt(event.Retry.Milliseconds(), 10))
+		buf.WriteByte('\n')
+	}
+	// Multiline data is sent as multiple data fields. Clients join them back with newline.
+	for _, line := range bytes.Split(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")), []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// sanitizeSSEField removes line breaks that would end the field early.
+func sanitizeSSEField(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
@@ -1,8 +1,11 @@
 package towerhttp
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/tigorlazuardi/tower"
 )
@@ -18,10 +21,20 @@ import (
 // and end the process.
 //
 // Body of nil will be treated as http.NoBody.
+//
+// Range requests are supported when the body implements io.Seeker, or the size is set by ContentLength RespondOption.
+// Satisfiable ranges of GET requests are responded with http.StatusPartialContent, with multipart/byteranges body for
+// multiple ranges, and unsatisfiable ranges with http.StatusRequestedRangeNotSatisfiable. If-Range header is evaluated
+// against ETag and LastModified RespondOption. Partial responses are never compressed, since the ranges refer to the
+// uncompressed body.
+//
+// Requests with If-None-Match or If-Modified-Since headers that match ETag or LastModified RespondOption are responded
+// with http.StatusNotModified.
 func (r Responder) RespondStream(rw http.ResponseWriter, request *http.Request, contentType string, body io.Reader, opts ...RespondOption) {
 	var (
 		statusCode = http.StatusOK
 		err        error
+		timing     = newRespondTiming(request)
 	)
 	if body == nil {
 		body = http.NoBody
@@ -30,6 +43,54 @@ func (r Responder) RespondStream(rw http.ResponseWriter, request *http.Request,
 		statusCode = ch.HTTPCode()
 	}
 	opt := r.buildOption(statusCode, request, opts...)
+	if r.shortCircuit(rw, request, opt) {
+		return
+	}
+	if opt.streamNotAcceptable && body != http.NoBody {
+		err = r.tower.
+			Bail("none of the content codings in Accept-Encoding header %q is supported", request.Header.Get("Accept-Encoding")).
+			Code(http.StatusNotAcceptable).
+			Caller(opt.Caller).
+			Freeze()
+		opt.StatusCode = http.StatusNotAcceptable
+		r.respondError(rw, request, err, opt)
+		return
+	}
+	var partial *rangeResponse
+	if body != http.NoBody && opt.StatusCode >= 200 && opt.StatusCode < 300 && notModified(request, opt.ETag, opt.LastModified) {
+		setCacheHeaders(rw.Header(), opt, opt.ETag)
+		opt.StatusCode = http.StatusNotModified
+		body = http.NoBody
+	}
+	if body != http.NoBody {
+		var size int64
+		partial, size, err = prepareRange(rw, request, opt, contentType, body)
+		if errors.Is(err, errRangeUnsatisfiable) {
+			rw.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+			err = r.tower.
+				Wrap(err).
+				Code(http.StatusRequestedRangeNotSatisfiable).
+				Message("range %q is not satisfiable for body of %d bytes", request.Header.Get("Range"), size).
+				Caller(opt.Caller).
+				Freeze()
+			opt.StatusCode = http.StatusRequestedRangeNotSatisfiable
+			r.respondError(rw, request, err, opt)
+			return
+		}
+		if err != nil {
+			err = r.tower.Wrap(err).Message("failed to read range of the body").Caller(opt.Caller).Freeze()
+			opt.StatusCode = tower.Query.GetHTTPCode(err)
+			r.respondError(rw, request, err, opt)
+			return
+		}
+		setCacheHeaders(rw.Header(), opt, opt.ETag)
+		if partial != nil {
+			defer partial.close()
+			opt.StatusCode = http.StatusPartialContent
+			body = partial.body
+			contentType = partial.contentType
+		}
+	}
 	if len(r.hooks) > 0 {
 		var clone ClonedBody = NoopCloneBody{}
 		count := r.hooks.CountMaximumRespondBodyRead(contentType, request)
@@ -41,6 +102,7 @@ func (r Responder) RespondStream(rw http.ResponseWriter, request *http.Request,
 			clone = s
 		}
 		defer func() {
+			timing.finish()
 			var requestBody ClonedBody = NoopCloneBody{}
 			if b, ok := request.Body.(ClonedBody); ok {
 				requestBody = b
@@ -56,30 +118,38 @@ func (r Responder) RespondStream(rw http.ResponseWriter, request *http.Request,
 					ResponseHeader: rw.Header(),
 					Tower:          r.tower,
 					Error:          err,
+					Timing:         timing,
 				},
 				ResponseBody: RespondStreamBody{
 					Value:       clone,
 					ContentType: contentType,
 				},
 			}
-			for _, hook := range r.hooks {
-				hook.RespondStreamHookContext(hookContext)
-			}
+			r.hooks.respondStream(r.tower, hookContext)
 		}()
 	}
 	if body == http.NoBody {
+		r.setServerTiming(rw.Header(), timing)
 		rw.WriteHeader(opt.StatusCode)
 		return
 	}
 
-	compressed, ok := opt.StreamCompressor.StreamCompress(contentType, body)
-	if ok {
-		rw.Header().Set("Content-Encoding", opt.StreamCompressor.ContentEncoding())
-		body = compressed
+	r.setVary(rw.Header(), true)
+	if partial == nil {
+		compressed, ok := opt.StreamCompressor.StreamCompress(contentType, body)
+		if ok {
+			// Ranges are offsets of the uncompressed body, so they are not advertised for the compressed one.
+			rw.Header().Del("Accept-Ranges")
+			rw.Header().Set("Content-Encoding", opt.StreamCompressor.ContentEncoding())
+			body = compressed
+		}
 	}
 	if len(contentType) > 0 {
 		rw.Header().Set("Content-Type", contentType)
 	}
+	r.setServerTiming(rw.Header(), timing)
 	rw.WriteHeader(opt.StatusCode)
+	writeStart := time.Now()
 	_, err = io.Copy(rw, body)
+	timing.Write = time.Since(writeStart)
 }
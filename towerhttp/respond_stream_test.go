@@ -92,6 +92,7 @@ func TestResponder_RespondStream(t *testing.T) {
 						"type": "test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
@@ -107,6 +108,9 @@ func TestResponder_RespondStream(t *testing.T) {
 						"response": {
 							"body": "hello world",
 							"headers": {
+								"Accept-Ranges": [
+									"bytes"
+								],
 								"Content-Type": [
 									"text/plain; charset=utf-8"
 								]
@@ -167,6 +171,7 @@ func TestResponder_RespondStream(t *testing.T) {
 						"type": "test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
@@ -243,6 +248,7 @@ func TestResponder_RespondStream(t *testing.T) {
 						"type": "test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
@@ -291,6 +297,9 @@ func TestResponder_RespondStream(t *testing.T) {
 				if err != nil {
 					t.Fatal(err)
 				}
+				if resp.Header.Get("Accept-Ranges") != "" {
+					t.Errorf("expected no Accept-Ranges header for the compressed body, got %s", resp.Header.Get("Accept-Ranges"))
+				}
 				wantBody := bytes.Repeat([]byte("a"), 2000)
 				if !bytes.Equal(body, wantBody) {
 					t.Errorf("expected body to be %s, got %s", wantBody, body)
@@ -313,6 +322,7 @@ func TestResponder_RespondStream(t *testing.T) {
 						"type": "test"
 					},
 					"context": {
+						"duration_ms": "<<PRESENCE>>",
 						"request": {
 							"headers": {
 								"Accept-Encoding": [
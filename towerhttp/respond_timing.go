@@ -0,0 +1,98 @@
+package towerhttp
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RespondTiming is the timing of the response.
+type RespondTiming struct {
+	// Start is the time the request enters the RequestBodyCloner or the AccessLog middleware. If the request does not
+	// pass through the middlewares, Start is the time the Responder starts responding.
+	Start time.Time
+	// Total is the duration from Start until the response is written.
+	Total time.Duration
+	// Encode is the duration of encoding the body.
+	Encode time.Duration
+	// Compress is the duration of compressing the encoded body. For streams, the body is compressed while written, so
+	// the duration is included in Write instead.
+	Compress time.Duration
+	// Write is the duration of writing the body to the http.ResponseWriter.
+	Write time.Duration
+}
+
+func newRespondTiming(request *http.Request) *RespondTiming {
+	start, ok := requestStart(request)
+	if !ok {
+		start = time.Now()
+	}
+	return &RespondTiming{Start: start}
+}
+
+// finish sets the total duration.
+func (t *RespondTiming) finish() {
+	t.Total = time.Since(t.Start)
+}
+
+// serverTiming formats the timing into Server-Timing header value. The write duration is not included since the header
+// is sent before the body is written.
+//
+// "app" is the time spent before the Responder encodes the body, which is mostly the handler's time.
+func (t *RespondTiming) serverTiming() string {
+	elapsed := time.Since(t.Start)
+	app := elapsed - t.Encode - t.Compress
+	s := &strings.Builder{}
+	writeServerTimingMetric(s, "app", app)
+	if t.Encode > 0 {
+		writeServerTimingMetric(s, "encode", t.Encode)
+	}
+	if t.Compress > 0 {
+		writeServerTimingMetric(s, "compress", t.Compress)
+	}
+	return s.String()
+}
+
+func writeServerTimingMetric(s *strings.Builder, name string, d time.Duration) {
+	if s.Len() > 0 {
+		s.WriteString(", ")
+	}
+	s.WriteString(name)
+	s.WriteString(";dur=")
+	s.WriteString(strconv.FormatFloat(durationMillis(d), 'f', 3, 64))
+}
+
+// durationMillis converts the duration to milliseconds with fraction.
+func durationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// setServerTiming sets the Server-Timing header if enabled.
+func (r Responder) setServerTiming(header http.Header, timing *RespondTiming) {
+	if r.serverTiming {
+		header.Set("Server-Timing", timing.serverTiming())
+	}
+}
+
+var requestStartKey = struct{ key int }{779}
+
+func contextWithRequestStart(ctx context.Context, start time.Time) context.Context {
+	return context.WithValue(ctx, requestStartKey, start)
+}
+
+func requestStart(r *http.Request) (time.Time, bool) {
+	start, ok := r.Context().Value(requestStartKey).(time.Time)
+	return start, ok
+}
+
+// requestLatency returns the time since the request entered the middleware. Returns zero if the request does not pass
+// through the middleware.
+func requestLatency(r *http.Request) time.Duration {
+	start, ok := requestStart(r)
+	if !ok {
+		return 0
+	}
+	return time.Since(start)
+}
@@ -0,0 +1,48 @@
+package towerhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestResponder_ServerTiming(t *testing.T) {
+	responder := NewResponder()
+	responder.SetServerTiming(true)
+	var timing *RespondTiming
+	responder.RegisterHook(NewRespondHook(Option.RespondHook().OnRespond(func(ctx *RespondHookContext) {
+		timing = ctx.Timing
+	})))
+	handler := responder.RequestBodyCloner()(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Millisecond * 5)
+		responder.Respond(rw, r, map[string]string{"ok": "ok"})
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	header := rec.Header().Get("Server-Timing")
+	if !regexp.MustCompile(`^app;dur=\d+\.\d{3}, encode;dur=\d+\.\d{3}`).MatchString(header) {
+		t.Errorf("unexpected Server-Timing header %q", header)
+	}
+	if timing == nil {
+		t.Fatal("expected hook to receive timing")
+	}
+	if timing.Total < time.Millisecond*5 {
+		t.Errorf("expected total duration to include handler time, got %s", timing.Total)
+	}
+	if timing.Encode <= 0 || timing.Total < timing.Encode+timing.Compress+timing.Write {
+		t.Errorf("unexpected timing %+v", timing)
+	}
+}
+
+func TestResponder_ServerTimingDisabled(t *testing.T) {
+	responder := NewResponder()
+	rec := httptest.NewRecorder()
+	responder.Respond(rec, httptest.NewRequest(http.MethodGet, "/", nil), map[string]string{"ok": "ok"})
+	if header := rec.Header().Get("Server-Timing"); header != "" {
+		t.Errorf("expected no Server-Timing header, got %q", header)
+	}
+}
@@ -0,0 +1,134 @@
+package towerhttp
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+)
+
+// featureWriter is an http.ResponseWriter wrapper used by the middlewares to observe the response. flush, hijack, and
+// push are only called when the underlying http.ResponseWriter supports them.
+type featureWriter interface {
+	http.ResponseWriter
+	io.ReaderFrom
+	Unwrap() http.ResponseWriter
+	flush()
+	hijack() (net.Conn, *bufio.ReadWriter, error)
+	push(target string, opts *http.PushOptions) error
+}
+
+type featureFlusher struct{ w featureWriter }
+
+func (f featureFlusher) Flush() { f.w.flush() }
+
+type featureHijacker struct{ w featureWriter }
+
+func (h featureHijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) { return h.w.hijack() }
+
+type featurePusher struct{ w featureWriter }
+
+func (p featurePusher) Push(target string, opts *http.PushOptions) error {
+	return p.w.push(target, opts)
+}
+
+// wrapResponseWriter returns the http.ResponseWriter for the next handler, which implements http.Flusher,
+// http.Hijacker, and http.Pusher only when the underlying http.ResponseWriter does, so feature detection with type
+// assertions keeps working.
+func wrapResponseWriter(w featureWriter) http.ResponseWriter {
+	_, flusher := w.Unwrap().(http.Flusher)
+	_, hijacker := w.Unwrap().(http.Hijacker)
+	_, pusher := w.Unwrap().(http.Pusher)
+	f, h, p := featureFlusher{w}, featureHijacker{w}, featurePusher{w}
+	switch {
+	case flusher && hijacker && pusher:
+		return struct {
+			featureWriter
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+		}{w, f, h, p}
+	case flusher && hijacker:
+		return struct {
+			featureWriter
+			http.Flusher
+			http.Hijacker
+		}{w, f, h}
+	case flusher && pusher:
+		return struct {
+			featureWriter
+			http.Flusher
+			http.Pusher
+		}{w, f, p}
+	case hijacker && pusher:
+		return struct {
+			featureWriter
+			http.Hijacker
+			http.Pusher
+		}{w, h, p}
+	case flusher:
+		return struct {
+			featureWriter
+			http.Flusher
+		}{w, f}
+	case hijacker:
+		return struct {
+			featureWriter
+			http.Hijacker
+		}{w, h}
+	case pusher:
+		return struct {
+			featureWriter
+			http.Pusher
+		}{w, p}
+	}
+	return w
+}
+
+// recoverWriter records whether the response is committed, so the Recover middleware knows whether it can still respond.
+type recoverWriter struct {
+	http.ResponseWriter
+	committed bool
+}
+
+var _ featureWriter = (*recoverWriter)(nil)
+
+func (w *recoverWriter) WriteHeader(code int) {
+	// Informational responses, like 103 Early Hints, do not commit the response.
+	if code >= http.StatusOK {
+		w.committed = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recoverWriter) Write(b []byte) (int, error) {
+	w.committed = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recoverWriter) ReadFrom(src io.Reader) (int64, error) {
+	w.committed = true
+	if rf, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		return rf.ReadFrom(src)
+	}
+	return io.Copy(struct{ io.Writer }{w.ResponseWriter}, src)
+}
+
+func (w *recoverWriter) flush() {
+	w.committed = true
+	w.ResponseWriter.(http.Flusher).Flush()
+}
+
+func (w *recoverWriter) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.committed = true
+	return w.ResponseWriter.(http.Hijacker).Hijack()
+}
+
+func (w *recoverWriter) push(target string, opts *http.PushOptions) error {
+	return w.ResponseWriter.(http.Pusher).Push(target, opts)
+}
+
+// Unwrap returns the underlying http.ResponseWriter. Used by http.ResponseController.
+func (w *recoverWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
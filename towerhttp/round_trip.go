@@ -5,6 +5,7 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/tigorlazuardi/tower"
 )
@@ -18,6 +19,12 @@ type RoundTripContext struct {
 	Error        error
 	Caller       tower.Caller
 	Tower        *tower.Tower
+	// Curl is the curl command that reproduces the request. Empty unless the hook is created with Curl
+	// RoundTripHookOption.
+	Curl string
+	// Attempts lists every attempt made to get the response. There are more than one attempt when the inner
+	// http.RoundTripper is wrapped with Retry.
+	Attempts []RoundTripAttempt
 }
 
 type RoundTrip struct {
@@ -34,14 +41,24 @@ var srcFile = strings.Join([]string{runtime.GOROOT(), sep, "src", sep, "net", se
 // RoundTrip implements http.RoundTripper interface.
 func (rt *RoundTrip) RoundTrip(req *http.Request) (*http.Response, error) {
 	var reqBody ClonedBody = NoopCloneBody{}
-	req = req.Clone(req.Context())
+	attempts := &roundTripAttempts{}
+	req = req.Clone(contextWithRoundTripAttempts(req.Context(), attempts))
 	wantReqBody := rt.hook.AcceptRequestBodySize(req)
 	if wantReqBody != 0 {
 		reqBodyClone := wrapBodyCloner(req.Body, wantReqBody)
 		req.Body = reqBodyClone
 		reqBody = reqBodyClone
 	}
+	start := time.Now()
 	res, err := rt.inner.RoundTrip(req)
+	attemptList := attempts.get()
+	if len(attemptList) == 0 {
+		attempt := RoundTripAttempt{Attempt: 1, Start: start, Duration: time.Since(start), Error: err}
+		if res != nil {
+			attempt.StatusCode = res.StatusCode
+		}
+		attemptList = append(attemptList, attempt)
+	}
 
 	caller := tower.GetCaller(rt.callerDepth)
 	// detect client.Get(), client.Head(), client.Post(), client.PostForm() request.
@@ -56,6 +73,7 @@ func (rt *RoundTrip) RoundTrip(req *http.Request) (*http.Response, error) {
 		Error:        err,
 		Tower:        rt.tower,
 		Caller:       caller,
+		Attempts:     attemptList,
 	}
 	if res != nil {
 		wantResBody := rt.hook.AcceptResponseBodySize(req, res)
@@ -0,0 +1,76 @@
+package towerhttp
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+// RoundTripAttempt is a single attempt to send the request to the server.
+type RoundTripAttempt struct {
+	// Attempt is the 1-based number of the attempt.
+	Attempt int
+	// Start is the time the attempt started.
+	Start time.Time
+	// Duration is the time it took to get the response or the error.
+	Duration time.Duration
+	// StatusCode is the status of the response. Zero if the attempt failed without response.
+	StatusCode int
+	// Error is the error of the attempt, if any.
+	Error error
+}
+
+var roundTripAttemptsKey = struct{ key int }{780}
+
+// roundTripAttempts collects the attempts made by the resilience wrappers beneath RoundTrip.
+type roundTripAttempts struct {
+	mu   sync.Mutex
+	list []RoundTripAttempt
+}
+
+func (r *roundTripAttempts) add(attempt RoundTripAttempt) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.list = append(r.list, attempt)
+}
+
+func (r *roundTripAttempts) get() []RoundTripAttempt {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]RoundTripAttempt, len(r.list))
+	copy(out, r.list)
+	return out
+}
+
+func contextWithRoundTripAttempts(ctx context.Context, attempts *roundTripAttempts) context.Context {
+	return context.WithValue(ctx, roundTripAttemptsKey, attempts)
+}
+
+// recordRoundTripAttempt records the attempt to the RoundTrip that wraps the request, if any.
+func recordRoundTripAttempt(req *http.Request, attempt RoundTripAttempt) {
+	if attempts, ok := req.Context().Value(roundTripAttemptsKey).(*roundTripAttempts); ok {
+		attempts.add(attempt)
+	}
+}
+
+func buildRoundTripAttemptsFields(f tower.Fields, attempts []RoundTripAttempt) tower.Fields {
+	list := make([]tower.Fields, 0, len(attempts))
+	for _, attempt := range attempts {
+		fields := tower.F{
+			"attempt":  attempt.Attempt,
+			"duration": attempt.Duration.String(),
+		}
+		if attempt.StatusCode != 0 {
+			fields["status"] = attempt.StatusCode
+		}
+		if attempt.Error != nil {
+			fields["error"] = attempt.Error.Error()
+		}
+		list = append(list, fields)
+	}
+	f["attempts"] = list
+	return f
+}
@@ -0,0 +1,284 @@
+package towerhttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+// ErrCircuitOpen is the cause of the error returned by CircuitBreaker when the circuit of the host is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of the circuit of a host.
+type CircuitState int8
+
+const (
+	// CircuitClosed lets requests through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects requests with ErrCircuitOpen.
+	CircuitOpen
+	// CircuitHalfOpen lets a single probe request through to check whether the host has recovered.
+	CircuitHalfOpen
+)
+
+func (c CircuitState) String() string {
+	switch c {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitFailureFunc decides whether the result of the request counts as failure. res is nil if err is not nil.
+type CircuitFailureFunc = func(res *http.Response, err error) bool
+
+// CircuitBreaker is an http.RoundTripper that stops sending requests to a host after consecutive failures.
+//
+// Every host has its own circuit. The circuit opens after the number of consecutive failures set by FailureThreshold
+// CircuitBreakerOption, and rejects requests with error caused by ErrCircuitOpen with http.StatusServiceUnavailable
+// code. After the duration set by OpenDuration CircuitBreakerOption, the circuit becomes half-open and lets a single probe
+// request through. The circuit closes if the probe succeeds, otherwise it opens again.
+//
+// Every state transition is logged as tower.Entry at warn level, and sent to the messengers if Notify
+// CircuitBreakerOption is set.
+//
+// Requests canceled by the caller do not count as failure.
+type CircuitBreaker struct {
+	inner            http.RoundTripper
+	tower            *tower.Tower
+	failureThreshold int
+	openDuration     time.Duration
+	isFailure        CircuitFailureFunc
+	notify           bool
+	notifyOpts       []tower.MessageOption
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+type circuitTransition struct {
+	host     string
+	from, to CircuitState
+	failures int
+}
+
+// WrapCircuitBreaker wraps the given http.RoundTripper with CircuitBreaker. If rt is nil, http.DefaultTransport is used.
+//
+// By default, the circuit opens after 5 consecutive failures for 30 seconds. Transport errors and 5xx responses count
+// as failure.
+func WrapCircuitBreaker(rt http.RoundTripper, opts ...CircuitBreakerOption) *CircuitBreaker {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	cb := &CircuitBreaker{
+		inner:            rt,
+		tower:            tower.Global.Tower(),
+		failureThreshold: 5,
+		openDuration:     30 * time.Second,
+		isFailure: func(res *http.Response, err error) bool {
+			return err != nil || res.StatusCode >= 500
+		},
+		circuits: map[string]*circuit{},
+	}
+	for _, opt := range opts {
+		opt.apply(cb)
+	}
+	return cb
+}
+
+// State returns the current state of the circuit of the host.
+func (cb *CircuitBreaker) State(host string) CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if c, ok := cb.circuits[host]; ok {
+		return c.state
+	}
+	return CircuitClosed
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (cb *CircuitBreaker) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	allowed, probe, transition := cb.allow(host)
+	cb.emit(req.Context(), transition)
+	if !allowed {
+		return nil, cb.tower.Wrap(ErrCircuitOpen).
+			Code(http.StatusServiceUnavailable).
+			Message("circuit breaker is open for host %s", host).
+			Context(tower.F{"host": host, "method": req.Method, "url": redactedURL(req.URL)}).
+			Freeze()
+	}
+	res, err := cb.inner.RoundTrip(req)
+	if err != nil && req.Context().Err() != nil {
+		cb.release(host, probe)
+		return res, err
+	}
+	cb.emit(req.Context(), cb.report(host, probe, cb.isFailure(res, err)))
+	return res, err
+}
+
+func (cb *CircuitBreaker) allow(host string) (allowed, probe bool, transition *circuitTransition) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	c, ok := cb.circuits[host]
+	if !ok {
+		return true, false, nil
+	}
+	switch c.state {
+	case CircuitOpen:
+		if time.Since(c.openedAt) < cb.openDuration {
+			return false, false, nil
+		}
+		transition = &circuitTransition{host: host, from: CircuitOpen, to: CircuitHalfOpen, failures: c.failures}
+		c.state = CircuitHalfOpen
+		c.probing = true
+		return true, true, transition
+	case CircuitHalfOpen:
+		if c.probing {
+			return false, false, nil
+		}
+		c.probing = true
+		return true, true, nil
+	}
+	return true, false, nil
+}
+
+// release gives up the probe without changing the state, so the next request can probe the host.
+func (cb *CircuitBreaker) release(host string, probe bool) {
+	if !probe {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if c, ok := cb.circuits[host]; ok {
+		c.probing = false
+	}
+}
+
+func (cb *CircuitBreaker) report(host string, probe, failed bool) *circuitTransition {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	c, ok := cb.circuits[host]
+	if !ok {
+		if !failed {
+			return nil
+		}
+		c = &circuit{}
+		cb.circuits[host] = c
+	}
+	if probe {
+		c.probing = false
+	}
+	if !failed {
+		if c.state == CircuitHalfOpen && probe {
+			transition := &circuitTransition{host: host, from: CircuitHalfOpen, to: CircuitClosed, failures: c.failures}
+			delete(cb.circuits, host)
+			return transition
+		}
+		if c.state == CircuitClosed {
+			delete(cb.circuits, host)
+		}
+		return nil
+	}
+	c.failures++
+	switch {
+	case c.state == CircuitHalfOpen && probe,
+		c.state == CircuitClosed && c.failures >= cb.failureThreshold:
+		transition := &circuitTransition{host: host, from: c.state, to: CircuitOpen, failures: c.failures}
+		c.state = CircuitOpen
+		c.openedAt = time.Now()
+		return transition
+	}
+	return nil
+}
+
+func (cb *CircuitBreaker) emit(ctx context.Context, transition *circuitTransition) {
+	if transition == nil {
+		return
+	}
+	entry := cb.tower.NewEntry("circuit breaker for host %s is %s", transition.host, transition.to).
+		Level(tower.WarnLevel).
+		Context(tower.F{
+			"host":     transition.host,
+			"from":     transition.from.String(),
+			"to":       transition.to.String(),
+			"failures": transition.failures,
+		}).
+		Freeze()
+	entry.Log(ctx)
+	if cb.notify {
+		entry.Notify(ctx, cb.notifyOpts...)
+	}
+}
+
+type CircuitBreakerOption interface {
+	apply(*CircuitBreaker)
+}
+
+type (
+	CircuitBreakerOptionBuilder []CircuitBreakerOption
+	circuitBreakerOptionFunc    func(*CircuitBreaker)
+)
+
+func (c circuitBreakerOptionFunc) apply(cb *CircuitBreaker) {
+	c(cb)
+}
+
+func (c CircuitBreakerOptionBuilder) apply(cb *CircuitBreaker) {
+	for _, v := range c {
+		v.apply(cb)
+	}
+}
+
+// Tower sets the tower instance used to log the state transitions and create the errors. Defaults to the global tower
+// instance.
+func (c CircuitBreakerOptionBuilder) Tower(t *tower.Tower) CircuitBreakerOptionBuilder {
+	return append(c, circuitBreakerOptionFunc(func(cb *CircuitBreaker) {
+		cb.tower = t
+	}))
+}
+
+// FailureThreshold sets the number of consecutive failures to open the circuit. Defaults to 5.
+func (c CircuitBreakerOptionBuilder) FailureThreshold(n int) CircuitBreakerOptionBuilder {
+	return append(c, circuitBreakerOptionFunc(func(cb *CircuitBreaker) {
+		cb.failureThreshold = n
+	}))
+}
+
+// OpenDuration sets how long the circuit stays open before letting a probe request through. Defaults to 30 seconds.
+func (c CircuitBreakerOptionBuilder) OpenDuration(d time.Duration) CircuitBreakerOptionBuilder {
+	return append(c, circuitBreakerOptionFunc(func(cb *CircuitBreaker) {
+		cb.openDuration = d
+	}))
+}
+
+// IsFailure sets the function to decide whether the result counts as failure. Defaults to transport errors and 5xx
+// responses.
+func (c CircuitBreakerOptionBuilder) IsFailure(f CircuitFailureFunc) CircuitBreakerOptionBuilder {
+	return append(c, circuitBreakerOptionFunc(func(cb *CircuitBreaker) {
+		cb.isFailure = f
+	}))
+}
+
+// Notify sends the state transitions to the messengers, in addition to logging them.
+func (c CircuitBreakerOptionBuilder) Notify(opts ...tower.MessageOption) CircuitBreakerOptionBuilder {
+	return append(c, circuitBreakerOptionFunc(func(cb *CircuitBreaker) {
+		cb.notify = true
+		cb.notifyOpts = opts
+	}))
+}
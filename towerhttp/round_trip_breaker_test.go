@@ -0,0 +1,88 @@
+package towerhttp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+func TestCircuitBreaker_RoundTrip(t *testing.T) {
+	var healthy int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.LoadInt32(&healthy) == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	tow := tower.NewTower(tower.Service{Name: "TestCircuitBreaker", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	cb := WrapCircuitBreaker(nil, Option.CircuitBreaker().
+		Tower(tow).
+		FailureThreshold(2).
+		OpenDuration(50*time.Millisecond),
+	)
+	client := &http.Client{Transport: cb}
+	get := func() (*http.Response, error) {
+		res, err := client.Get(server.URL)
+		if res != nil {
+			_ = res.Body.Close()
+		}
+		return res, err
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := get(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if state := cb.State(host); state != CircuitOpen {
+		t.Fatalf("expected circuit to be open, got %s", state)
+	}
+	_, err := get()
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if code := tower.Query.GetHTTPCode(urlErr.Err); code != http.StatusServiceUnavailable {
+			t.Errorf("expected code 503, got %d", code)
+		}
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	atomic.StoreInt32(&healthy, 1)
+	res, err := get()
+	if err != nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("expected probe to succeed, got %v", err)
+	}
+	if state := cb.State(host); state != CircuitClosed {
+		t.Fatalf("expected circuit to be closed, got %s", state)
+	}
+
+	out := logger.String()
+	for _, want := range []string{
+		`"message":"circuit breaker for host ` + host + ` is open"`,
+		`"message":"circuit breaker for host ` + host + ` is half-open"`,
+		`"message":"circuit breaker for host ` + host + ` is closed"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected log to contain %s, got %s", want, out)
+		}
+	}
+	if strings.Count(out, `"level":"warn"`) != 3 {
+		t.Errorf("expected 3 warn entries, got %s", out)
+	}
+}
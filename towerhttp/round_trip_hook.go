@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/tigorlazuardi/tower"
 )
@@ -36,6 +35,8 @@ type roundTripHook struct {
 	filterRequest    RoundTripFilterRequest
 	filterResponse   RoundTripFilterResponse
 	log              RoundTripExecuteHookFunc
+	curl             bool
+	curlMaskHeaders  []string
 }
 
 func (rth *roundTripHook) AcceptRequestBodySize(r *http.Request) int {
@@ -53,6 +54,9 @@ func (rth *roundTripHook) AcceptResponseBodySize(req *http.Request, res *http.Re
 }
 
 func (rth *roundTripHook) ExecuteHook(ctx *RoundTripContext) {
+	if rth.curl {
+		ctx.Curl = CurlCommand(ctx.Request, ctx.RequestBody, rth.curlMaskHeaders...)
+	}
 	rth.log(ctx)
 }
 
@@ -105,6 +109,15 @@ func (r RoundTripHookOptionBuilder) Log(log RoundTripExecuteHookFunc) RoundTripH
 	}))
 }
 
+// Curl adds the curl command that reproduces the request to RoundTripContext.Curl, which the default log function adds
+// to the log context. Values of headers that may hold secrets and maskHeaders are masked. See CurlCommand for details.
+func (r RoundTripHookOptionBuilder) Curl(maskHeaders ...string) RoundTripHookOptionBuilder {
+	return append(r, RoundTripHookOptionFunc(func(hook *roundTripHook) {
+		hook.curl = true
+		hook.curlMaskHeaders = maskHeaders
+	}))
+}
+
 func defaultRoundTriptHookOpts() RoundTripHookOptionBuilder {
 	return Option.RoundTripHook().
 		ReadRequestBodyLimit(1024 * 1024).
@@ -120,6 +133,12 @@ func defaultRounTripLogFunc(ctx *RoundTripContext) {
 	if ctx.Response != nil {
 		fields = buildClientResponseFields(fields, ctx.Response, ctx.ResponseBody)
 	}
+	if ctx.Curl != "" {
+		fields["curl"] = ctx.Curl
+	}
+	if len(ctx.Attempts) > 1 {
+		fields = buildRoundTripAttemptsFields(fields, ctx.Attempts)
+	}
 	if ctx.Error != nil {
 		builder := ctx.Tower.Wrap(ctx.Error).Context(fields)
 		if ctx.Response != nil {
@@ -161,7 +180,7 @@ func buildClientRequestFields(f tower.Fields, req *http.Request, body ClonedBody
 		switch {
 		case body.Truncated():
 			fields["body"] = fmt.Sprintf("%s (truncated)", body.String()[0:body.Limit()])
-		case strings.Contains(contentType, "application/json") && isJson(b):
+		case isJSONContentType(contentType) && isJson(b):
 			fields["body"] = json.RawMessage(b)
 		case contentType == "" && isJsonLite(b) && isJson(b):
 			fields["body"] = json.RawMessage(b)
@@ -187,7 +206,7 @@ func buildClientResponseFields(f tower.Fields, res *http.Response, body ClonedBo
 		switch {
 		case body.Truncated():
 			fields["body"] = fmt.Sprintf("%s (truncated)", body.String()[0:body.Limit()])
-		case strings.Contains(contentType, "application/json") && isJson(b):
+		case isJSONContentType(contentType) && isJson(b):
 			fields["body"] = json.RawMessage(body.CloneBytes())
 		case contentType == "" && isJsonLite(b) && isJson(b):
 			fields["body"] = json.RawMessage(body.CloneBytes())
@@ -0,0 +1,300 @@
+package towerhttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type (
+	// RetryPolicy decides whether the attempt should be retried. res is nil if err is not nil.
+	RetryPolicy = func(req *http.Request, res *http.Response, err error) bool
+	// RetryBackoff returns how long to wait before the next attempt. attempt is the 1-based number of the failed attempt.
+	RetryBackoff = func(attempt int) time.Duration
+)
+
+// Retry is an http.RoundTripper that retries failed requests with backoff.
+//
+// Only requests with idempotent methods (GET, HEAD, OPTIONS, TRACE, PUT, DELETE) are retried by default. The request
+// body is read into memory before the first attempt, unless the request has GetBody, so it can be replayed on every
+// attempt.
+//
+// Retry-After header of 429 and 503 responses is honored, as long as it does not exceed the max backoff.
+//
+// Wrap the inner http.RoundTripper with WrapTimeout to limit the duration of each attempt, and wrap Retry with
+// RoundTrip to log the request with every attempt in RoundTripContext.Attempts.
+type Retry struct {
+	inner       http.RoundTripper
+	maxAttempts int
+	maxBackoff  time.Duration
+	backoff     RetryBackoff
+	policy      RetryPolicy
+	methods     map[string]bool
+}
+
+// WrapRetry wraps the given http.RoundTripper with Retry. If rt is nil, http.DefaultTransport is used.
+//
+// By default, requests are attempted 3 times with exponential backoff starting from 100ms with jitter, up to 5s. Transport
+// errors and 429, 502, 503, 504 responses are retried. Errors caused by ErrCircuitOpen or by the canceled request
+// context are not retried.
+func WrapRetry(rt http.RoundTripper, opts ...RetryOption) *Retry {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	retry := &Retry{
+		inner:       rt,
+		maxAttempts: 3,
+		maxBackoff:  5 * time.Second,
+		backoff:     ExponentialBackoff(100 * time.Millisecond),
+		policy:      defaultRetryPolicy,
+		methods: map[string]bool{
+			http.MethodGet:     true,
+			http.MethodHead:    true,
+			http.MethodOptions: true,
+			http.MethodTrace:   true,
+			http.MethodPut:     true,
+			http.MethodDelete:  true,
+		},
+	}
+	for _, opt := range opts {
+		opt.apply(retry)
+	}
+	return retry
+}
+
+// ExponentialBackoff returns RetryBackoff that doubles the base duration on every attempt, with up to 50% random jitter.
+func ExponentialBackoff(base time.Duration) RetryBackoff {
+	return func(attempt int) time.Duration {
+		d := base << (attempt - 1)
+		if d <= 0 {
+			return base
+		}
+		return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+	}
+}
+
+func defaultRetryPolicy(req *http.Request, res *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, ErrCircuitOpen) && req.Context().Err() == nil
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (r *Retry) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !r.methods[req.Method] || r.maxAttempts <= 1 {
+		return r.attempt(req, 1)
+	}
+	ctx := req.Context()
+	// http.RoundTripper must not modify the request, so the body is buffered on a clone.
+	req = req.Clone(ctx)
+	getBody, err := replayableBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 {
+			attemptReq = req.Clone(ctx)
+			if getBody != nil {
+				if attemptReq.Body, err = getBody(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		res, err := r.attempt(attemptReq, attempt)
+		if attempt >= r.maxAttempts || ctx.Err() != nil || !r.policy(attemptReq, res, err) {
+			return res, err
+		}
+		wait := r.wait(attempt, res)
+		if res != nil {
+			// Drain the body so the connection can be reused.
+			_, _ = io.CopyN(io.Discard, res.Body, 4096)
+			_ = res.Body.Close()
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (r *Retry) attempt(req *http.Request, attempt int) (*http.Response, error) {
+	start := time.Now()
+	res, err := r.inner.RoundTrip(req)
+	a := RoundTripAttempt{Attempt: attempt, Start: start, Duration: time.Since(start), Error: err}
+	if res != nil {
+		a.StatusCode = res.StatusCode
+	}
+	recordRoundTripAttempt(req, a)
+	return res, err
+}
+
+func (r *Retry) wait(attempt int, res *http.Response) time.Duration {
+	wait := r.backoff(attempt)
+	if res != nil && (res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable) {
+		if after, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			wait = after
+		}
+	}
+	if r.maxBackoff > 0 && wait > r.maxBackoff {
+		wait = r.maxBackoff
+	}
+	return wait
+}
+
+// replayableBody returns a function to get a fresh copy of the request body for the next attempts. The body is read
+// into memory if the request has no GetBody, and req.Body is replaced with the buffered copy, so req must be a clone
+// owned by the caller.
+func replayableBody(req *http.Request) (func() (io.ReadCloser, error), error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		return req.GetBody, nil
+	}
+	b, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	getBody := func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+	req.Body, _ = getBody()
+	req.GetBody = getBody
+	return getBody, nil
+}
+
+func parseRetryAfter(s string) (time.Duration, bool) {
+	if s == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(s); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(s); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// Timeout is an http.RoundTripper that limits the duration of each request, including reading the response body.
+//
+// Wrapped by Retry, the timeout applies to each attempt instead of the whole request.
+type Timeout struct {
+	inner   http.RoundTripper
+	timeout time.Duration
+}
+
+// WrapTimeout wraps the given http.RoundTripper with Timeout. If rt is nil, http.DefaultTransport is used.
+func WrapTimeout(rt http.RoundTripper, timeout time.Duration) *Timeout {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &Timeout{inner: rt, timeout: timeout}
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (t *Timeout) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.inner.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	res, err := t.inner.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return res, err
+	}
+	res.Body = &cancelOnCloseBody{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// cancelOnCloseBody cancels the context of the request when the response body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnCloseBody) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+type RetryOption interface {
+	apply(*Retry)
+}
+
+type (
+	RetryOptionBuilder []RetryOption
+	retryOptionFunc    func(*Retry)
+)
+
+func (r retryOptionFunc) apply(retry *Retry) {
+	r(retry)
+}
+
+func (r RetryOptionBuilder) apply(retry *Retry) {
+	for _, v := range r {
+		v.apply(retry)
+	}
+}
+
+// MaxAttempts sets the maximum number of attempts, including the first one. Defaults to 3.
+func (r RetryOptionBuilder) MaxAttempts(n int) RetryOptionBuilder {
+	return append(r, retryOptionFunc(func(retry *Retry) {
+		retry.maxAttempts = n
+	}))
+}
+
+// Backoff sets the duration to wait between attempts. Defaults to ExponentialBackoff(100 * time.Millisecond).
+func (r RetryOptionBuilder) Backoff(backoff RetryBackoff) RetryOptionBuilder {
+	return append(r, retryOptionFunc(func(retry *Retry) {
+		retry.backoff = backoff
+	}))
+}
+
+// MaxBackoff caps the duration to wait between attempts, including the one from Retry-After header. Defaults to 5s.
+// Zero or negative value disables the cap.
+func (r RetryOptionBuilder) MaxBackoff(d time.Duration) RetryOptionBuilder {
+	return append(r, retryOptionFunc(func(retry *Retry) {
+		retry.maxBackoff = d
+	}))
+}
+
+// Policy sets the function to decide whether the attempt should be retried. Defaults to retry transport errors and
+// 429, 502, 503, 504 responses.
+func (r RetryOptionBuilder) Policy(policy RetryPolicy) RetryOptionBuilder {
+	return append(r, retryOptionFunc(func(retry *Retry) {
+		retry.policy = policy
+	}))
+}
+
+// Methods replaces the methods that are retried. Defaults to idempotent methods: GET, HEAD, OPTIONS, TRACE, PUT, and
+// DELETE.
+func (r RetryOptionBuilder) Methods(methods ...string) RetryOptionBuilder {
+	return append(r, retryOptionFunc(func(retry *Retry) {
+		retry.methods = make(map[string]bool, len(methods))
+		for _, method := range methods {
+			retry.methods[method] = true
+		}
+	}))
+}
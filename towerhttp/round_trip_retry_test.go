@@ -0,0 +1,211 @@
+package towerhttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+func TestRetry_RoundTrip(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		method   string
+		status   int
+		attempts int
+	}{
+		{name: "idempotent method is retried with body", method: http.MethodPut, status: http.StatusOK, attempts: 3},
+		{name: "non idempotent method is not retried", method: http.MethodPost, status: http.StatusServiceUnavailable, attempts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+			var got *RoundTripContext
+			retry := WrapRetry(nil, Option.Retry().Backoff(func(int) time.Duration { return time.Millisecond }))
+			client := &http.Client{Transport: WrapRoundTripper(retry, Option.RoundTrip().Hook(NewRoundTripHook(
+				Option.RoundTripHook().Log(func(ctx *RoundTripContext) { got = ctx }),
+			)))}
+			req, _ := http.NewRequest(tt.method, server.URL, strings.NewReader("payload"))
+			res, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_, _ = io.ReadAll(res.Body)
+			_ = res.Body.Close()
+			if res.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, res.StatusCode)
+			}
+			if got == nil {
+				t.Fatal("expected hook to be called")
+			}
+			if len(got.Attempts) != tt.attempts {
+				t.Fatalf("expected %d attempts, got %d", tt.attempts, len(got.Attempts))
+			}
+			for i, attempt := range got.Attempts {
+				if attempt.Attempt != i+1 {
+					t.Errorf("expected attempt number %d, got %d", i+1, attempt.Attempt)
+				}
+			}
+			if last := got.Attempts[len(got.Attempts)-1]; last.StatusCode != tt.status {
+				t.Errorf("expected last attempt status %d, got %d", tt.status, last.StatusCode)
+			}
+		})
+	}
+}
+
+func TestRetry_RoundTrip_DoesNotModifyRequest(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if atomic.AddInt32(&calls, 1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	// Hides the strings.Reader type, so http.NewRequest does not set GetBody.
+	body := io.NopCloser(struct{ io.Reader }{strings.NewReader("payload")})
+	req, _ := http.NewRequest(http.MethodPut, server.URL, body)
+	retry := WrapRetry(nil, Option.Retry().Backoff(func(int) time.Duration { return time.Millisecond }))
+	res, err := retry.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, res.StatusCode)
+	}
+	if req.Body != body {
+		t.Error("expected request body to not be replaced")
+	}
+	if req.GetBody != nil {
+		t.Error("expected request GetBody to not be set")
+	}
+}
+
+func TestRetry_Timeout(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	var attempts []RoundTripAttempt
+	transport := WrapRetry(WrapTimeout(nil, 50*time.Millisecond), Option.Retry().Backoff(func(int) time.Duration { return 0 }))
+	client := &http.Client{Transport: WrapRoundTripper(transport, Option.RoundTrip().Hook(NewRoundTripHook(
+		Option.RoundTripHook().Log(func(ctx *RoundTripContext) { attempts = ctx.Attempts }),
+	)))}
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if string(body) != "ok" {
+		t.Errorf("expected body ok, got %q", body)
+	}
+	if len(attempts) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(attempts))
+	}
+	if !errors.Is(attempts[0].Error, context.DeadlineExceeded) {
+		t.Errorf("expected first attempt to time out, got %v", attempts[0].Error)
+	}
+}
+
+func TestRetry_CircuitBreaker(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cb := WrapCircuitBreaker(nil, Option.CircuitBreaker().FailureThreshold(1).OpenDuration(time.Minute))
+	var attempts []RoundTripAttempt
+	transport := WrapRetry(cb, Option.Retry().Backoff(func(int) time.Duration { return time.Millisecond }))
+	client := &http.Client{Transport: WrapRoundTripper(transport, Option.RoundTrip().Hook(NewRoundTripHook(
+		Option.RoundTripHook().Log(func(ctx *RoundTripContext) { attempts = ctx.Attempts }),
+	)))}
+	_, err := client.Get(server.URL)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if len(attempts) != 2 {
+		t.Fatalf("expected the open circuit to stop retries after 2 attempts, got %d", len(attempts))
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected 1 request to reach the server, got %d", n)
+	}
+}
+
+func TestRetry_defaultRetryPolicy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceled, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	tests := []struct {
+		name string
+		req  *http.Request
+		err  error
+		want bool
+	}{
+		{name: "transport error", req: req, err: errors.New("connection reset"), want: true},
+		{name: "circuit open", req: req, err: tower.Wrap(ErrCircuitOpen).Freeze(), want: false},
+		{name: "canceled request", req: canceled, err: context.Canceled, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultRetryPolicy(tt.req, nil, tt.err); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("2"); !ok || d != 2*time.Second {
+		t.Errorf("expected 2s, got %v %v", d, ok)
+	}
+	if d, ok := parseRetryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)); !ok || d != 0 {
+		t.Errorf("expected 0 for past date, got %v %v", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("expected invalid value to be rejected")
+	}
+}
@@ -0,0 +1,24 @@
+package towerhttp
+
+import "context"
+
+var routeKey = struct{ key int }{781}
+
+// RouteFunc returns the route pattern matched by the router, e.g. "/users/:id".
+type RouteFunc = func() string
+
+// ContextWithRoute stores the route pattern of the request in the context. The logger hook and AccessLog add the route
+// to the request fields, so requests to the same endpoint can be grouped regardless of the path parameters.
+//
+// The route is resolved lazily, because some routers only know the matched route after the middlewares run.
+func ContextWithRoute(ctx context.Context, route RouteFunc) context.Context {
+	return context.WithValue(ctx, routeKey, route)
+}
+
+// RouteFromContext returns the route pattern stored by ContextWithRoute. Returns empty string if there is none.
+func RouteFromContext(ctx context.Context) string {
+	if route, ok := ctx.Value(routeKey).(RouteFunc); ok && route != nil {
+		return route()
+	}
+	return ""
+}
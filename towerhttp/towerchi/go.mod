@@ -0,0 +1,20 @@
+module github.com/tigorlazuardi/tower/towerhttp/towerchi
+
+go 1.19
+
+require (
+	github.com/tigorlazuardi/tower v0.8.1
+	github.com/tigorlazuardi/tower/towerhttp v0.8.1
+)
+
+require (
+	github.com/andybalholm/brotli v1.0.5 // indirect
+	github.com/fxamacker/cbor/v2 v2.5.0 // indirect
+	github.com/go-chi/chi/v5 v5.0.8
+	github.com/klauspost/compress v1.16.7 // indirect
+	github.com/tigorlazuardi/tower/cache v0.8.1 // indirect
+	github.com/tigorlazuardi/tower/pool v0.8.1 // indirect
+	github.com/vmihailenco/msgpack/v5 v5.4.1 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+)
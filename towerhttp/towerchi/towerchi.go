@@ -0,0 +1,75 @@
+// Package towerchi integrates towerhttp.Responder with chi.
+package towerchi
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tigorlazuardi/tower/towerhttp"
+)
+
+var skip = towerhttp.Option.Respond().AddCallerSkip(1)
+
+// Responder adds chi integration to towerhttp.Responder. chi handlers use net/http types, so the respond methods are
+// the same as towerhttp.Responder, with the caller adjusted to point to the handler.
+type Responder struct {
+	responder *towerhttp.Responder
+}
+
+// New creates a Responder around the given towerhttp.Responder. If responder is nil, the global responder from
+// towerhttp.Exported.Responder() is used.
+func New(responder *towerhttp.Responder) *Responder {
+	if responder == nil {
+		responder = towerhttp.Exported.Responder()
+	}
+	return &Responder{responder: responder}
+}
+
+// Middleware clones the request body for the hooks, recovers panics, and adds the matched route pattern to the logger
+// fields. Register it with Router.Use.
+func (r *Responder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, request *http.Request) {
+		// chi resolves the pattern after the middlewares of the router run, so the route is read lazily from the
+		// routing context that chi fills in place.
+		rctx := chi.RouteContext(request.Context())
+		request = request.WithContext(towerhttp.ContextWithRoute(request.Context(), func() string {
+			if rctx == nil {
+				return ""
+			}
+			return rctx.RoutePattern()
+		}))
+		r.responder.RequestBodyCloner()(r.responder.Recover()(next)).ServeHTTP(rw, request)
+	})
+}
+
+// NotFound responds with http.StatusNotFound tower.Error. Set it with Router.NotFound.
+func (r *Responder) NotFound(rw http.ResponseWriter, request *http.Request) {
+	err := r.responder.Tower().Bail("route %s %s not found", request.Method, request.URL.Path).
+		Code(http.StatusNotFound).
+		Freeze()
+	r.responder.RespondError(rw, request, err)
+}
+
+// MethodNotAllowed responds with http.StatusMethodNotAllowed tower.Error. Set it with Router.MethodNotAllowed.
+func (r *Responder) MethodNotAllowed(rw http.ResponseWriter, request *http.Request) {
+	err := r.responder.Tower().Bail("method %s is not allowed for %s", request.Method, request.URL.Path).
+		Code(http.StatusMethodNotAllowed).
+		Freeze()
+	r.responder.RespondError(rw, request, err)
+}
+
+// Respond writes the body with towerhttp.Responder.Respond.
+func (r *Responder) Respond(rw http.ResponseWriter, request *http.Request, body any, opts ...towerhttp.RespondOption) {
+	r.responder.Respond(rw, request, body, append([]towerhttp.RespondOption{skip}, opts...)...)
+}
+
+// RespondError writes the error with towerhttp.Responder.RespondError.
+func (r *Responder) RespondError(rw http.ResponseWriter, request *http.Request, err error, opts ...towerhttp.RespondOption) {
+	r.responder.RespondError(rw, request, err, append([]towerhttp.RespondOption{skip}, opts...)...)
+}
+
+// RespondStream writes the stream with towerhttp.Responder.RespondStream.
+func (r *Responder) RespondStream(rw http.ResponseWriter, request *http.Request, contentType string, body io.Reader, opts ...towerhttp.RespondOption) {
+	r.responder.RespondStream(rw, request, contentType, body, append([]towerhttp.RespondOption{skip}, opts...)...)
+}
@@ -0,0 +1,90 @@
+package towerchi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/towerhttp"
+)
+
+func TestResponder(t *testing.T) {
+	tow := tower.NewTower(tower.Service{Name: "TestTowerChi", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	responder := towerhttp.NewResponder()
+	responder.SetTower(tow)
+	responder.RegisterHook(towerhttp.NewLoggerHook())
+	r := New(responder)
+
+	router := chi.NewRouter()
+	router.Use(r.Middleware)
+	router.NotFound(r.NotFound)
+	router.MethodNotAllowed(r.MethodNotAllowed)
+	router.Route("/users", func(router chi.Router) {
+		router.Get("/{id}", func(rw http.ResponseWriter, request *http.Request) {
+			r.Respond(rw, request, map[string]string{"id": chi.URLParam(request, "id")})
+		})
+	})
+	router.Get("/panic", func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		status   int
+		response string
+		log      string
+	}{
+		{name: "respond", method: http.MethodGet, target: "/users/1", status: http.StatusOK, response: `{"id":"1"}`, log: `"route":"/users/{id}"`},
+		{name: "not found", method: http.MethodGet, target: "/missing", status: http.StatusNotFound, response: `route GET /missing not found`, log: `"code":404`},
+		{name: "method not allowed", method: http.MethodPost, target: "/panic", status: http.StatusMethodNotAllowed, response: `method POST is not allowed`, log: `"code":405`},
+		{name: "panic", method: http.MethodGet, target: "/panic", status: http.StatusInternalServerError, response: `Internal Server Error`, log: `"route":"/panic"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger.Reset()
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, nil))
+			if rec.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), tt.response) {
+				t.Errorf("expected response to contain %s, got %s", tt.response, rec.Body.String())
+			}
+			if !strings.Contains(logger.String(), tt.log) {
+				t.Errorf("expected log to contain %s, got %s", tt.log, logger.String())
+			}
+		})
+	}
+}
+
+func TestResponder_PanicAfterWrite(t *testing.T) {
+	tow := tower.NewTower(tower.Service{Name: "TestTowerChi", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	responder := towerhttp.NewResponder()
+	responder.SetTower(tow)
+	r := New(responder)
+
+	router := chi.NewRouter()
+	router.Use(r.Middleware)
+	router.Get("/panic", func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusAccepted)
+		_, _ = rw.Write([]byte("partial"))
+		panic("boom")
+	})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "partial" {
+		t.Errorf("expected the written response to be kept, got %d %q", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(logger.String(), "panic after the response is written") {
+		t.Errorf("expected the panic to be logged, got %s", logger.String())
+	}
+}
@@ -0,0 +1,29 @@
+module github.com/tigorlazuardi/tower/towerhttp/towerecho
+
+go 1.19
+
+require (
+	github.com/labstack/echo/v4 v4.10.2
+	github.com/tigorlazuardi/tower v0.8.1
+	github.com/tigorlazuardi/tower/towerhttp v0.8.1
+)
+
+require (
+	github.com/andybalholm/brotli v1.0.5 // indirect
+	github.com/fxamacker/cbor/v2 v2.5.0 // indirect
+	github.com/klauspost/compress v1.16.7 // indirect
+	github.com/labstack/gommon v0.4.0 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.17 // indirect
+	github.com/tigorlazuardi/tower/cache v0.8.1 // indirect
+	github.com/tigorlazuardi/tower/pool v0.8.1 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/valyala/fasttemplate v1.2.2 // indirect
+	github.com/vmihailenco/msgpack/v5 v5.4.1 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+	golang.org/x/crypto v0.6.0 // indirect
+	golang.org/x/net v0.7.0 // indirect
+	golang.org/x/sys v0.5.0 // indirect
+	golang.org/x/text v0.7.0 // indirect
+)
@@ -0,0 +1,129 @@
+// Package towerecho integrates towerhttp.Responder with echo.
+package towerecho
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/towerhttp"
+)
+
+var skip = towerhttp.Option.Respond().AddCallerSkip(1)
+
+// Responder exposes towerhttp.Responder methods on echo.Context.
+type Responder struct {
+	responder *towerhttp.Responder
+}
+
+// New creates a Responder around the given towerhttp.Responder. If responder is nil, the global responder from
+// towerhttp.Exported.Responder() is used.
+func New(responder *towerhttp.Responder) *Responder {
+	if responder == nil {
+		responder = towerhttp.Exported.Responder()
+	}
+	return &Responder{responder: responder}
+}
+
+// Middleware clones the request body for the hooks, recovers panics, and adds the matched route to the logger fields.
+//
+// Register it with Echo.Use, so the route is already matched when the middleware runs.
+func (r *Responder) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			handler := http.HandlerFunc(func(_ http.ResponseWriter, request *http.Request) {
+				c.SetRequest(request)
+				err = next(c)
+			})
+			request := c.Request().WithContext(towerhttp.ContextWithRoute(c.Request().Context(), c.Path))
+			r.responder.RequestBodyCloner()(r.responder.Recover()(handler)).ServeHTTP(committedWriter{c.Response()}, request)
+			return err
+		}
+	}
+}
+
+// HTTPErrorHandler responds the errors returned by the handlers with RespondError. Set it as Echo.HTTPErrorHandler.
+func (r *Responder) HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+	r.responder.RespondError(c.Response(), c.Request(), r.Error(err))
+}
+
+// Respond writes the body with towerhttp.Responder.Respond. It always returns nil, so it can be returned from the
+// handler.
+func (r *Responder) Respond(c echo.Context, body any, opts ...towerhttp.RespondOption) error {
+	r.responder.Respond(c.Response(), c.Request(), body, append([]towerhttp.RespondOption{skip}, opts...)...)
+	return nil
+}
+
+// RespondError maps the error with Error and writes it with towerhttp.Responder.RespondError. It always returns nil,
+// so it can be returned from the handler.
+func (r *Responder) RespondError(c echo.Context, err error, opts ...towerhttp.RespondOption) error {
+	r.responder.RespondError(c.Response(), c.Request(), r.Error(err), append([]towerhttp.RespondOption{skip}, opts...)...)
+	return nil
+}
+
+// RespondStream writes the stream with towerhttp.Responder.RespondStream. It always returns nil, so it can be returned
+// from the handler.
+func (r *Responder) RespondStream(c echo.Context, contentType string, body io.Reader, opts ...towerhttp.RespondOption) error {
+	r.responder.RespondStream(c.Response(), c.Request(), contentType, body, append([]towerhttp.RespondOption{skip}, opts...)...)
+	return nil
+}
+
+// Error maps echo errors into tower.Error:
+//
+// - *echo.BindingError gets its code, with the invalid field added to the error context as
+// towerhttp.Public{"fields": towerhttp.FieldErrors}.
+//
+// - *echo.HTTPError gets its code and message. The internal error is used as the cause if there is one.
+//
+// Other errors, including tower.Error, are returned as is.
+func (r *Responder) Error(err error) error {
+	var towerErr tower.Error
+	if errors.As(err, &towerErr) {
+		return err
+	}
+	var (
+		bindingErr *echo.BindingError
+		httpErr    *echo.HTTPError
+	)
+	switch {
+	case errors.As(err, &bindingErr):
+		return r.wrap(bindingErr.HTTPError).
+			Context(towerhttp.Public{"fields": towerhttp.FieldErrors{{
+				Field:   bindingErr.Field,
+				Message: fmt.Sprint(bindingErr.Message),
+			}}}).
+			Freeze()
+	case errors.As(err, &httpErr):
+		return r.wrap(httpErr).Freeze()
+	}
+	return err
+}
+
+func (r *Responder) wrap(httpErr *echo.HTTPError) tower.ErrorBuilder {
+	var cause error = httpErr
+	if httpErr.Internal != nil {
+		cause = httpErr.Internal
+	}
+	return r.responder.Tower().Wrap(cause).
+		Code(httpErr.Code).
+		Message(fmt.Sprint(httpErr.Message))
+}
+
+// committedWriter reports the Committed field of echo.Response to towerhttp.Responder.Recover, since the handlers write
+// with echo.Context instead of the writer given by the middlewares.
+type committedWriter struct {
+	*echo.Response
+}
+
+var _ towerhttp.WrittenWriter = committedWriter{}
+
+// Written implements towerhttp.WrittenWriter.
+func (w committedWriter) Written() bool {
+	return w.Committed
+}
@@ -0,0 +1,96 @@
+package towerecho
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/towerhttp"
+)
+
+func TestResponder(t *testing.T) {
+	tow := tower.NewTower(tower.Service{Name: "TestTowerEcho", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	responder := towerhttp.NewResponder()
+	responder.SetTower(tow)
+	responder.RegisterHook(towerhttp.NewLoggerHook())
+	r := New(responder)
+
+	e := echo.New()
+	e.HTTPErrorHandler = r.HTTPErrorHandler
+	e.Use(r.Middleware())
+	e.GET("/users/:id", func(c echo.Context) error {
+		return r.Respond(c, map[string]string{"id": c.Param("id")})
+	})
+	e.GET("/bind", func(c echo.Context) error {
+		var q struct {
+			Page int `query:"page"`
+		}
+		return echo.QueryParamsBinder(c).Int("page", &q.Page).BindError()
+	})
+	e.GET("/forbidden", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusForbidden, "no access")
+	})
+	e.GET("/panic", func(c echo.Context) error {
+		panic("boom")
+	})
+
+	tests := []struct {
+		name     string
+		target   string
+		status   int
+		response string
+		log      string
+	}{
+		{name: "respond", target: "/users/1", status: http.StatusOK, response: `{"id":"1"}`, log: `"route":"/users/:id"`},
+		{name: "binding error", target: "/bind?page=x", status: http.StatusBadRequest, response: `failed to bind field value to int`, log: `"field":"page"`},
+		{name: "http error", target: "/forbidden", status: http.StatusForbidden, response: `no access`, log: `"route":"/forbidden"`},
+		{name: "not found", target: "/missing", status: http.StatusNotFound, response: `Not Found`, log: `"code":404`},
+		{name: "panic", target: "/panic", status: http.StatusInternalServerError, response: `Internal Server Error`, log: `"route":"/panic"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger.Reset()
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if rec.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), tt.response) {
+				t.Errorf("expected response to contain %s, got %s", tt.response, rec.Body.String())
+			}
+			if !strings.Contains(logger.String(), tt.log) {
+				t.Errorf("expected log to contain %s, got %s", tt.log, logger.String())
+			}
+		})
+	}
+}
+
+func TestResponder_PanicAfterWrite(t *testing.T) {
+	tow := tower.NewTower(tower.Service{Name: "TestTowerEcho", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	responder := towerhttp.NewResponder()
+	responder.SetTower(tow)
+	r := New(responder)
+
+	e := echo.New()
+	e.HTTPErrorHandler = r.HTTPErrorHandler
+	e.Use(r.Middleware())
+	e.GET("/panic", func(c echo.Context) error {
+		_ = c.String(http.StatusAccepted, "partial")
+		panic("boom")
+	})
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "partial" {
+		t.Errorf("expected the written response to be kept, got %d %q", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(logger.String(), "panic after the response is written") {
+		t.Errorf("expected the panic to be logged, got %s", logger.String())
+	}
+}
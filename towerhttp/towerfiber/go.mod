@@ -0,0 +1,28 @@
+module github.com/tigorlazuardi/tower/towerhttp/towerfiber
+
+go 1.19
+
+require (
+	github.com/gofiber/fiber/v2 v2.41.0
+	github.com/tigorlazuardi/tower v0.8.1
+	github.com/tigorlazuardi/tower/towerhttp v0.8.1
+	github.com/valyala/fasthttp v1.44.0
+)
+
+require (
+	github.com/andybalholm/brotli v1.0.5 // indirect
+	github.com/fxamacker/cbor/v2 v2.5.0 // indirect
+	github.com/klauspost/compress v1.16.7 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.17 // indirect
+	github.com/mattn/go-runewidth v0.0.14 // indirect
+	github.com/rivo/uniseg v0.2.0 // indirect
+	github.com/tigorlazuardi/tower/cache v0.8.1 // indirect
+	github.com/tigorlazuardi/tower/pool v0.8.1 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/valyala/tcplisten v1.0.0 // indirect
+	github.com/vmihailenco/msgpack/v5 v5.4.1 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+	golang.org/x/sys v0.0.0-20220811171246-fbc7d0a398ab // indirect
+)
@@ -0,0 +1,168 @@
+// Package towerfiber integrates towerhttp.Responder with fiber.
+//
+// fiber is built on fasthttp, so the requests are converted to *http.Request and the responses are written through an
+// http.ResponseWriter that writes to the fiber.Ctx. The converted requests share memory with fasthttp, so they must not
+// be used after the handler returns.
+package towerfiber
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/towerhttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+var skip = towerhttp.Option.Respond().AddCallerSkip(1)
+
+// Responder exposes towerhttp.Responder methods on fiber.Ctx.
+type Responder struct {
+	responder *towerhttp.Responder
+}
+
+// New creates a Responder around the given towerhttp.Responder. If responder is nil, the global responder from
+// towerhttp.Exported.Responder() is used.
+func New(responder *towerhttp.Responder) *Responder {
+	if responder == nil {
+		responder = towerhttp.Exported.Responder()
+	}
+	return &Responder{responder: responder}
+}
+
+// Middleware clones the request body for the hooks, recovers panics, and adds the matched route to the logger fields.
+// Register it with App.Use.
+func (r *Responder) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		rw, request, err := r.convert(c)
+		if err != nil {
+			return err
+		}
+		request = request.WithContext(towerhttp.ContextWithRoute(request.Context(), func() string {
+			return c.Route().Path
+		}))
+		handler := http.HandlerFunc(func(_ http.ResponseWriter, request *http.Request) {
+			// fiber handlers read the body from fiber.Ctx, so the body is read here for the hooks to see.
+			_, _ = io.Copy(io.Discard, request.Body)
+			c.SetUserContext(request.Context())
+			err = c.Next()
+		})
+		r.responder.RequestBodyCloner()(r.responder.Recover()(handler)).ServeHTTP(rw, request)
+		return err
+	}
+}
+
+// ErrorHandler responds the errors returned by the handlers with RespondError. Set it as fiber.Config.ErrorHandler.
+func (r *Responder) ErrorHandler(c *fiber.Ctx, err error) error {
+	rw, request, convertErr := r.convert(c)
+	if convertErr != nil {
+		return convertErr
+	}
+	r.responder.RespondError(rw, request, r.Error(err))
+	return nil
+}
+
+// Respond writes the body with towerhttp.Responder.Respond.
+func (r *Responder) Respond(c *fiber.Ctx, body any, opts ...towerhttp.RespondOption) error {
+	rw, request, err := r.convert(c)
+	if err != nil {
+		return err
+	}
+	r.responder.Respond(rw, request, body, append([]towerhttp.RespondOption{skip}, opts...)...)
+	return nil
+}
+
+// RespondError maps the error with Error and writes it with towerhttp.Responder.RespondError.
+func (r *Responder) RespondError(c *fiber.Ctx, err error, opts ...towerhttp.RespondOption) error {
+	rw, request, convertErr := r.convert(c)
+	if convertErr != nil {
+		return convertErr
+	}
+	r.responder.RespondError(rw, request, r.Error(err), append([]towerhttp.RespondOption{skip}, opts...)...)
+	return nil
+}
+
+// RespondStream writes the stream with towerhttp.Responder.RespondStream.
+func (r *Responder) RespondStream(c *fiber.Ctx, contentType string, body io.Reader, opts ...towerhttp.RespondOption) error {
+	rw, request, err := r.convert(c)
+	if err != nil {
+		return err
+	}
+	r.responder.RespondStream(rw, request, contentType, body, append([]towerhttp.RespondOption{skip}, opts...)...)
+	return nil
+}
+
+// Error maps *fiber.Error into tower.Error with its code and message. Other errors, including tower.Error, are returned
+// as is.
+func (r *Responder) Error(err error) error {
+	var towerErr tower.Error
+	if errors.As(err, &towerErr) {
+		return err
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return r.responder.Tower().Wrap(err).
+			Code(fiberErr.Code).
+			Message(fiberErr.Message).
+			Freeze()
+	}
+	return err
+}
+
+func (r *Responder) convert(c *fiber.Ctx) (http.ResponseWriter, *http.Request, error) {
+	request := &http.Request{}
+	if err := fasthttpadaptor.ConvertRequest(c.Context(), request, true); err != nil {
+		return nil, nil, r.responder.Tower().Wrap(err).
+			Code(http.StatusBadRequest).
+			Message("failed to convert fiber request").
+			Freeze()
+	}
+	return &responseWriter{c: c, header: http.Header{}}, request.WithContext(c.UserContext()), nil
+}
+
+// writtenKey is the fiber.Ctx local set when any responseWriter of the request writes the header.
+const writtenKey = "towerfiber-written"
+
+// responseWriter writes the response to fiber.Ctx.
+type responseWriter struct {
+	c           *fiber.Ctx
+	header      http.Header
+	wroteHeader bool
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.c.Locals(writtenKey, true)
+	for key, values := range w.header {
+		w.c.Response().Header.Del(key)
+		for _, value := range values {
+			w.c.Response().Header.Add(key, value)
+		}
+	}
+	w.c.Status(statusCode)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.c.Write(b)
+}
+
+var _ towerhttp.WrittenWriter = (*responseWriter)(nil)
+
+// Written implements towerhttp.WrittenWriter. The response is written if any responseWriter of the request wrote it, or
+// if the handlers wrote the body with fiber.Ctx.
+func (w *responseWriter) Written() bool {
+	written, _ := w.c.Locals(writtenKey).(bool)
+	return written || len(w.c.Response().Body()) > 0
+}
@@ -0,0 +1,124 @@
+package towerfiber
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/towerhttp"
+)
+
+func TestResponder(t *testing.T) {
+	tow := tower.NewTower(tower.Service{Name: "TestTowerFiber", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	responder := towerhttp.NewResponder()
+	responder.SetTower(tow)
+	responder.RegisterHook(towerhttp.NewLoggerHook())
+	r := New(responder)
+
+	app := fiber.New(fiber.Config{ErrorHandler: r.ErrorHandler})
+	app.Use(r.Middleware())
+	app.Post("/users/:id", func(c *fiber.Ctx) error {
+		var body map[string]string
+		if err := c.BodyParser(&body); err != nil {
+			return err
+		}
+		body["id"] = c.Params("id")
+		return r.Respond(c, body)
+	})
+	app.Get("/teapot", func(c *fiber.Ctx) error {
+		return fiber.NewError(http.StatusTeapot, "short and stout")
+	})
+	app.Get("/panic", func(c *fiber.Ctx) error {
+		panic("boom")
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		body     string
+		status   int
+		response string
+		log      []string
+	}{
+		{
+			name:     "respond",
+			method:   http.MethodPost,
+			target:   "/users/1",
+			body:     `{"name":"tower"}`,
+			status:   http.StatusOK,
+			response: `{"id":"1","name":"tower"}`,
+			log:      []string{`"route":"/users/:id"`, `"body":{"name":"tower"}`},
+		},
+		{name: "fiber error", method: http.MethodGet, target: "/teapot", status: http.StatusTeapot, response: `short and stout`, log: []string{`"code":418`}},
+		{name: "panic", method: http.MethodGet, target: "/panic", status: http.StatusInternalServerError, response: `Internal Server Error`, log: []string{`"route":"/panic"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger.Reset()
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			body, _ := io.ReadAll(res.Body)
+			if res.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, res.StatusCode)
+			}
+			if !strings.Contains(string(body), tt.response) {
+				t.Errorf("expected response to contain %s, got %s", tt.response, body)
+			}
+			if got := res.Header.Get("Content-Type"); got != "application/json" {
+				t.Errorf("expected content type application/json, got %s", got)
+			}
+			for _, want := range tt.log {
+				if !strings.Contains(logger.String(), want) {
+					t.Errorf("expected log to contain %s, got %s", want, logger.String())
+				}
+			}
+		})
+	}
+}
+
+func TestResponder_PanicAfterWrite(t *testing.T) {
+	tow := tower.NewTower(tower.Service{Name: "TestTowerFiber", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	responder := towerhttp.NewResponder()
+	responder.SetTower(tow)
+	r := New(responder)
+
+	app := fiber.New(fiber.Config{ErrorHandler: r.ErrorHandler})
+	app.Use(r.Middleware())
+	app.Get("/panic", func(c *fiber.Ctx) error {
+		_ = c.Status(http.StatusAccepted).SendString("partial")
+		panic("boom")
+	})
+	app.Get("/panic-after-respond", func(c *fiber.Ctx) error {
+		_ = r.Respond(c, map[string]string{"ok": "ok"}, towerhttp.Option.Respond().StatusCode(http.StatusAccepted))
+		panic("boom")
+	})
+	for target, want := range map[string]string{"/panic": "partial", "/panic-after-respond": "{\"ok\":\"ok\"}\n"} {
+		t.Run(target, func(t *testing.T) {
+			logger.Reset()
+			res, err := app.Test(httptest.NewRequest(http.MethodGet, target, nil))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			body, _ := io.ReadAll(res.Body)
+			if res.StatusCode != http.StatusAccepted || string(body) != want {
+				t.Errorf("expected the written response to be kept, got %d %q", res.StatusCode, body)
+			}
+			if !strings.Contains(logger.String(), "panic after the response is written") {
+				t.Errorf("expected the panic to be logged, got %s", logger.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Package towergin integrates towerhttp.Responder with gin.
+package towergin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/towerhttp"
+)
+
+var skip = towerhttp.Option.Respond().AddCallerSkip(1)
+
+// Responder exposes towerhttp.Responder methods on gin.Context.
+type Responder struct {
+	responder *towerhttp.Responder
+}
+
+// New creates a Responder around the given towerhttp.Responder. If responder is nil, the global responder from
+// towerhttp.Exported.Responder() is used.
+func New(responder *towerhttp.Responder) *Responder {
+	if responder == nil {
+		responder = towerhttp.Exported.Responder()
+	}
+	return &Responder{responder: responder}
+}
+
+// Middleware clones the request body for the hooks, recovers panics, and adds the matched route to the logger fields.
+//
+// Errors added to the context with c.Error, including binding errors from c.Bind, are responded with RespondError if the
+// handlers did not write the response body.
+func (r *Responder) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		handler := http.HandlerFunc(func(_ http.ResponseWriter, request *http.Request) {
+			defer func() {
+				// Stops the rest of the handlers from running after the panic is recovered.
+				if p := recover(); p != nil {
+					c.Abort()
+					panic(p)
+				}
+			}()
+			c.Request = request
+			c.Next()
+			// c.Bind writes the status immediately without body, so the error is still written as long as there is no
+			// body yet.
+			if len(c.Errors) > 0 && c.Writer.Size() <= 0 {
+				r.RespondError(c, c.Errors.Last())
+			}
+		})
+		request := c.Request.WithContext(towerhttp.ContextWithRoute(c.Request.Context(), c.FullPath))
+		r.responder.RequestBodyCloner()(r.responder.Recover()(handler)).ServeHTTP(c.Writer, request)
+	}
+}
+
+// Respond writes the body with towerhttp.Responder.Respond.
+func (r *Responder) Respond(c *gin.Context, body any, opts ...towerhttp.RespondOption) {
+	r.responder.Respond(c.Writer, c.Request, body, append([]towerhttp.RespondOption{skip}, opts...)...)
+}
+
+// RespondError maps the error with Error and writes it with towerhttp.Responder.RespondError.
+func (r *Responder) RespondError(c *gin.Context, err error, opts ...towerhttp.RespondOption) {
+	r.responder.RespondError(c.Writer, c.Request, r.Error(err), append([]towerhttp.RespondOption{skip}, opts...)...)
+}
+
+// RespondStream writes the stream with towerhttp.Responder.RespondStream.
+func (r *Responder) RespondStream(c *gin.Context, contentType string, body io.Reader, opts ...towerhttp.RespondOption) {
+	r.responder.RespondStream(c.Writer, c.Request, contentType, body, append([]towerhttp.RespondOption{skip}, opts...)...)
+}
+
+// Error maps gin errors into tower.Error:
+//
+// - *gin.Error is unwrapped to its cause. Binding errors get http.StatusBadRequest code.
+//
+// - validator.ValidationErrors get http.StatusBadRequest code, with the invalid fields added to the error context as
+// towerhttp.Public{"fields": towerhttp.FieldErrors}.
+//
+// - JSON syntax and type errors, and empty or incomplete body get http.StatusBadRequest code.
+//
+// Other errors, including tower.Error, are returned as is.
+func (r *Responder) Error(err error) error {
+	var (
+		bind   bool
+		ginErr *gin.Error
+	)
+	if errors.As(err, &ginErr) && ginErr.Err != nil {
+		bind = ginErr.IsType(gin.ErrorTypeBind)
+		err = ginErr.Err
+	}
+	var towerErr tower.Error
+	if errors.As(err, &towerErr) {
+		return err
+	}
+	var (
+		validationErrs validator.ValidationErrors
+		syntaxErr      *json.SyntaxError
+		typeErr        *json.UnmarshalTypeError
+		t              = r.responder.Tower()
+	)
+	switch {
+	case errors.As(err, &validationErrs):
+		fields := make(towerhttp.FieldErrors, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, towerhttp.FieldError{Field: fe.Field(), Message: validationMessage(fe)})
+		}
+		return t.Wrap(err).
+			Code(http.StatusBadRequest).
+			Message("request is invalid").
+			Context(towerhttp.Public{"fields": fields}).
+			Freeze()
+	case errors.As(err, &typeErr):
+		return t.Wrap(err).
+			Code(http.StatusBadRequest).
+			Message("request body has invalid field").
+			Context(towerhttp.Public{"fields": towerhttp.FieldErrors{{
+				Field:   typeErr.Field,
+				Message: fmt.Sprintf("must be of type %s", typeErr.Type),
+			}}}).
+			Freeze()
+	case errors.Is(err, io.EOF):
+		return t.Wrap(err).Code(http.StatusBadRequest).Message("request body is empty").Freeze()
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return t.Wrap(err).Code(http.StatusBadRequest).Message("request body is incomplete").Freeze()
+	case errors.As(err, &syntaxErr):
+		return t.Wrap(err).
+			Code(http.StatusBadRequest).
+			Message("request body is malformed at offset %d", syntaxErr.Offset).
+			Freeze()
+	case bind:
+		return t.Wrap(err).Code(http.StatusBadRequest).Message("failed to bind request").Freeze()
+	}
+	return err
+}
+
+func validationMessage(fe validator.FieldError) string {
+	if fe.Param() != "" {
+		return fmt.Sprintf("failed on %s=%s validation", fe.Tag(), fe.Param())
+	}
+	return fmt.Sprintf("failed on %s validation", fe.Tag())
+}
@@ -0,0 +1,87 @@
+package towergin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/towerhttp"
+)
+
+func TestResponder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tow := tower.NewTower(tower.Service{Name: "TestTowerGin", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	responder := towerhttp.NewResponder()
+	responder.SetTower(tow)
+	responder.RegisterHook(towerhttp.NewLoggerHook())
+	r := New(responder)
+
+	type payload struct {
+		Name string `json:"name" binding:"required"`
+	}
+	engine := gin.New()
+	engine.Use(r.Middleware())
+	engine.GET("/users/:id", func(c *gin.Context) {
+		r.Respond(c, map[string]string{"id": c.Param("id")})
+	})
+	engine.POST("/should-bind", func(c *gin.Context) {
+		var p payload
+		if err := c.ShouldBindJSON(&p); err != nil {
+			r.RespondError(c, err)
+			return
+		}
+		r.Respond(c, p)
+	})
+	engine.POST("/bind", func(c *gin.Context) {
+		var p payload
+		_ = c.BindJSON(&p)
+	})
+	engine.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	engine.GET("/panic-after-write", func(c *gin.Context) {
+		c.String(http.StatusAccepted, "partial")
+		panic("boom")
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		body     string
+		status   int
+		response string
+		exact    bool
+		log      string
+	}{
+		{name: "respond", method: http.MethodGet, target: "/users/1", status: http.StatusOK, response: `{"id":"1"}`, log: `"route":"/users/:id"`},
+		{name: "validation error", method: http.MethodPost, target: "/should-bind", body: `{}`, status: http.StatusBadRequest, response: `request is invalid`, log: `"field":"Name"`},
+		{name: "syntax error", method: http.MethodPost, target: "/should-bind", body: `{`, status: http.StatusBadRequest, response: `request body is incomplete`, log: `"route":"/should-bind"`},
+		{name: "bind error from context", method: http.MethodPost, target: "/bind", body: `{}`, status: http.StatusBadRequest, response: `request is invalid`, log: `"route":"/bind"`},
+		{name: "panic", method: http.MethodGet, target: "/panic", status: http.StatusInternalServerError, response: `Internal Server Error`, log: `"route":"/panic"`},
+		{name: "panic after write", method: http.MethodGet, target: "/panic-after-write", status: http.StatusAccepted, response: `partial`, exact: true, log: `panic after the response is written`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger.Reset()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			engine.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), tt.response) || tt.exact && rec.Body.String() != tt.response {
+				t.Errorf("expected response to contain %s, got %s", tt.response, rec.Body.String())
+			}
+			if !strings.Contains(logger.String(), tt.log) {
+				t.Errorf("expected log to contain %s, got %s", tt.log, logger.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Package towergorilla integrates towerhttp.WebSocketSession with github.com/gorilla/websocket.
+package towergorilla
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/towerhttp"
+)
+
+// Responder upgrades WebSocket connections that are logged with the tower instance of towerhttp.Responder.
+type Responder struct {
+	responder *towerhttp.Responder
+}
+
+// New creates a Responder around the given towerhttp.Responder. If responder is nil, the global responder from
+// towerhttp.Exported.Responder() is used.
+func New(responder *towerhttp.Responder) *Responder {
+	if responder == nil {
+		responder = towerhttp.Exported.Responder()
+	}
+	return &Responder{responder: responder}
+}
+
+// Upgrade upgrades the connection with the given websocket.Upgrader, and wraps the connection with a
+// towerhttp.WebSocketSession, which logs the connection. If upgrader is nil, a zero value websocket.Upgrader is used.
+//
+// If the upgrade fails, the upgrader has already written the response, and the returned error is a tower.Error with
+// http.StatusBadRequest code and the request metadata.
+func (r *Responder) Upgrade(upgrader *websocket.Upgrader, rw http.ResponseWriter, request *http.Request, responseHeader http.Header, sessionOpts ...towerhttp.WebSocketOption) (*Conn, error) {
+	if upgrader == nil {
+		upgrader = &websocket.Upgrader{}
+	}
+	conn, err := upgrader.Upgrade(rw, request, responseHeader)
+	if err != nil {
+		return nil, r.responder.Tower().Wrap(err).
+			Code(http.StatusBadRequest).
+			Message("websocket handshake failed: %s", request.URL.Path).
+			Context(tower.F{"method": request.Method, "url": towerhttp.WebSocketRedactedURL(request), "remote_addr": request.RemoteAddr}).
+			Freeze()
+	}
+	sessionOpts = append([]towerhttp.WebSocketOption{
+		towerhttp.Option.WebSocket().Tower(r.responder.Tower()).Subprotocol(conn.Subprotocol()),
+	}, sessionOpts...)
+	sessionOpts = append(sessionOpts, towerhttp.Option.WebSocket().AddCallerSkip(1))
+	return &Conn{Conn: conn, session: towerhttp.NewWebSocketSession(request, sessionOpts...)}, nil
+}
+
+// Conn wraps websocket.Conn to record the frames, close status, and errors to the towerhttp.WebSocketSession.
+//
+// ReadMessage, NextReader, ReadJSON, WriteMessage, NextWriter, WriteJSON, and WriteControl errors are returned as
+// tower.Error with the connection metadata. Close messages written with WriteMessage or WriteControl are recorded as
+// the close status of the server. Close ends the session, which logs the disconnection.
+type Conn struct {
+	*websocket.Conn
+	session *towerhttp.WebSocketSession
+}
+
+// Session returns the towerhttp.WebSocketSession of the connection.
+func (c *Conn) Session() *towerhttp.WebSocketSession {
+	return c.session
+}
+
+// ReadMessage reads a whole message from the connection.
+func (c *Conn) ReadMessage() (messageType int, p []byte, err error) {
+	messageType, p, err = c.Conn.ReadMessage()
+	if err != nil {
+		c.setClose(err)
+		return messageType, p, c.session.ReadError(err)
+	}
+	c.session.Received(towerhttp.WebSocketMessageType(messageType), p)
+	return messageType, p, nil
+}
+
+// NextReader returns the reader of the next message. The message is recorded when the reader reaches EOF.
+func (c *Conn) NextReader() (messageType int, r io.Reader, err error) {
+	messageType, r, err = c.Conn.NextReader()
+	if err != nil {
+		c.setClose(err)
+		return messageType, r, c.session.ReadError(err)
+	}
+	return messageType, c.session.WrapReader(towerhttp.WebSocketMessageType(messageType), r), nil
+}
+
+// ReadJSON reads the next message and decodes it as JSON into v.
+func (c *Conn) ReadJSON(v any) error {
+	messageType, p, err := c.Conn.ReadMessage()
+	if err != nil {
+		c.setClose(err)
+		return c.session.ReadError(err)
+	}
+	c.session.Received(towerhttp.WebSocketMessageType(messageType), p)
+	if err := json.Unmarshal(p, v); err != nil {
+		return c.session.ReadError(err)
+	}
+	return nil
+}
+
+// WriteMessage writes a whole message to the connection.
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	if messageType == websocket.CloseMessage {
+		c.setServerClose(data)
+	}
+	if err := c.Conn.WriteMessage(messageType, data); err != nil {
+		return c.session.WriteError(err)
+	}
+	if isDataMessage(messageType) {
+		c.session.Sent(towerhttp.WebSocketMessageType(messageType), data)
+	}
+	return nil
+}
+
+// NextWriter returns the writer of the next message. The message is recorded when the writer is closed.
+func (c *Conn) NextWriter(messageType int) (io.WriteCloser, error) {
+	w, err := c.Conn.NextWriter(messageType)
+	if err != nil {
+		return nil, c.session.WriteError(err)
+	}
+	if !isDataMessage(messageType) {
+		return w, nil
+	}
+	return c.session.WrapWriter(towerhttp.WebSocketMessageType(messageType), w), nil
+}
+
+// WriteJSON encodes v as JSON and writes it as a text message.
+func (c *Conn) WriteJSON(v any) error {
+	p, err := json.Marshal(v)
+	if err != nil {
+		return c.session.WriteError(err)
+	}
+	if err := c.Conn.WriteMessage(websocket.TextMessage, p); err != nil {
+		return c.session.WriteError(err)
+	}
+	c.session.Sent(towerhttp.WebSocketText, p)
+	return nil
+}
+
+// WriteControl writes a control message with the given deadline.
+func (c *Conn) WriteControl(messageType int, data []byte, deadline time.Time) error {
+	if messageType == websocket.CloseMessage {
+		c.setServerClose(data)
+	}
+	if err := c.Conn.WriteControl(messageType, data, deadline); err != nil {
+		return c.session.WriteError(err)
+	}
+	return nil
+}
+
+// Close closes the underlying network connection without sending a close message, and ends the session.
+//
+// To close the connection gracefully, send a close message with WriteControl or WriteMessage first.
+func (c *Conn) Close() error {
+	err := c.Conn.Close()
+	c.session.End(err)
+	return err
+}
+
+func (c *Conn) setClose(err error) {
+	var ce *websocket.CloseError
+	if errors.As(err, &ce) {
+		c.session.SetClose(ce.Code, ce.Text, "client")
+	}
+}
+
+func (c *Conn) setServerClose(data []byte) {
+	if len(data) < 2 {
+		c.session.SetClose(websocket.CloseNoStatusReceived, "", "server")
+		return
+	}
+	c.session.SetClose(int(binary.BigEndian.Uint16(data)), string(data[2:]), "server")
+}
+
+func isDataMessage(messageType int) bool {
+	return messageType == websocket.TextMessage || messageType == websocket.BinaryMessage
+}
@@ -0,0 +1,198 @@
+package towergorilla
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/towerhttp"
+)
+
+func TestResponder_Upgrade(t *testing.T) {
+	tow := tower.NewTower(tower.Service{Name: "towergorilla-test", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	responder := towerhttp.NewResponder()
+	responder.SetTower(tow)
+	ws := New(responder)
+
+	done := make(chan error, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := ws.Upgrade(nil, rw, r, nil, towerhttp.Option.WebSocket().SampleFrames(1))
+		if err != nil {
+			done <- err
+			return
+		}
+		defer conn.Close()
+		for {
+			typ, p, err := conn.ReadMessage()
+			if err != nil {
+				done <- err
+				return
+			}
+			if err := conn.WriteMessage(typ, append([]byte("echo "), p...)); err != nil {
+				done <- err
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/chat", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if err := client.WriteMessage(websocket.TextMessage, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	_, p, err := client.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(p) != "echo hello" {
+		t.Errorf("expected echo hello, got %s", p)
+	}
+	closeMessage := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "bye")
+	if err := client.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	readErr := <-done
+	var closeErr *websocket.CloseError
+	if !errors.As(readErr, &closeErr) || closeErr.Code != websocket.CloseNormalClosure {
+		t.Errorf("expected read error caused by normal closure, got %v", readErr)
+	}
+	if got := tower.Query.GetMessage(readErr); got != "websocket read failed: /chat" {
+		t.Errorf("expected tower error, got %q", got)
+	}
+	// Wait for the deferred Close to log the disconnection.
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(logger.String(), "websocket disconnected") && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	out := logger.String()
+	for _, want := range []string{
+		`"message":"websocket connected: /chat"`,
+		`"close_code":1000`,
+		`"close_reason":"bye"`,
+		`"closed_by":"client"`,
+		`"inbound":{"bytes":5,"frames":1,"samples":[{"data":"hello"`,
+		`"outbound":{"bytes":10,"frames":1,"samples":[{"data":"echo hello"`,
+		"towergorilla/towergorilla_test.go:",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %s in log, got %s", want, out)
+		}
+	}
+	if strings.Contains(out, `"level":"warn"`) || strings.Contains(out, `"level":"error"`) {
+		t.Errorf("expected normal closure to be logged at info level, got %s", out)
+	}
+}
+
+func TestConn_WriteControlClose(t *testing.T) {
+	tow := tower.NewTower(tower.Service{Name: "towergorilla-test", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	responder := towerhttp.NewResponder()
+	responder.SetTower(tow)
+	ws := New(responder)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := ws.Upgrade(nil, rw, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(4000, "kicked"), time.Now().Add(time.Second))
+	}))
+	defer server.Close()
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/room", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	_, _, err = client.ReadMessage()
+	if !websocket.IsCloseError(err, 4000) {
+		t.Errorf("expected close error with code 4000, got %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(logger.String(), "websocket disconnected") && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	out := logger.String()
+	for _, want := range []string{
+		`"close_code":4000`,
+		`"close_reason":"kicked"`,
+		`"closed_by":"server"`,
+		`"level":"warn"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %s in log, got %s", want, out)
+		}
+	}
+}
+
+func TestConn_ReadJSON(t *testing.T) {
+	tow := tower.NewTower(tower.Service{Name: "towergorilla-test", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	responder := towerhttp.NewResponder()
+	responder.SetTower(tow)
+	ws := New(responder)
+
+	done := make(chan map[string]int, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := ws.Upgrade(nil, rw, r, nil)
+		if err != nil {
+			return
+		}
+		var v map[string]int
+		_ = conn.ReadJSON(&v)
+		_ = conn.Close()
+		done <- v
+	}))
+	defer server.Close()
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if err := client.WriteMessage(websocket.BinaryMessage, []byte(`{"a":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	if v := <-done; v["a"] != 1 {
+		t.Errorf("expected decoded value, got %v", v)
+	}
+	out := logger.String()
+	if !strings.Contains(out, `"type":"binary"`) || strings.Contains(out, `"type":"text"`) {
+		t.Errorf("expected the message to be recorded as binary, got %s", out)
+	}
+}
+
+func TestResponder_UpgradeFailure(t *testing.T) {
+	ws := New(nil)
+	rec := httptest.NewRecorder()
+	_, err := ws.Upgrade(nil, rec, httptest.NewRequest(http.MethodGet, "/chat?token=secret", nil), nil)
+	if err == nil {
+		t.Fatal("expected handshake error")
+	}
+	if b, _ := json.Marshal(err); strings.Contains(string(b), "secret") || !strings.Contains(string(b), "/chat?token=***") {
+		t.Errorf("expected the query to be redacted, got %s", b)
+	}
+	if tower.Query.GetHTTPCode(err) != http.StatusBadRequest {
+		t.Errorf("expected 400 code, got %d", tower.Query.GetHTTPCode(err))
+	}
+	if rec.Code == http.StatusOK {
+		t.Errorf("expected the upgrader to write an error response, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,126 @@
+// Package towernhooyr integrates towerhttp.WebSocketSession with nhooyr.io/websocket.
+package towernhooyr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/towerhttp"
+	"nhooyr.io/websocket"
+)
+
+// Responder accepts WebSocket connections that are logged with the tower instance of towerhttp.Responder.
+type Responder struct {
+	responder *towerhttp.Responder
+}
+
+// New creates a Responder around the given towerhttp.Responder. If responder is nil, the global responder from
+// towerhttp.Exported.Responder() is used.
+func New(responder *towerhttp.Responder) *Responder {
+	if responder == nil {
+		responder = towerhttp.Exported.Responder()
+	}
+	return &Responder{responder: responder}
+}
+
+// Accept accepts the WebSocket handshake with websocket.Accept, and wraps the connection with a
+// towerhttp.WebSocketSession, which logs the connection.
+//
+// If the handshake fails, websocket.Accept has already written the response, and the returned error is a tower.Error
+// with http.StatusBadRequest code and the request metadata.
+func (r *Responder) Accept(rw http.ResponseWriter, request *http.Request, opts *websocket.AcceptOptions, sessionOpts ...towerhttp.WebSocketOption) (*Conn, error) {
+	conn, err := websocket.Accept(rw, request, opts)
+	if err != nil {
+		return nil, r.responder.Tower().Wrap(err).
+			Code(http.StatusBadRequest).
+			Message("websocket handshake failed: %s", request.URL.Path).
+			Context(tower.F{"method": request.Method, "url": towerhttp.WebSocketRedactedURL(request), "remote_addr": request.RemoteAddr}).
+			Freeze()
+	}
+	sessionOpts = append([]towerhttp.WebSocketOption{
+		towerhttp.Option.WebSocket().Tower(r.responder.Tower()).Subprotocol(conn.Subprotocol()),
+	}, sessionOpts...)
+	sessionOpts = append(sessionOpts, towerhttp.Option.WebSocket().AddCallerSkip(1))
+	return &Conn{Conn: conn, session: towerhttp.NewWebSocketSession(request, sessionOpts...)}, nil
+}
+
+// Conn wraps websocket.Conn to record the frames, close status, and errors to the towerhttp.WebSocketSession.
+//
+// Read, Reader, Write, and Writer errors are returned as tower.Error with the connection metadata. Close and CloseNow
+// end the session, which logs the disconnection.
+//
+// Helpers that take *websocket.Conn, like wsjson, bypass the session. Use Read and Write with encoding/json instead.
+type Conn struct {
+	*websocket.Conn
+	session *towerhttp.WebSocketSession
+}
+
+// Session returns the towerhttp.WebSocketSession of the connection.
+func (c *Conn) Session() *towerhttp.WebSocketSession {
+	return c.session
+}
+
+// Read reads a whole message from the connection.
+func (c *Conn) Read(ctx context.Context) (websocket.MessageType, []byte, error) {
+	typ, p, err := c.Conn.Read(ctx)
+	if err != nil {
+		c.setClose(err)
+		return typ, p, c.session.ReadError(err)
+	}
+	c.session.Received(towerhttp.WebSocketMessageType(typ), p)
+	return typ, p, nil
+}
+
+// Reader returns the reader of the next message. The message is recorded when the reader reaches EOF.
+func (c *Conn) Reader(ctx context.Context) (websocket.MessageType, io.Reader, error) {
+	typ, r, err := c.Conn.Reader(ctx)
+	if err != nil {
+		c.setClose(err)
+		return typ, r, c.session.ReadError(err)
+	}
+	return typ, c.session.WrapReader(towerhttp.WebSocketMessageType(typ), r), nil
+}
+
+// Write writes a whole message to the connection.
+func (c *Conn) Write(ctx context.Context, typ websocket.MessageType, p []byte) error {
+	if err := c.Conn.Write(ctx, typ, p); err != nil {
+		return c.session.WriteError(err)
+	}
+	c.session.Sent(towerhttp.WebSocketMessageType(typ), p)
+	return nil
+}
+
+// Writer returns the writer of the next message. The message is recorded when the writer is closed.
+func (c *Conn) Writer(ctx context.Context, typ websocket.MessageType) (io.WriteCloser, error) {
+	w, err := c.Conn.Writer(ctx, typ)
+	if err != nil {
+		return nil, c.session.WriteError(err)
+	}
+	return c.session.WrapWriter(towerhttp.WebSocketMessageType(typ), w), nil
+}
+
+// Close closes the connection with the status code and reason, and ends the session. If the client has closed the
+// connection first, the session keeps the close status sent by the client.
+func (c *Conn) Close(code websocket.StatusCode, reason string) error {
+	c.session.SetClose(int(code), reason, "server")
+	err := c.Conn.Close(code, reason)
+	c.session.End(err)
+	return err
+}
+
+// CloseNow closes the connection without the closing handshake, and ends the session.
+func (c *Conn) CloseNow() error {
+	err := c.Conn.CloseNow()
+	c.session.End(err)
+	return err
+}
+
+func (c *Conn) setClose(err error) {
+	var ce websocket.CloseError
+	if errors.As(err, &ce) {
+		c.session.SetClose(int(ce.Code), ce.Reason, "client")
+	}
+}
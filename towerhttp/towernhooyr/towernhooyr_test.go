@@ -0,0 +1,112 @@
+package towernhooyr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/towerhttp"
+	"nhooyr.io/websocket"
+)
+
+func TestResponder_Accept(t *testing.T) {
+	tow := tower.NewTower(tower.Service{Name: "towernhooyr-test", Environment: "test", Type: "test"})
+	logger := tower.NewTestingJSONLogger()
+	tow.SetLogger(logger)
+	responder := towerhttp.NewResponder()
+	responder.SetTower(tow)
+	ws := New(responder)
+
+	done := make(chan error, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := ws.Accept(rw, r, nil, towerhttp.Option.WebSocket().SampleFrames(1))
+		if err != nil {
+			done <- err
+			return
+		}
+		defer conn.Close(websocket.StatusInternalError, "unexpected")
+		for {
+			typ, p, err := conn.Read(r.Context())
+			if err != nil {
+				done <- err
+				return
+			}
+			if err := conn.Write(r.Context(), typ, append([]byte("echo "), p...)); err != nil {
+				done <- err
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(server.URL, "http")+"/chat", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Write(ctx, websocket.MessageText, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	_, p, err := client.Read(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(p) != "echo hello" {
+		t.Errorf("expected echo hello, got %s", p)
+	}
+	_ = client.Close(websocket.StatusNormalClosure, "bye")
+
+	readErr := <-done
+	if websocket.CloseStatus(readErr) != websocket.StatusNormalClosure {
+		t.Errorf("expected read error caused by normal closure, got %v", readErr)
+	}
+	if got := tower.Query.GetMessage(readErr); got != "websocket read failed: /chat" {
+		t.Errorf("expected tower error, got %q", got)
+	}
+	// Wait for the deferred Close to log the disconnection.
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(logger.String(), "websocket disconnected") && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	out := logger.String()
+	for _, want := range []string{
+		`"message":"websocket connected: /chat"`,
+		`"close_code":1000`,
+		`"close_reason":"bye"`,
+		`"closed_by":"client"`,
+		`"inbound":{"bytes":5,"frames":1,"samples":[{"data":"hello"`,
+		`"outbound":{"bytes":10,"frames":1,"samples":[{"data":"echo hello"`,
+		"towernhooyr/towernhooyr_test.go:",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %s in log, got %s", want, out)
+		}
+	}
+	if strings.Contains(out, `"level":"warn"`) || strings.Contains(out, `"level":"error"`) {
+		t.Errorf("expected normal closure to be logged at info level, got %s", out)
+	}
+}
+
+func TestResponder_AcceptFailure(t *testing.T) {
+	ws := New(nil)
+	rec := httptest.NewRecorder()
+	_, err := ws.Accept(rec, httptest.NewRequest(http.MethodGet, "/chat?token=secret", nil), nil)
+	if err == nil {
+		t.Fatal("expected handshake error")
+	}
+	if b, _ := json.Marshal(err); strings.Contains(string(b), "secret") || !strings.Contains(string(b), "/chat?token=***") {
+		t.Errorf("expected the query to be redacted, got %s", b)
+	}
+	if tower.Query.GetHTTPCode(err) != http.StatusBadRequest {
+		t.Errorf("expected 400 code, got %d", tower.Query.GetHTTPCode(err))
+	}
+	if rec.Code == http.StatusOK {
+		t.Errorf("expected websocket.Accept to write an error response, got %d", rec.Code)
+	}
+}
@@ -40,6 +40,10 @@ func (n NoopBodyTransform) BodyTransform(_ context.Context, input any) any {
 	return input
 }
 
+// SimpleErrorTransformer transforms error into {"error": message}. If the error implements json.Marshaler and not
+// tower.MessageHint, the error is encoded as is, which may expose the whole error chain to the client.
+//
+// Use ErrorPolicyTransformer to control what is exposed depending on the environment.
 type SimpleErrorTransformer struct{}
 
 func (n SimpleErrorTransformer) ErrorBodyTransform(_ context.Context, err error) any {
@@ -0,0 +1,143 @@
+package towerhttp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+var (
+	_ BodyTransformer      = (*EnvelopeTransformer)(nil)
+	_ ErrorBodyTransformer = (*EnvelopeTransformer)(nil)
+	_ HeaderTransformer    = (*EnvelopeTransformer)(nil)
+)
+
+var responseMetaKey = struct{ key int }{782}
+
+// ContextWithResponseMeta adds metadata to the response of the request. The metadata is merged with the metadata set
+// previously in the context, and the later value wins for the same key.
+//
+// EnvelopeTransformer puts the metadata in the meta member of the envelope, for both success and error responses.
+//
+// Example:
+//
+//	r = r.WithContext(towerhttp.ContextWithResponseMeta(r.Context(), map[string]any{"request_id": id}))
+//	responder.Respond(rw, r, body)
+func ContextWithResponseMeta(ctx context.Context, meta map[string]any) context.Context {
+	prev := ResponseMetaFromContext(ctx)
+	merged := make(map[string]any, len(prev)+len(meta))
+	for k, v := range prev {
+		merged[k] = v
+	}
+	for k, v := range meta {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, responseMetaKey, merged)
+}
+
+// ResponseMetaFromContext returns the metadata set by ContextWithResponseMeta. Returns nil if there is none. The
+// returned map must not be modified.
+func ResponseMetaFromContext(ctx context.Context) map[string]any {
+	meta, _ := ctx.Value(responseMetaKey).(map[string]any)
+	return meta
+}
+
+// EnvelopeTransformer wraps response bodies in an envelope, so success and error responses have the same shape.
+//
+// Success responses are {"data": body, "meta": {...}}, and error responses are {"error": ..., "meta": {...}}. The meta
+// member holds the metadata set by ContextWithResponseMeta, and is omitted if there is no metadata.
+//
+// Paginated bodies have their items as the data member, the pagination metadata in the "pagination" member of the meta,
+// and the links to other pages in the Link header.
+//
+// The error member is the result of the inner ErrorBodyTransformer, so it composes with ErrorPolicyTransformer or
+// ProblemDetailsTransformer. By default, the error member is {"message": message, "code": code}.
+//
+// Use as both BodyTransformer and ErrorBodyTransformer of the Responder:
+//
+//	envelope := towerhttp.NewEnvelopeTransformer()
+//	responder.SetBodyTransformer(envelope)
+//	responder.SetErrorTransformer(envelope)
+type EnvelopeTransformer struct {
+	dataMember  string
+	metaMember  string
+	errorMember string
+	errors      ErrorBodyTransformer
+}
+
+// NewEnvelopeTransformer creates a new EnvelopeTransformer with "data", "meta", and "error" members.
+func NewEnvelopeTransformer() *EnvelopeTransformer {
+	return &EnvelopeTransformer{
+		dataMember:  "data",
+		metaMember:  "meta",
+		errorMember: "error",
+		errors:      envelopeErrorTransformer{},
+	}
+}
+
+// SetMembers sets the names of the data, meta, and error members of the envelope. Empty names keep the current names.
+func (e *EnvelopeTransformer) SetMembers(data, meta, err string) {
+	if data != "" {
+		e.dataMember = data
+	}
+	if meta != "" {
+		e.metaMember = meta
+	}
+	if err != "" {
+		e.errorMember = err
+	}
+}
+
+// SetErrorTransformer sets the ErrorBodyTransformer whose result becomes the error member of the envelope.
+func (e *EnvelopeTransformer) SetErrorTransformer(transformer ErrorBodyTransformer) {
+	e.errors = transformer
+}
+
+// BodyTransform implements BodyTransformer.
+func (e EnvelopeTransformer) BodyTransform(ctx context.Context, input any) any {
+	meta := ResponseMetaFromContext(ctx)
+	if paginated, ok := input.(Paginated); ok {
+		input = paginated.PaginationData()
+		if pagination := paginated.Pagination(); pagination != nil {
+			merged := make(map[string]any, len(meta)+1)
+			for k, v := range meta {
+				merged[k] = v
+			}
+			merged["pagination"] = pagination.PaginationMeta()
+			meta = merged
+		}
+	}
+	envelope := map[string]any{e.dataMember: input}
+	if len(meta) > 0 {
+		envelope[e.metaMember] = meta
+	}
+	return envelope
+}
+
+// TransformHeader implements HeaderTransformer.
+func (e EnvelopeTransformer) TransformHeader(request *http.Request, header http.Header, input any) {
+	setLinkHeader(request, header, input)
+}
+
+// ErrorBodyTransform implements ErrorBodyTransformer.
+func (e EnvelopeTransformer) ErrorBodyTransform(ctx context.Context, err error) any {
+	envelope := map[string]any{e.errorMember: e.errors.ErrorBodyTransform(ctx, err)}
+	if meta := ResponseMetaFromContext(ctx); len(meta) > 0 {
+		envelope[e.metaMember] = meta
+	}
+	return envelope
+}
+
+type envelopeErrorTransformer struct{}
+
+func (envelopeErrorTransformer) ErrorBodyTransform(_ context.Context, err error) any {
+	if err == nil {
+		err = errInternalServerError
+	}
+	message := tower.Query.GetMessage(err)
+	if message == "" {
+		message = err.Error()
+	}
+	return map[string]any{"message": message, "code": tower.Query.GetCodeHint(err)}
+}
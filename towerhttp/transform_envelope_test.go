@@ -0,0 +1,121 @@
+package towerhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kinbiko/jsonassert"
+	"github.com/tigorlazuardi/tower"
+)
+
+func TestResponder_RespondEnvelope(t *testing.T) {
+	tests := []struct {
+		name        string
+		transformer func() *EnvelopeTransformer
+		meta        map[string]any
+		respond     func(responder *Responder, rw http.ResponseWriter, r *http.Request)
+		status      int
+		link        string
+		want        string
+	}{
+		{
+			name:        "plain body",
+			transformer: NewEnvelopeTransformer,
+			respond: func(responder *Responder, rw http.ResponseWriter, r *http.Request) {
+				responder.Respond(rw, r, map[string]string{"name": "alice"})
+			},
+			status: http.StatusOK,
+			want:   `{"data": {"name": "alice"}}`,
+		},
+		{
+			name:        "paginated body with metadata",
+			transformer: NewEnvelopeTransformer,
+			meta:        map[string]any{"request_id": "abc"},
+			respond: func(responder *Responder, rw http.ResponseWriter, r *http.Request) {
+				responder.Respond(rw, r, testPage{
+					items: []string{"alice"},
+					page:  CursorPagination{NextCursor: "next", Limit: 1},
+				})
+			},
+			status: http.StatusOK,
+			link:   `</users?cursor=next&limit=1>; rel="next"`,
+			want: `{
+				"data": ["alice"],
+				"meta": {
+					"request_id": "abc",
+					"pagination": {"limit": 1, "next_cursor": "next"}
+				}
+			}`,
+		},
+		{
+			name:        "error",
+			transformer: NewEnvelopeTransformer,
+			meta:        map[string]any{"request_id": "abc"},
+			respond: func(responder *Responder, rw http.ResponseWriter, r *http.Request) {
+				responder.RespondError(rw, r, tower.Bail("user not found").Code(http.StatusNotFound).Freeze())
+			},
+			status: http.StatusNotFound,
+			want:   `{"error": {"message": "user not found", "code": 404}, "meta": {"request_id": "abc"}}`,
+		},
+		{
+			name: "error with custom members and inner transformer",
+			transformer: func() *EnvelopeTransformer {
+				e := NewEnvelopeTransformer()
+				e.SetMembers("result", "", "errors")
+				e.SetErrorTransformer(NewProblemDetailsTransformer())
+				return e
+			},
+			respond: func(responder *Responder, rw http.ResponseWriter, r *http.Request) {
+				responder.RespondError(rw, r, tower.Bail("bad input").Code(http.StatusBadRequest).Freeze())
+			},
+			status: http.StatusBadRequest,
+			want: `{"errors": {
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"detail": "bad input"
+			}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transformer := tt.transformer()
+			responder := NewResponder()
+			responder.SetBodyTransformer(transformer)
+			responder.SetErrorTransformer(transformer)
+			req := httptest.NewRequest(http.MethodGet, "/users", nil)
+			if tt.meta != nil {
+				req = req.WithContext(ContextWithResponseMeta(req.Context(), tt.meta))
+			}
+			rec := httptest.NewRecorder()
+			tt.respond(responder, rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			if got := rec.Header().Get("Link"); got != tt.link {
+				t.Errorf("expected Link %q, got %q", tt.link, got)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("expected Content-Type application/json, got %q", got)
+			}
+			jsonassert.New(t).Assertf(rec.Body.String(), tt.want)
+		})
+	}
+}
+
+func TestContextWithResponseMeta(t *testing.T) {
+	ctx := ContextWithResponseMeta(context.Background(), map[string]any{"a": 1, "b": 1})
+	child := ContextWithResponseMeta(ctx, map[string]any{"b": 2})
+	if got := ResponseMetaFromContext(child); got["a"] != 1 || got["b"] != 2 {
+		t.Errorf("expected merged metadata, got %v", got)
+	}
+	if got := ResponseMetaFromContext(ctx); got["b"] != 1 {
+		t.Errorf("expected parent metadata to be unchanged, got %v", got)
+	}
+	if got := ResponseMetaFromContext(context.Background()); got != nil {
+		t.Errorf("expected nil metadata, got %v", got)
+	}
+}
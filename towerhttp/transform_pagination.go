@@ -0,0 +1,243 @@
+package towerhttp
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ BodyTransformer   = (*PaginationTransformer)(nil)
+	_ HeaderTransformer = (*PaginationTransformer)(nil)
+	_ Pagination        = OffsetPagination{}
+	_ Pagination        = CursorPagination{}
+)
+
+// HeaderTransformer is an optional interface for BodyTransformer to set response headers from the input. Respond calls
+// it with the body as given by the handler, before BodyTransform is called.
+type HeaderTransformer interface {
+	TransformHeader(request *http.Request, header http.Header, input any)
+}
+
+// Paginated is implemented by response bodies that are a page of a collection. Transformers that support pagination,
+// like PaginationTransformer and EnvelopeTransformer, respond with the items as the body, and describe the page with
+// metadata and RFC 8288 Link header.
+//
+// Example:
+//
+//	type UserPage struct {
+//		Users []User
+//		Page  towerhttp.OffsetPagination
+//	}
+//
+//	func (u UserPage) PaginationData() any                 { return u.Users }
+//	func (u UserPage) Pagination() towerhttp.Pagination { return u.Page }
+type Paginated interface {
+	// PaginationData returns the items of the page.
+	PaginationData() any
+	// Pagination returns the description of the page.
+	Pagination() Pagination
+}
+
+// PageLink is a link to another page of the collection.
+type PageLink struct {
+	// Rel is the relation type, e.g. "next", "prev", "first", or "last".
+	Rel string
+	URL *url.URL
+}
+
+// Pagination describes a page of a collection.
+type Pagination interface {
+	// PaginationMeta returns the metadata of the page, e.g. {"offset": 20, "limit": 10, "total": 95}.
+	PaginationMeta() map[string]any
+	// PaginationLinks returns links to other pages, derived from the URL of the current request. The given URL is a copy
+	// and can be modified.
+	PaginationLinks(current *url.URL) []PageLink
+}
+
+// OffsetPagination describes a page of offset based pagination.
+type OffsetPagination struct {
+	Offset int
+	Limit  int
+	// Total is the number of items in the whole collection.
+	Total int64
+	// OffsetParam is the query parameter of the offset. Defaults to "offset".
+	OffsetParam string
+	// LimitParam is the query parameter of the limit. Defaults to "limit".
+	LimitParam string
+}
+
+// PaginationMeta implements Pagination.
+func (o OffsetPagination) PaginationMeta() map[string]any {
+	return map[string]any{"offset": o.Offset, "limit": o.Limit, "total": o.Total}
+}
+
+// PaginationLinks implements Pagination. Returns first, prev, next, and last links. Returns nil if Limit is not positive.
+func (o OffsetPagination) PaginationLinks(current *url.URL) []PageLink {
+	if o.Limit <= 0 {
+		return nil
+	}
+	offsetParam, limitParam := o.OffsetParam, o.LimitParam
+	if offsetParam == "" {
+		offsetParam = "offset"
+	}
+	if limitParam == "" {
+		limitParam = "limit"
+	}
+	link := func(rel string, offset int64) PageLink {
+		return PageLink{Rel: rel, URL: withQuery(current, map[string]string{
+			offsetParam: strconv.FormatInt(offset, 10),
+			limitParam:  strconv.Itoa(o.Limit),
+		})}
+	}
+	offset, limit := int64(o.Offset), int64(o.Limit)
+	links := []PageLink{link("first", 0)}
+	if offset > 0 {
+		prev := offset - limit
+		if prev < 0 {
+			prev = 0
+		}
+		links = append(links, link("prev", prev))
+	}
+	if offset+limit < o.Total {
+		links = append(links, link("next", offset+limit))
+	}
+	if o.Total > 0 {
+		links = append(links, link("last", (o.Total-1)/limit*limit))
+	}
+	return links
+}
+
+// CursorPagination describes a page of cursor based pagination.
+type CursorPagination struct {
+	// Cursor is the cursor of the current page. Empty for the first page.
+	Cursor string
+	// NextCursor is the cursor of the next page. Empty if this is the last page.
+	NextCursor string
+	// PrevCursor is the cursor of the previous page. Empty if this is the first page.
+	PrevCursor string
+	Limit      int
+	// CursorParam is the query parameter of the cursor. Defaults to "cursor".
+	CursorParam string
+	// LimitParam is the query parameter of the limit. Defaults to "limit".
+	LimitParam string
+}
+
+// PaginationMeta implements Pagination. Empty cursors are omitted.
+func (c CursorPagination) PaginationMeta() map[string]any {
+	meta := map[string]any{"limit": c.Limit}
+	if c.Cursor != "" {
+		meta["cursor"] = c.Cursor
+	}
+	if c.NextCursor != "" {
+		meta["next_cursor"] = c.NextCursor
+	}
+	if c.PrevCursor != "" {
+		meta["prev_cursor"] = c.PrevCursor
+	}
+	return meta
+}
+
+// PaginationLinks implements Pagination. Returns first, prev, and next links.
+func (c CursorPagination) PaginationLinks(current *url.URL) []PageLink {
+	cursorParam, limitParam := c.CursorParam, c.LimitParam
+	if cursorParam == "" {
+		cursorParam = "cursor"
+	}
+	if limitParam == "" {
+		limitParam = "limit"
+	}
+	link := func(rel, cursor string) PageLink {
+		query := map[string]string{cursorParam: cursor}
+		if c.Limit > 0 {
+			query[limitParam] = strconv.Itoa(c.Limit)
+		}
+		return PageLink{Rel: rel, URL: withQuery(current, query)}
+	}
+	var links []PageLink
+	if c.Cursor != "" {
+		links = append(links, link("first", ""))
+	}
+	if c.PrevCursor != "" {
+		links = append(links, link("prev", c.PrevCursor))
+	}
+	if c.NextCursor != "" {
+		links = append(links, link("next", c.NextCursor))
+	}
+	return links
+}
+
+// withQuery returns a copy of u with the query parameters replaced. Empty values remove the parameter.
+func withQuery(u *url.URL, params map[string]string) *url.URL {
+	cp := *u
+	query := cp.Query()
+	for k, v := range params {
+		if v == "" {
+			query.Del(k)
+		} else {
+			query.Set(k, v)
+		}
+	}
+	cp.RawQuery = query.Encode()
+	return &cp
+}
+
+// setLinkHeader adds the page links of the paginated body to the Link header as described in RFC 8288. Links are
+// relative to the request, unless the request URL is absolute.
+func setLinkHeader(request *http.Request, header http.Header, input any) {
+	paginated, ok := input.(Paginated)
+	if !ok {
+		return
+	}
+	pagination := paginated.Pagination()
+	if pagination == nil {
+		return
+	}
+	current := &url.URL{Path: request.URL.Path, RawPath: request.URL.RawPath, RawQuery: request.URL.RawQuery}
+	if request.URL.IsAbs() {
+		current = request.URL
+	}
+	links := pagination.PaginationLinks(current)
+	if len(links) == 0 {
+		return
+	}
+	values := make([]string, 0, len(links))
+	for _, link := range links {
+		values = append(values, "<"+link.URL.String()+`>; rel="`+link.Rel+`"`)
+	}
+	header.Add("Link", strings.Join(values, ", "))
+}
+
+// PaginationTransformer responds with the items of Paginated bodies, and describes the page with RFC 8288 Link header.
+// Other bodies are passed to the next BodyTransformer.
+//
+// Use EnvelopeTransformer to also include the pagination metadata in the body.
+type PaginationTransformer struct {
+	next BodyTransformer
+}
+
+// NewPaginationTransformer creates a new PaginationTransformer. If next is nil, NoopBodyTransform is used.
+func NewPaginationTransformer(next BodyTransformer) *PaginationTransformer {
+	if next == nil {
+		next = NoopBodyTransform{}
+	}
+	return &PaginationTransformer{next: next}
+}
+
+// BodyTransform implements BodyTransformer.
+func (p PaginationTransformer) BodyTransform(ctx context.Context, input any) any {
+	if paginated, ok := input.(Paginated); ok {
+		input = paginated.PaginationData()
+	}
+	return p.next.BodyTransform(ctx, input)
+}
+
+// TransformHeader implements HeaderTransformer.
+func (p PaginationTransformer) TransformHeader(request *http.Request, header http.Header, input any) {
+	setLinkHeader(request, header, input)
+	if ht, ok := p.next.(HeaderTransformer); ok {
+		ht.TransformHeader(request, header, input)
+	}
+}
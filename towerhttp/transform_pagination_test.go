@@ -0,0 +1,115 @@
+package towerhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/kinbiko/jsonassert"
+)
+
+type testPage struct {
+	items []string
+	page  Pagination
+}
+
+func (t testPage) PaginationData() any {
+	return t.items
+}
+
+func (t testPage) Pagination() Pagination {
+	return t.page
+}
+
+func TestOffsetPagination_PaginationLinks(t *testing.T) {
+	current, _ := url.Parse("/users?sort=name&offset=20&limit=10")
+	tests := []struct {
+		name       string
+		pagination OffsetPagination
+		want       map[string]string
+	}{
+		{
+			name:       "middle page",
+			pagination: OffsetPagination{Offset: 20, Limit: 10, Total: 95},
+			want: map[string]string{
+				"first": "/users?limit=10&offset=0&sort=name",
+				"prev":  "/users?limit=10&offset=10&sort=name",
+				"next":  "/users?limit=10&offset=30&sort=name",
+				"last":  "/users?limit=10&offset=90&sort=name",
+			},
+		},
+		{
+			name:       "last page with custom params",
+			pagination: OffsetPagination{Offset: 5, Limit: 10, Total: 10, OffsetParam: "skip", LimitParam: "take"},
+			want: map[string]string{
+				"first": "/users?limit=10&offset=20&skip=0&sort=name&take=10",
+				"prev":  "/users?limit=10&offset=20&skip=0&sort=name&take=10",
+				"last":  "/users?limit=10&offset=20&skip=0&sort=name&take=10",
+			},
+		},
+		{
+			name:       "empty collection",
+			pagination: OffsetPagination{Limit: 10},
+			want: map[string]string{
+				"first": "/users?limit=10&offset=0&sort=name",
+			},
+		},
+		{
+			name:       "no limit",
+			pagination: OffsetPagination{Total: 10},
+			want:       map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := tt.pagination.PaginationLinks(current)
+			if len(links) != len(tt.want) {
+				t.Fatalf("expected %d links, got %v", len(tt.want), links)
+			}
+			for _, link := range links {
+				if got := link.URL.String(); got != tt.want[link.Rel] {
+					t.Errorf("expected %s link %q, got %q", link.Rel, tt.want[link.Rel], got)
+				}
+			}
+		})
+	}
+}
+
+func TestCursorPagination_PaginationLinks(t *testing.T) {
+	current, _ := url.Parse("/events?cursor=b")
+	links := CursorPagination{Cursor: "b", PrevCursor: "a", NextCursor: "c", Limit: 2}.PaginationLinks(current)
+	want := []PageLink{
+		{Rel: "first", URL: &url.URL{Path: "/events", RawQuery: "limit=2"}},
+		{Rel: "prev", URL: &url.URL{Path: "/events", RawQuery: "cursor=a&limit=2"}},
+		{Rel: "next", URL: &url.URL{Path: "/events", RawQuery: "cursor=c&limit=2"}},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("expected %d links, got %v", len(want), links)
+	}
+	for i := range links {
+		if links[i].Rel != want[i].Rel || links[i].URL.String() != want[i].URL.String() {
+			t.Errorf("expected %s %s, got %s %s", want[i].Rel, want[i].URL, links[i].Rel, links[i].URL)
+		}
+	}
+}
+
+func TestResponder_RespondPaginationTransformer(t *testing.T) {
+	responder := NewResponder()
+	responder.SetBodyTransformer(NewPaginationTransformer(nil))
+	req := httptest.NewRequest(http.MethodGet, "/users?offset=0&limit=2", nil)
+	rec := httptest.NewRecorder()
+	responder.Respond(rec, req, testPage{
+		items: []string{"alice", "bob"},
+		page:  OffsetPagination{Limit: 2, Total: 3},
+	})
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	want := `</users?limit=2&offset=0>; rel="first", </users?limit=2&offset=2>; rel="next", </users?limit=2&offset=2>; rel="last"`
+	if got := rec.Header().Get("Link"); got != want {
+		t.Errorf("expected Link %q, got %q", want, got)
+	}
+	jsonassert.New(t).Assertf(rec.Body.String(), `["alice", "bob"]`)
+}
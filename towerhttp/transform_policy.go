@@ -0,0 +1,252 @@
+package towerhttp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+var _ ErrorBodyTransformer = (*ErrorPolicyTransformer)(nil)
+
+// ErrorExposure controls how much of an error is exposed in the response body.
+type ErrorExposure uint8
+
+const (
+	// ExposureAuto derives the exposure from the environment of the tower.Service. See ExposureFromEnvironment.
+	ExposureAuto ErrorExposure = iota
+	// ExposurePublic only exposes messages of whitelisted codes. Server errors are replaced by a generic message and an
+	// incident ID.
+	ExposurePublic
+	// ExposureDebug exposes the full error chain, including callers and contexts.
+	ExposureDebug
+)
+
+func (e ErrorExposure) String() string {
+	switch e {
+	case ExposurePublic:
+		return "public"
+	case ExposureDebug:
+		return "debug"
+	}
+	return "auto"
+}
+
+// ExposureFromEnvironment returns ExposureDebug for development and testing environments ("development", "dev",
+// "local", "testing", "test"), and ExposurePublic for everything else, including empty environment.
+func ExposureFromEnvironment(environment string) ErrorExposure {
+	switch strings.ToLower(strings.TrimSpace(environment)) {
+	case "development", "dev", "local", "testing", "test":
+		return ExposureDebug
+	}
+	return ExposurePublic
+}
+
+var errorExposureKey = struct{ key int }{778}
+
+// ContextWithErrorExposure overrides the error exposure for the request. Use this in a middleware, e.g. to give debug
+// output to authenticated internal users in production.
+func ContextWithErrorExposure(ctx context.Context, exposure ErrorExposure) context.Context {
+	return context.WithValue(ctx, errorExposureKey, exposure)
+}
+
+// ErrorExposureFromContext returns the error exposure set by ContextWithErrorExposure. Returns ExposureAuto if not set.
+func ErrorExposureFromContext(ctx context.Context) ErrorExposure {
+	exposure, _ := ctx.Value(errorExposureKey).(ErrorExposure)
+	return exposure
+}
+
+var incidentKey = struct{ key int }{783}
+
+// incidentRecord holds the incident ID given to the client, so RespondError can add it to the logged error.
+type incidentRecord struct {
+	id string
+}
+
+func contextWithIncidentRecord(ctx context.Context) (context.Context, *incidentRecord) {
+	record := &incidentRecord{}
+	return context.WithValue(ctx, incidentKey, record), record
+}
+
+func recordIncident(ctx context.Context, id string) {
+	if record, ok := ctx.Value(incidentKey).(*incidentRecord); ok {
+		record.id = id
+	}
+}
+
+// IncidentIDFunc returns the ID for the client to refer to when reporting server errors.
+type IncidentIDFunc = func(ctx context.Context, err error) string
+
+// ErrorPolicyTransformer transforms errors based on the ErrorExposure.
+//
+// In public mode, the body is {"error": "message"}. The message is taken from tower.MessageHint in the error stack,
+// but only when the code of the error is whitelisted. Otherwise, the status text is used. Server errors (5xx) that are
+// not whitelisted are replaced by a generic message and an "incident_id" so the client can report the issue, and the
+// operator can find the logged error by the ID. When used by Responder.RespondError, the "incident_id" is added to the
+// context of the error given to the respond hooks.
+//
+// In debug mode, the body is {"error": "message", "detail": err}, where detail is the full error chain as marshaled by
+// the error's json.Marshaler implementation, or err.Error() if it doesn't implement one.
+//
+// The mode is derived from the tower.Service.Environment of the tower instance. It can be overridden per request by
+// ContextWithErrorExposure, or by the ErrorExposure RespondOption.
+type ErrorPolicyTransformer struct {
+	tower          *tower.Tower
+	exposure       ErrorExposure
+	allowed        map[int]bool
+	genericMessage string
+	incidentID     IncidentIDFunc
+	tracer         tower.TraceCapturer
+}
+
+// NewErrorPolicyTransformer creates a new ErrorPolicyTransformer.
+//
+// It has the following default values:
+//
+// - Tower: points to the global tower instance
+//
+// - Exposure: ExposureAuto
+//
+// - Allowed codes: none, which means all client error (4xx) codes are whitelisted.
+//
+// - Generic message: "Internal Server Error"
+//
+// - Incident ID: random 16 hex characters
+func NewErrorPolicyTransformer() *ErrorPolicyTransformer {
+	return &ErrorPolicyTransformer{
+		tower:          tower.Global.Tower(),
+		genericMessage: "Internal Server Error",
+		incidentID:     randomIncidentID,
+		tracer:         tower.NoopTracer{},
+	}
+}
+
+// SetTower sets the tower instance whose Service.Environment determines the mode.
+func (p *ErrorPolicyTransformer) SetTower(t *tower.Tower) {
+	p.tower = t
+}
+
+// SetExposure sets the mode regardless of the environment. ExposureAuto derives the mode from the environment.
+func (p *ErrorPolicyTransformer) SetExposure(exposure ErrorExposure) {
+	p.exposure = exposure
+}
+
+// AllowCodes whitelists error codes whose messages are exposed in public mode. Codes are matched against
+// tower.CodeHint and the HTTP status code of the error.
+//
+// When no codes are whitelisted, messages of all client error (4xx) codes are exposed.
+func (p *ErrorPolicyTransformer) AllowCodes(codes ...int) {
+	if p.allowed == nil {
+		p.allowed = make(map[int]bool, len(codes))
+	}
+	for _, code := range codes {
+		p.allowed[code] = true
+	}
+}
+
+// SetGenericMessage sets the message for server errors in public mode.
+func (p *ErrorPolicyTransformer) SetGenericMessage(message string) {
+	p.genericMessage = message
+}
+
+// SetIncidentID sets the function to create incident IDs for server errors in public mode.
+func (p *ErrorPolicyTransformer) SetIncidentID(f IncidentIDFunc) {
+	p.incidentID = f
+}
+
+// SetTracer sets the trace capturer. Captured traces are added to the server error body in public mode, so the client
+// can refer to the trace ID instead of the incident ID.
+func (p *ErrorPolicyTransformer) SetTracer(tracer tower.TraceCapturer) {
+	p.tracer = tracer
+}
+
+// Exposure returns the mode for the request.
+func (p ErrorPolicyTransformer) Exposure(ctx context.Context) ErrorExposure {
+	if exposure := ErrorExposureFromContext(ctx); exposure != ExposureAuto {
+		return exposure
+	}
+	if p.exposure != ExposureAuto {
+		return p.exposure
+	}
+	return ExposureFromEnvironment(p.tower.GetService().Environment)
+}
+
+// ErrorBodyTransform implements ErrorBodyTransformer.
+func (p ErrorPolicyTransformer) ErrorBodyTransform(ctx context.Context, err error) any {
+	if err == nil {
+		err = errInternalServerError
+	}
+	if p.Exposure(ctx) == ExposureDebug {
+		return p.debugBody(err)
+	}
+	return p.publicBody(ctx, err)
+}
+
+func (p ErrorPolicyTransformer) debugBody(err error) any {
+	message := tower.Query.GetMessage(err)
+	if message == "" {
+		message = err.Error()
+	}
+	var detail any = err.Error()
+	if _, ok := err.(json.Marshaler); ok {
+		detail = err
+	}
+	return map[string]any{"error": message, "detail": detail}
+}
+
+func (p ErrorPolicyTransformer) publicBody(ctx context.Context, err error) any {
+	status := tower.Query.GetHTTPCode(err)
+	if p.isAllowed(err, status) {
+		message := tower.Query.GetMessage(err)
+		if message == "" {
+			message = http.StatusText(status)
+		}
+		body := map[string]any{"error": message}
+		if status >= 500 {
+			p.addIncident(ctx, err, body)
+		}
+		return body
+	}
+	if status < 500 {
+		return map[string]any{"error": http.StatusText(status)}
+	}
+	body := map[string]any{"error": p.genericMessage}
+	p.addIncident(ctx, err, body)
+	return body
+}
+
+func (p ErrorPolicyTransformer) isAllowed(err error, status int) bool {
+	if len(p.allowed) == 0 {
+		return status < 500
+	}
+	return p.allowed[status] || p.allowed[tower.Query.GetCodeHint(err)]
+}
+
+func (p ErrorPolicyTransformer) addIncident(ctx context.Context, err error, body map[string]any) {
+	if p.incidentID != nil {
+		if id := p.incidentID(ctx, err); id != "" {
+			body["incident_id"] = id
+			recordIncident(ctx, id)
+		}
+	}
+	if p.tracer == nil {
+		return
+	}
+	if trace := p.tracer.CaptureTrace(ctx); len(trace) > 0 {
+		m := make(map[string]string, len(trace))
+		for _, kv := range trace {
+			m[kv.Key] = kv.Value
+		}
+		body["trace"] = m
+	}
+}
+
+func randomIncidentID(context.Context, error) string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,171 @@
+package towerhttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kinbiko/jsonassert"
+	"github.com/tigorlazuardi/tower"
+)
+
+func TestExposureFromEnvironment(t *testing.T) {
+	tests := []struct {
+		environment string
+		want        ErrorExposure
+	}{
+		{"", ExposurePublic},
+		{"production", ExposurePublic},
+		{"staging", ExposurePublic},
+		{"Development", ExposureDebug},
+		{"local", ExposureDebug},
+		{"testing", ExposureDebug},
+	}
+	for _, tt := range tests {
+		t.Run(tt.environment, func(t *testing.T) {
+			if got := ExposureFromEnvironment(tt.environment); got != tt.want {
+				t.Errorf("ExposureFromEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorPolicyTransformer_ErrorBodyTransform(t *testing.T) {
+	production := tower.NewTower(tower.Service{Name: "policy-test", Environment: "production", Type: "unit-test"})
+	newTransformer := func() *ErrorPolicyTransformer {
+		p := NewErrorPolicyTransformer()
+		p.SetTower(production)
+		p.SetIncidentID(func(ctx context.Context, err error) string { return "incident-1" })
+		return p
+	}
+	tests := []struct {
+		name      string
+		transform func() *ErrorPolicyTransformer
+		ctx       context.Context
+		err       error
+		want      string
+	}{
+		{
+			name:      "public client error",
+			transform: newTransformer,
+			ctx:       context.Background(),
+			err:       production.Bail("invalid email").Code(http.StatusBadRequest).Freeze(),
+			want:      `{"error": "invalid email"}`,
+		},
+		{
+			name:      "public server error",
+			transform: newTransformer,
+			ctx:       context.Background(),
+			err:       production.Wrap(errors.New("dial tcp 10.0.0.1:5432")).Message("database down").Freeze(),
+			want:      `{"error": "Internal Server Error", "incident_id": "incident-1"}`,
+		},
+		{
+			name: "public not whitelisted client error",
+			transform: func() *ErrorPolicyTransformer {
+				p := newTransformer()
+				p.AllowCodes(http.StatusNotFound, 1503)
+				return p
+			},
+			ctx:  context.Background(),
+			err:  production.Bail("user 1 is banned").Code(http.StatusForbidden).Freeze(),
+			want: `{"error": "Forbidden"}`,
+		},
+		{
+			name: "public whitelisted server error",
+			transform: func() *ErrorPolicyTransformer {
+				p := newTransformer()
+				p.AllowCodes(1503)
+				p.SetTracer(tower.TraceCaptureFunc(func(ctx context.Context) tower.Trace {
+					return tower.Trace{tower.NewKeyValue("trace_id", "abc")}
+				}))
+				return p
+			},
+			ctx:  context.Background(),
+			err:  production.Bail("under maintenance").Code(1503).Freeze(),
+			want: `{"error": "under maintenance", "incident_id": "incident-1", "trace": {"trace_id": "abc"}}`,
+		},
+		{
+			name:      "debug override from context",
+			transform: newTransformer,
+			ctx:       ContextWithErrorExposure(context.Background(), ExposureDebug),
+			err:       production.Bail("database down").Freeze(),
+			want: `{
+				"error": "database down",
+				"detail": {
+					"time": "<<PRESENCE>>",
+					"code": 500,
+					"message": "database down",
+					"caller": "<<PRESENCE>>",
+					"level": "error",
+					"service": "<<PRESENCE>>",
+					"error": "<<PRESENCE>>"
+				}
+			}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := tt.transform().ErrorBodyTransform(tt.ctx, tt.err)
+			b, err := NewJSONEncoder().Encode(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			jsonassert.New(t).Assertf(string(b), tt.want)
+		})
+	}
+}
+
+func TestResponder_RespondErrorExposureOption(t *testing.T) {
+	responder := NewResponder()
+	policy := NewErrorPolicyTransformer()
+	policy.SetExposure(ExposurePublic)
+	responder.SetErrorTransformer(policy)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		responder.RespondError(rw, r, errors.New("secret"), Option.Respond().ErrorExposure(ExposureDebug))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	jsonassert.New(t).Assertf(string(body), `{"error": "secret", "detail": "secret"}`)
+}
+
+func TestResponder_RespondErrorLogsIncidentID(t *testing.T) {
+	logger := tower.NewTestingJSONLogger()
+	tow := tower.NewTower(tower.Service{Name: "policy-test", Environment: "production", Type: "unit-test"})
+	tow.SetLogger(logger)
+	responder := NewResponder()
+	responder.SetTower(tow)
+	policy := NewErrorPolicyTransformer()
+	policy.SetTower(tow)
+	policy.SetIncidentID(func(ctx context.Context, err error) string { return "incident-42" })
+	responder.SetErrorTransformer(policy)
+	responder.RegisterHook(NewLoggerHook())
+
+	rec := httptest.NewRecorder()
+	responder.RespondError(rec, httptest.NewRequest(http.MethodGet, "/orders", nil), errors.New("database down"))
+
+	jsonassert.New(t).Assertf(rec.Body.String(), `{"error": "Internal Server Error", "incident_id": "incident-42"}`)
+	logs := logger.String()
+	if !strings.Contains(logs, `"incident_id":"incident-42"`) {
+		t.Errorf("expected incident_id in the logged error, got %s", logs)
+	}
+	if !strings.Contains(logs, "database down") {
+		t.Errorf("expected the original error in the log, got %s", logs)
+	}
+
+	logger.Reset()
+	rec = httptest.NewRecorder()
+	responder.RespondError(rec, httptest.NewRequest(http.MethodGet, "/orders", nil), tow.Bail("not found").Code(http.StatusNotFound).Freeze())
+	if strings.Contains(logger.String(), "incident_id") {
+		t.Errorf("expected no incident_id for client errors, got %s", logger.String())
+	}
+}
@@ -0,0 +1,238 @@
+package towerhttp
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+var (
+	_ ErrorBodyTransformer        = (*ProblemDetailsTransformer)(nil)
+	_ ErrorContentTypeTransformer = (*ProblemDetailsTransformer)(nil)
+	_ ProblemExtension            = (Public)(nil)
+)
+
+// ErrorContentTypeTransformer is an optional interface for ErrorBodyTransformer to override the Content-Type header
+// set from the Encoder.
+type ErrorContentTypeTransformer interface {
+	// ErrorContentType returns the Content-Type for the error body encoded with given Encoder's content type.
+	//
+	// Returning empty string keeps the Encoder's content type.
+	ErrorContentType(encoderContentType string) string
+}
+
+// ProblemExtension is implemented by error context values that are safe to be exposed to clients. The returned
+// members are added to the problem details as extension members.
+//
+// Error context values that do not implement ProblemExtension are never exposed.
+type ProblemExtension interface {
+	ProblemExtension() map[string]any
+}
+
+// Public is an error context that is safe to be exposed to clients.
+//
+// Example:
+//
+//	tower.Bail("invalid email").Code(400).Context(towerhttp.Public{"field": "email"}).Freeze()
+type Public map[string]any
+
+// ProblemExtension implements ProblemExtension.
+func (p Public) ProblemExtension() map[string]any {
+	return p
+}
+
+// ProblemDetails is the error body as defined by RFC 9457.
+//
+// See https://www.rfc-editor.org/rfc/rfc9457 for details.
+type ProblemDetails struct {
+	Type     string
+	Title    string
+	Status   int
+	Detail   string
+	Instance string
+	// Extensions are additional members of the problem details. Members with the same name as the standard members
+	// are ignored.
+	Extensions map[string]any
+}
+
+var problemMembers = map[string]bool{"type": true, "title": true, "status": true, "detail": true, "instance": true}
+
+// MarshalJSON implements json.Marshaler.
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		if !problemMembers[k] {
+			m[k] = v
+		}
+	}
+	if p.Type != "" {
+		m["type"] = p.Type
+	}
+	if p.Title != "" {
+		m["title"] = p.Title
+	}
+	if p.Status != 0 {
+		m["status"] = p.Status
+	}
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	return json.Marshal(m)
+}
+
+// MarshalXML implements xml.Marshaler using the RFC 9457 XML format.
+func (p ProblemDetails) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	start := xml.StartElement{Name: xml.Name{Space: "urn:ietf:rfc:7807", Local: "problem"}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	members := []struct {
+		name  string
+		value any
+		empty bool
+	}{
+		{"type", p.Type, p.Type == ""},
+		{"title", p.Title, p.Title == ""},
+		{"status", p.Status, p.Status == 0},
+		{"detail", p.Detail, p.Detail == ""},
+		{"instance", p.Instance, p.Instance == ""},
+	}
+	for _, member := range members {
+		if member.empty {
+			continue
+		}
+		if err := e.EncodeElement(member.value, xml.StartElement{Name: xml.Name{Local: member.name}}); err != nil {
+			return err
+		}
+	}
+	keys := make([]string, 0, len(p.Extensions))
+	for k := range p.Extensions {
+		if !problemMembers[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := e.EncodeElement(p.Extensions[k], xml.StartElement{Name: xml.Name{Local: k}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// ProblemExtensionFunc modifies the problem details before it's encoded. Use this to add extension members or to
+// set the instance member.
+type ProblemExtensionFunc = func(ctx context.Context, err error, problem *ProblemDetails)
+
+// ProblemDetailsTransformer transforms errors into RFC 9457 problem details.
+//
+// The members are derived from the error:
+//
+// - status: tower.HTTPCodeHint in the error stack. Defaults to 500.
+//
+// - title: the status text of the status code.
+//
+// - type: type base URI joined with the key of the error if both are set. Otherwise, "about:blank".
+//
+// - detail: tower.MessageHint in the error stack. Omitted when there's none, so error strings are not leaked to
+// clients.
+//
+// - code extension: tower.CodeHint in the error stack, if it differs from the status.
+//
+// - other extensions: error context values that implement ProblemExtension, like Public.
+//
+// The Content-Type is set to "application/problem+json" or "application/problem+xml" depending on the Encoder.
+type ProblemDetailsTransformer struct {
+	typeBaseURI string
+	extension   ProblemExtensionFunc
+}
+
+// NewProblemDetailsTransformer creates a new ProblemDetailsTransformer.
+func NewProblemDetailsTransformer() *ProblemDetailsTransformer {
+	return &ProblemDetailsTransformer{}
+}
+
+// SetTypeBaseURI sets the base URI for the type member. The key of the error is appended to the base URI.
+//
+// Example: "https://example.com/problems/" with key "out-of-credit" produces
+// "https://example.com/problems/out-of-credit".
+func (p *ProblemDetailsTransformer) SetTypeBaseURI(uri string) {
+	p.typeBaseURI = uri
+}
+
+// SetExtension sets the hook to modify the problem details before it's encoded.
+func (p *ProblemDetailsTransformer) SetExtension(extension ProblemExtensionFunc) {
+	p.extension = extension
+}
+
+// ErrorBodyTransform implements ErrorBodyTransformer.
+func (p ProblemDetailsTransformer) ErrorBodyTransform(ctx context.Context, err error) any {
+	problem := &ProblemDetails{
+		Type:   "about:blank",
+		Status: http.StatusInternalServerError,
+	}
+	if err == nil {
+		problem.Title = http.StatusText(problem.Status)
+		return problem
+	}
+	problem.Status = tower.Query.GetHTTPCode(err)
+	problem.Title = http.StatusText(problem.Status)
+	problem.Detail = tower.Query.GetMessage(err)
+
+	errs := tower.Query.CollectErrors(err)
+	if key := topKey(errs); key != "" && p.typeBaseURI != "" {
+		problem.Type = strings.TrimSuffix(p.typeBaseURI, "/") + "/" + key
+	}
+	if code := tower.Query.GetCodeHint(err); code != problem.Status {
+		problem.setExtension("code", code)
+	}
+	// Iterate from the bottom most error, so the top most error's context wins.
+	for i := len(errs) - 1; i >= 0; i-- {
+		for _, c := range errs[i].Context() {
+			if ext, ok := c.(ProblemExtension); ok {
+				for k, v := range ext.ProblemExtension() {
+					problem.setExtension(k, v)
+				}
+			}
+		}
+	}
+	if p.extension != nil {
+		p.extension(ctx, err, problem)
+	}
+	return problem
+}
+
+// ErrorContentType implements ErrorContentTypeTransformer.
+func (p ProblemDetailsTransformer) ErrorContentType(encoderContentType string) string {
+	switch baseMediaType(encoderContentType) {
+	case "application/json":
+		return "application/problem+json"
+	case "application/xml":
+		return "application/problem+xml"
+	}
+	return ""
+}
+
+func (p *ProblemDetails) setExtension(key string, value any) {
+	if p.Extensions == nil {
+		p.Extensions = make(map[string]any, 4)
+	}
+	p.Extensions[key] = value
+}
+
+func topKey(errs []tower.Error) string {
+	for _, e := range errs {
+		if key := e.Key(); key != "" {
+			return key
+		}
+	}
+	return ""
+}
@@ -0,0 +1,119 @@
+package towerhttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kinbiko/jsonassert"
+	"github.com/tigorlazuardi/tower"
+)
+
+func TestProblemDetailsTransformer_ErrorBodyTransform(t *testing.T) {
+	tow := tower.NewTower(tower.Service{Name: "problem-test", Environment: "testing", Type: "unit-test"})
+	tests := []struct {
+		name      string
+		transform func() *ProblemDetailsTransformer
+		err       error
+		want      string
+	}{
+		{
+			name:      "plain error",
+			transform: NewProblemDetailsTransformer,
+			err:       errors.New("boom"),
+			want:      `{"type": "about:blank", "title": "Internal Server Error", "status": 500}`,
+		},
+		{
+			name: "tower error with key, code and public context",
+			transform: func() *ProblemDetailsTransformer {
+				p := NewProblemDetailsTransformer()
+				p.SetTypeBaseURI("https://example.com/problems/")
+				return p
+			},
+			err: tow.Bail("not enough credit").
+				Code(1403).
+				Key("out-of-credit").
+				Context(Public{"balance": 30}, tower.F{"secret": "hidden"}).
+				Freeze(),
+			want: `{
+				"type": "https://example.com/problems/out-of-credit",
+				"title": "Forbidden",
+				"status": 403,
+				"detail": "not enough credit",
+				"code": 1403,
+				"balance": 30
+			}`,
+		},
+		{
+			name: "extension hook",
+			transform: func() *ProblemDetailsTransformer {
+				p := NewProblemDetailsTransformer()
+				p.SetExtension(func(ctx context.Context, err error, problem *ProblemDetails) {
+					problem.Instance = "/orders/1"
+					problem.Extensions = map[string]any{"status": "ignored", "trace_id": "abc"}
+				})
+				return p
+			},
+			err: tow.Bail("not found").Code(http.StatusNotFound).Freeze(),
+			want: `{
+				"type": "about:blank",
+				"title": "Not Found",
+				"status": 404,
+				"detail": "not found",
+				"instance": "/orders/1",
+				"trace_id": "abc"
+			}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := tt.transform().ErrorBodyTransform(context.Background(), tt.err)
+			b, err := NewJSONEncoder().Encode(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			jsonassert.New(t).Assertf(string(b), tt.want)
+		})
+	}
+}
+
+func TestResponder_RespondErrorProblemDetails(t *testing.T) {
+	responder := NewResponder()
+	responder.SetErrorTransformer(NewProblemDetailsTransformer())
+	responder.AddEncoder(NewXMLEncoder())
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		responder.RespondError(rw, r, tower.Bail("bad input").Code(http.StatusBadRequest).Freeze())
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); got != "application/problem+json" {
+		t.Errorf("expected Content-Type application/problem+json, got %s", got)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Accept", "application/xml")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if got := resp.Header.Get("Content-Type"); got != "application/problem+xml" {
+		t.Errorf("expected Content-Type application/problem+xml, got %s", got)
+	}
+	if !strings.Contains(string(body), `<problem xmlns="urn:ietf:rfc:7807"><type>about:blank</type><title>Bad Request</title><status>400</status><detail>bad input</detail></problem>`) {
+		t.Errorf("unexpected xml body: %s", body)
+	}
+}
@@ -8,8 +8,14 @@ import (
 func isHumanReadable(contentType string) bool {
 	return contentType == "" ||
 		strings.Contains(contentType, "text/") ||
-		strings.Contains(contentType, "application/json") ||
-		strings.Contains(contentType, "application/xml")
+		isJSONContentType(contentType) ||
+		strings.Contains(contentType, "application/xml") ||
+		strings.Contains(contentType, "+xml")
+}
+
+// isJSONContentType checks if the content type is JSON or a JSON based structured syntax like application/problem+json.
+func isJSONContentType(contentType string) bool {
+	return strings.Contains(contentType, "application/json") || strings.Contains(contentType, "+json")
 }
 func isJson(b []byte) bool {
 	var js json.RawMessage
@@ -0,0 +1,426 @@
+package towerhttp
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+// WebSocketMessageType is the type of WebSocket data frame, with the same value as the opcode in RFC 6455.
+type WebSocketMessageType int
+
+const (
+	WebSocketText   WebSocketMessageType = 1
+	WebSocketBinary WebSocketMessageType = 2
+)
+
+func (w WebSocketMessageType) String() string {
+	switch w {
+	case WebSocketText:
+		return "text"
+	case WebSocketBinary:
+		return "binary"
+	}
+	return fmt.Sprintf("opcode(%d)", int(w))
+}
+
+// WebSocket close codes as defined in RFC 6455 section 7.4.1.
+const (
+	WebSocketCloseNormal        = 1000
+	WebSocketCloseGoingAway     = 1001
+	WebSocketCloseNoStatus      = 1005
+	WebSocketCloseAbnormal      = 1006
+	WebSocketCloseInternalError = 1011
+)
+
+// WebSocketFrame is a sampled data frame.
+type WebSocketFrame struct {
+	Type WebSocketMessageType
+	Time time.Time
+	// Size is the size of the whole frame.
+	Size int
+	// Data is the head of the frame, up to SampleFrameSize WebSocketOption.
+	Data      []byte
+	Truncated bool
+}
+
+// MarshalJSON implements json.Marshaler. Text frames are encoded as JSON if valid, otherwise as string. Binary frames
+// are encoded as base64.
+func (w WebSocketFrame) MarshalJSON() ([]byte, error) {
+	m := map[string]any{
+		"type": w.Type.String(),
+		"time": w.Time,
+		"size": w.Size,
+	}
+	switch {
+	case w.Type == WebSocketText && !w.Truncated && isJsonLite(w.Data) && isJson(w.Data):
+		m["data"] = json.RawMessage(w.Data)
+	case w.Type == WebSocketText && utf8.Valid(w.Data):
+		m["data"] = string(w.Data)
+	default:
+		m["data_base64"] = base64.StdEncoding.EncodeToString(w.Data)
+	}
+	if w.Truncated {
+		m["truncated"] = true
+	}
+	return json.Marshal(m)
+}
+
+// WebSocketStats is the traffic of one direction of a WebSocket connection.
+type WebSocketStats struct {
+	Frames int64
+	Bytes  int64
+	// Samples are the last frames, up to SampleFrames WebSocketOption, oldest first.
+	Samples []WebSocketFrame
+}
+
+type webSocketDirection struct {
+	frames  int64
+	bytes   int64
+	samples []WebSocketFrame
+	next    int
+}
+
+func (w *webSocketDirection) record(frame WebSocketFrame, limit int) {
+	w.frames++
+	w.bytes += int64(frame.Size)
+	if limit <= 0 {
+		return
+	}
+	if len(w.samples) < limit {
+		w.samples = append(w.samples, frame)
+		return
+	}
+	w.samples[w.next] = frame
+	w.next = (w.next + 1) % limit
+}
+
+func (w *webSocketDirection) stats() WebSocketStats {
+	samples := make([]WebSocketFrame, 0, len(w.samples))
+	samples = append(samples, w.samples[w.next:]...)
+	samples = append(samples, w.samples[:w.next]...)
+	return WebSocketStats{Frames: w.frames, Bytes: w.bytes, Samples: samples}
+}
+
+// WebSocketSession tracks a WebSocket connection for logging and error reporting.
+//
+// The session logs a tower.Entry when it's created, and another one when End is called with the duration, close
+// status, the traffic in both directions, and the last sampled frames. Frames are sampled into bounded buffers, so long
+// living connections do not grow the memory.
+//
+// Use the adapters in towerhttp/towernhooyr and towerhttp/towergorilla modules instead of using the session directly.
+// They record the frames, close status, and errors of the connection to the session.
+type WebSocketSession struct {
+	tower           *tower.Tower
+	callerDepth     int
+	request         *http.Request
+	id              string
+	subprotocol     string
+	start           time.Time
+	sampleFrames    int
+	sampleFrameSize int
+
+	mu          sync.Mutex
+	inbound     webSocketDirection
+	outbound    webSocketDirection
+	closeCode   int
+	closeReason string
+	closedBy    string
+	err         error
+	ended       bool
+}
+
+// NewWebSocketSession creates a session for the upgraded request, and logs the connection.
+//
+// By default, the last 10 frames of each direction are sampled, up to 1KB per frame.
+func NewWebSocketSession(request *http.Request, opts ...WebSocketOption) *WebSocketSession {
+	s := &WebSocketSession{
+		tower:           tower.Global.Tower(),
+		callerDepth:     2,
+		request:         request,
+		id:              randomWebSocketID(),
+		start:           time.Now(),
+		sampleFrames:    10,
+		sampleFrameSize: 1024,
+	}
+	for _, opt := range opts {
+		opt.apply(s)
+	}
+	fields := s.Fields()
+	s.tower.NewEntry("websocket connected: %s", s.request.URL.Path).
+		Caller(tower.GetCaller(s.callerDepth)).
+		Context(fields).
+		Log(request.Context())
+	return s
+}
+
+// ID returns the random ID of the connection, which is in every log entry and error of the session.
+func (s *WebSocketSession) ID() string {
+	return s.id
+}
+
+// Request returns the upgraded request.
+func (s *WebSocketSession) Request() *http.Request {
+	return s.request
+}
+
+// Received records an inbound data frame.
+func (s *WebSocketSession) Received(typ WebSocketMessageType, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inbound.record(s.frame(typ, data, len(data)), s.sampleFrames)
+}
+
+// Sent records an outbound data frame.
+func (s *WebSocketSession) Sent(typ WebSocketMessageType, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outbound.record(s.frame(typ, data, len(data)), s.sampleFrames)
+}
+
+func (s *WebSocketSession) frame(typ WebSocketMessageType, data []byte, size int) WebSocketFrame {
+	frame := WebSocketFrame{Type: typ, Time: time.Now(), Size: size}
+	n := len(data)
+	if s.sampleFrameSize >= 0 && n > s.sampleFrameSize {
+		n = s.sampleFrameSize
+	}
+	frame.Data = make([]byte, n)
+	copy(frame.Data, data)
+	frame.Truncated = n < size
+	return frame
+}
+
+// WrapReader wraps the reader of an inbound frame, so the frame is recorded when the reader reaches EOF.
+func (s *WebSocketSession) WrapReader(typ WebSocketMessageType, r io.Reader) io.Reader {
+	return &webSocketFrameReader{session: s, typ: typ, r: r}
+}
+
+// WrapWriter wraps the writer of an outbound frame, so the frame is recorded when the writer is closed.
+func (s *WebSocketSession) WrapWriter(typ WebSocketMessageType, w io.WriteCloser) io.WriteCloser {
+	return &webSocketFrameWriter{session: s, typ: typ, w: w}
+}
+
+// SetClose records the close status of the connection. closedBy is "client" or "server". Only the first call takes
+// effect, which is the side that starts the closing handshake.
+func (s *WebSocketSession) SetClose(code int, reason, closedBy string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closeCode != 0 {
+		return
+	}
+	s.closeCode = code
+	s.closeReason = reason
+	s.closedBy = closedBy
+}
+
+// ReadError turns the error of reading from the connection into a tower.Error with the connection metadata. Returns nil
+// if err is nil.
+//
+// The error is also reported in the disconnection log, unless it's caused by the closing handshake.
+func (s *WebSocketSession) ReadError(err error) error {
+	return s.wrapError(err, "read")
+}
+
+// WriteError turns the error of writing to the connection into a tower.Error with the connection metadata. Returns nil
+// if err is nil.
+//
+// The error is also reported in the disconnection log.
+func (s *WebSocketSession) WriteError(err error) error {
+	return s.wrapError(err, "write")
+}
+
+func (s *WebSocketSession) wrapError(err error, op string) error {
+	if err == nil {
+		return nil
+	}
+	s.mu.Lock()
+	code := s.closeCode
+	if s.err == nil && !isNormalClose(code) {
+		s.err = err
+	}
+	s.mu.Unlock()
+	fields := s.Fields()
+	fields["operation"] = op
+	return s.tower.Wrap(err).
+		Message("websocket %s failed: %s", op, s.request.URL.Path).
+		Caller(tower.GetCaller(s.callerDepth + 1)).
+		Context(fields).
+		Freeze()
+}
+
+// WebSocketRedactedURL returns the path of the request with every query value replaced with "***".
+//
+// Browsers can not set headers on WebSocket handshakes, so credentials are often sent in the query, e.g.
+// "/ws?token=secret". The host is left out, since it is the same for every connection to the server.
+func WebSocketRedactedURL(r *http.Request) string {
+	if r.URL.RawQuery == "" {
+		return r.URL.Path
+	}
+	params := strings.Split(r.URL.RawQuery, "&")
+	for i, param := range params {
+		if key, _, ok := strings.Cut(param, "="); ok {
+			params[i] = key + "=***"
+		}
+	}
+	return r.URL.Path + "?" + strings.Join(params, "&")
+}
+
+// Fields returns the metadata of the connection. The URL is redacted with WebSocketRedactedURL.
+func (s *WebSocketSession) Fields() tower.F {
+	request := tower.F{
+		"method":      s.request.Method,
+		"url":         WebSocketRedactedURL(s.request),
+		"remote_addr": s.request.RemoteAddr,
+	}
+	if route := RouteFromContext(s.request.Context()); route != "" {
+		request["route"] = route
+	}
+	if ua := s.request.UserAgent(); ua != "" {
+		request["user_agent"] = ua
+	}
+	websocket := tower.F{"id": s.id}
+	if s.subprotocol != "" {
+		websocket["subprotocol"] = s.subprotocol
+	}
+	return tower.F{"request": request, "websocket": websocket}
+}
+
+// End logs the disconnection with the duration, close status, and traffic of the connection. err is the error that
+// ends the connection, if any. Only the first call takes effect.
+//
+// The entry is logged at info level for normal closure (1000) and going away (1001), at warn level for other close
+// codes, and at error level if the connection ends with an error without close status.
+func (s *WebSocketSession) End(err error) {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	if err != nil && s.err == nil && !isNormalClose(s.closeCode) {
+		s.err = err
+	}
+	code, reason, closedBy, endErr := s.closeCode, s.closeReason, s.closedBy, s.err
+	inbound, outbound := s.inbound.stats(), s.outbound.stats()
+	s.mu.Unlock()
+
+	duration := time.Since(s.start)
+	fields := s.Fields()
+	websocket := fields["websocket"].(tower.F)
+	websocket["duration_ms"] = durationMillis(duration)
+	websocket["inbound"] = webSocketStatsFields(inbound)
+	websocket["outbound"] = webSocketStatsFields(outbound)
+	if code != 0 {
+		websocket["close_code"] = code
+		websocket["closed_by"] = closedBy
+		if reason != "" {
+			websocket["close_reason"] = reason
+		}
+	}
+
+	level := tower.InfoLevel
+	switch {
+	case isNormalClose(code):
+	case code != 0:
+		level = tower.WarnLevel
+	case endErr != nil:
+		level = tower.ErrorLevel
+	}
+	if endErr != nil {
+		fields["error"] = endErr.Error()
+	}
+	s.tower.NewEntry("websocket disconnected after %s: %s", duration.Round(time.Millisecond), s.request.URL.Path).
+		Level(level).
+		Caller(tower.GetCaller(s.callerDepth)).
+		Context(fields).
+		Log(s.request.Context())
+}
+
+func randomWebSocketID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func isNormalClose(code int) bool {
+	return code == WebSocketCloseNormal || code == WebSocketCloseGoingAway
+}
+
+func webSocketStatsFields(stats WebSocketStats) tower.F {
+	f := tower.F{"frames": stats.Frames, "bytes": stats.Bytes}
+	if len(stats.Samples) > 0 {
+		f["samples"] = stats.Samples
+	}
+	return f
+}
+
+type webSocketFrameReader struct {
+	session *WebSocketSession
+	typ     WebSocketMessageType
+	r       io.Reader
+	head    []byte
+	size    int
+	done    bool
+}
+
+func (w *webSocketFrameReader) Read(p []byte) (int, error) {
+	n, err := w.r.Read(p)
+	w.size += n
+	if room := w.session.sampleFrameSize - len(w.head); room > 0 || w.session.sampleFrameSize < 0 {
+		take := n
+		if w.session.sampleFrameSize >= 0 && take > room {
+			take = room
+		}
+		w.head = append(w.head, p[:take]...)
+	}
+	if err == io.EOF && !w.done {
+		w.done = true
+		w.session.mu.Lock()
+		frame := w.session.frame(w.typ, w.head, w.size)
+		w.session.inbound.record(frame, w.session.sampleFrames)
+		w.session.mu.Unlock()
+	}
+	return n, err
+}
+
+type webSocketFrameWriter struct {
+	session *WebSocketSession
+	typ     WebSocketMessageType
+	w       io.WriteCloser
+	head    []byte
+	size    int
+}
+
+func (w *webSocketFrameWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.size += n
+	if room := w.session.sampleFrameSize - len(w.head); room > 0 || w.session.sampleFrameSize < 0 {
+		take := n
+		if w.session.sampleFrameSize >= 0 && take > room {
+			take = room
+		}
+		w.head = append(w.head, p[:take]...)
+	}
+	return n, err
+}
+
+func (w *webSocketFrameWriter) Close() error {
+	err := w.w.Close()
+	if err == nil {
+		w.session.mu.Lock()
+		frame := w.session.frame(w.typ, w.head, w.size)
+		w.session.outbound.record(frame, w.session.sampleFrames)
+		w.session.mu.Unlock()
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+package towerhttp
+
+import "github.com/tigorlazuardi/tower"
+
+type WebSocketOption interface {
+	apply(*WebSocketSession)
+}
+
+type (
+	WebSocketOptionBuilder []WebSocketOption
+	webSocketOptionFunc    func(*WebSocketSession)
+)
+
+func (w webSocketOptionFunc) apply(s *WebSocketSession) {
+	w(s)
+}
+
+func (w WebSocketOptionBuilder) apply(s *WebSocketSession) {
+	for _, v := range w {
+		v.apply(s)
+	}
+}
+
+// Tower sets the tower instance to log the connection and create the errors. Defaults to the global tower instance.
+func (w WebSocketOptionBuilder) Tower(t *tower.Tower) WebSocketOptionBuilder {
+	return append(w, webSocketOptionFunc(func(s *WebSocketSession) {
+		s.tower = t
+	}))
+}
+
+// Subprotocol sets the negotiated subprotocol to be logged.
+func (w WebSocketOptionBuilder) Subprotocol(subprotocol string) WebSocketOptionBuilder {
+	return append(w, webSocketOptionFunc(func(s *WebSocketSession) {
+		s.subprotocol = subprotocol
+	}))
+}
+
+// SampleFrames sets the number of last frames of each direction kept for the disconnection log. Defaults to 10. Zero or
+// negative value disables sampling. Frames are still counted.
+func (w WebSocketOptionBuilder) SampleFrames(n int) WebSocketOptionBuilder {
+	return append(w, webSocketOptionFunc(func(s *WebSocketSession) {
+		s.sampleFrames = n
+	}))
+}
+
+// SampleFrameSize limits the number of bytes kept for every sampled frame. Defaults to 1KB. Negative value keeps the
+// whole frame.
+func (w WebSocketOptionBuilder) SampleFrameSize(n int) WebSocketOptionBuilder {
+	return append(w, webSocketOptionFunc(func(s *WebSocketSession) {
+		s.sampleFrameSize = n
+	}))
+}
+
+// AddCallerSkip adds the caller skip to get the caller of the log entries and errors of the session. Adapters use this
+// to point the caller to the handler instead of the adapter.
+func (w WebSocketOptionBuilder) AddCallerSkip(i int) WebSocketOptionBuilder {
+	return append(w, webSocketOptionFunc(func(s *WebSocketSession) {
+		s.callerDepth += i
+	}))
+}
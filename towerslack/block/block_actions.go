@@ -0,0 +1,63 @@
+package block
+
+import (
+	"sync"
+
+	"github.com/francoispqt/gojay"
+)
+
+var actionsBlockPool = &sync.Pool{New: func() any { return &ActionsBlock{} }}
+
+var _ Block = (*ActionsBlock)(nil)
+
+// ActionsBlock holds interactive elements.
+//
+// See https://api.slack.com/reference/block-kit/blocks#actions for details.
+type ActionsBlock struct {
+	Elements Elements
+	BlockID  string
+}
+
+func NewActionsBlock(elements ...Element) *ActionsBlock {
+	ab := actionsBlockPool.Get().(*ActionsBlock) //nolint
+	ab.Elements = elements
+	return ab
+}
+
+func (b ActionsBlock) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "actions")
+	enc.AddArrayKey("elements", b.Elements)
+	enc.AddStringKeyOmitEmpty("block_id", b.BlockID)
+}
+
+func (b ActionsBlock) IsNil() bool {
+	return len(b.Elements) == 0
+}
+
+// Prep this block for Marshaling.
+func (b ActionsBlock) Build() gojay.MarshalerJSONObject {
+	return b
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (b *ActionsBlock) Release() {
+	for _, el := range b.Elements {
+		el.Release()
+	}
+	b.Elements = b.Elements[:0]
+	b.BlockID = ""
+	actionsBlockPool.Put(b)
+}
+
+// Validate checks the block against Slack limits.
+func (b ActionsBlock) Validate() error {
+	if len(b.Elements) > MaxActionsElements {
+		return errorLimit("actions elements", len(b.Elements), MaxActionsElements)
+	}
+	for _, el := range b.Elements {
+		if err := validate(el); err != nil {
+			return err
+		}
+	}
+	return validateLength("block_id", b.BlockID, MaxBlockIDLength)
+}
@@ -43,3 +43,16 @@ func (b ContextBlock) MarshalJSONObject(enc *gojay.Encoder) {
 func (b ContextBlock) IsNil() bool {
 	return len(b.Elements) == 0
 }
+
+// Validate checks the block against Slack limits.
+func (b ContextBlock) Validate() error {
+	if len(b.Elements) > MaxContextElements {
+		return errorLimit("context elements", len(b.Elements), MaxContextElements)
+	}
+	for _, el := range b.Elements {
+		if err := validate(el); err != nil {
+			return err
+		}
+	}
+	return validateLength("block_id", b.BlockID, MaxBlockIDLength)
+}
@@ -34,3 +34,8 @@ func (b DividerBlock) MarshalJSONObject(enc *gojay.Encoder) {
 func (b DividerBlock) IsNil() bool {
 	return false
 }
+
+// Validate checks the block against Slack limits.
+func (b DividerBlock) Validate() error {
+	return validateLength("block_id", b.BlockID, MaxBlockIDLength)
+}
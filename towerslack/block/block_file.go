@@ -42,3 +42,11 @@ func (b FileBlock) MarshalJSONObject(enc *gojay.Encoder) {
 func (b FileBlock) IsNil() bool {
 	return b.ExternalID == ""
 }
+
+// Validate checks the block against Slack limits.
+func (b FileBlock) Validate() error {
+	if b.ExternalID == "" {
+		return errorMissing("file", "external_id")
+	}
+	return validateLength("block_id", b.BlockID, MaxBlockIDLength)
+}
@@ -16,10 +16,7 @@ type HeaderBlock struct {
 // Creates New HeaderBlock. Text with length higher than 150 will be truncated to that length.
 func NewHeaderBlock(text string) *HeaderBlock {
 	hb := headerBlockPool.Get().(*HeaderBlock) //nolint
-	if len(text) > 150 {
-		text = text[:150]
-	}
-	hb.Text = NewTextComposition(TextPlain, text)
+	hb.Text = NewTextComposition(TextPlain, truncate(text, MaxHeaderLength))
 	return hb
 }
 
@@ -45,3 +42,14 @@ func (b *HeaderBlock) Release() {
 	b.BlockID = ""
 	headerBlockPool.Put(b)
 }
+
+// Validate checks the block against Slack limits.
+func (b HeaderBlock) Validate() error {
+	if b.Text == nil {
+		return errorMissing("header", "text")
+	}
+	if err := validateText("header text", b.Text, MaxHeaderLength); err != nil {
+		return err
+	}
+	return validateLength("block_id", b.BlockID, MaxBlockIDLength)
+}
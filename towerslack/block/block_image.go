@@ -0,0 +1,79 @@
+package block
+
+import (
+	"sync"
+
+	"github.com/francoispqt/gojay"
+)
+
+var imageBlockPool = &sync.Pool{New: func() any { return &ImageBlock{} }}
+
+var _ Block = (*ImageBlock)(nil)
+
+// ImageBlock displays an image.
+//
+// See https://api.slack.com/reference/block-kit/blocks#image for details.
+type ImageBlock struct {
+	ImageURL string
+	AltText  string
+	Title    *TextComposition
+	BlockID  string
+}
+
+func NewImageBlock(imageURL, altText string) *ImageBlock {
+	ib := imageBlockPool.Get().(*ImageBlock) //nolint
+	ib.ImageURL = imageURL
+	ib.AltText = altText
+	return ib
+}
+
+func (b ImageBlock) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "image")
+	enc.AddStringKey("image_url", b.ImageURL)
+	enc.AddStringKey("alt_text", b.AltText)
+	if b.Title != nil {
+		enc.AddObjectKey("title", b.Title)
+	}
+	enc.AddStringKeyOmitEmpty("block_id", b.BlockID)
+}
+
+func (b ImageBlock) IsNil() bool {
+	return b.ImageURL == ""
+}
+
+// Prep this block for Marshaling.
+func (b ImageBlock) Build() gojay.MarshalerJSONObject {
+	return b
+}
+
+// Validate checks the block against Slack limits.
+func (b ImageBlock) Validate() error {
+	if b.ImageURL == "" {
+		return errorMissing("image", "image_url")
+	}
+	if b.AltText == "" {
+		return errorMissing("image", "alt_text")
+	}
+	if err := validateLength("image url", b.ImageURL, MaxImageURLLength); err != nil {
+		return err
+	}
+	if err := validateLength("image alt_text", b.AltText, MaxAltTextLength); err != nil {
+		return err
+	}
+	if err := validateText("image title", b.Title, MaxLabelLength); err != nil {
+		return err
+	}
+	return validateLength("block_id", b.BlockID, MaxBlockIDLength)
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (b *ImageBlock) Release() {
+	b.ImageURL = ""
+	b.AltText = ""
+	if b.Title != nil {
+		b.Title.Release()
+		b.Title = nil
+	}
+	b.BlockID = ""
+	imageBlockPool.Put(b)
+}
@@ -0,0 +1,94 @@
+package block
+
+import (
+	"sync"
+
+	"github.com/francoispqt/gojay"
+)
+
+var inputBlockPool = &sync.Pool{New: func() any { return &InputBlock{} }}
+
+var _ Block = (*InputBlock)(nil)
+
+// InputBlock collects information from users.
+//
+// See https://api.slack.com/reference/block-kit/blocks#input for details.
+type InputBlock struct {
+	Label          *TextComposition
+	Element        Element
+	DispatchAction bool
+	BlockID        string
+	Hint           *TextComposition
+	Optional       bool
+}
+
+// Creates New InputBlock. Label with length higher than 2000 will be truncated to that length.
+func NewInputBlock(label string, element Element) *InputBlock {
+	ib := inputBlockPool.Get().(*InputBlock) //nolint
+	ib.Label = NewTextComposition(TextPlain, truncate(label, MaxLabelLength))
+	ib.Element = element
+	return ib
+}
+
+func (b InputBlock) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "input")
+	enc.AddObjectKey("label", b.Label)
+	if b.Element != nil {
+		enc.AddObjectKey("element", b.Element.BuildElement())
+	}
+	enc.AddBoolKeyOmitEmpty("dispatch_action", b.DispatchAction)
+	enc.AddStringKeyOmitEmpty("block_id", b.BlockID)
+	if b.Hint != nil {
+		enc.AddObjectKey("hint", b.Hint)
+	}
+	enc.AddBoolKeyOmitEmpty("optional", b.Optional)
+}
+
+func (b InputBlock) IsNil() bool {
+	return b.Element == nil
+}
+
+// Prep this block for Marshaling.
+func (b InputBlock) Build() gojay.MarshalerJSONObject {
+	return b
+}
+
+// Validate checks the block against Slack limits.
+func (b InputBlock) Validate() error {
+	if b.Label == nil {
+		return errorMissing("input", "label")
+	}
+	if b.Element == nil {
+		return errorMissing("input", "element")
+	}
+	if err := validateText("input label", b.Label, MaxLabelLength); err != nil {
+		return err
+	}
+	if err := validateText("input hint", b.Hint, MaxLabelLength); err != nil {
+		return err
+	}
+	if err := validateLength("block_id", b.BlockID, MaxBlockIDLength); err != nil {
+		return err
+	}
+	return validate(b.Element)
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (b *InputBlock) Release() {
+	if b.Label != nil {
+		b.Label.Release()
+		b.Label = nil
+	}
+	if b.Element != nil {
+		b.Element.Release()
+		b.Element = nil
+	}
+	if b.Hint != nil {
+		b.Hint.Release()
+		b.Hint = nil
+	}
+	b.DispatchAction = false
+	b.BlockID = ""
+	b.Optional = false
+	inputBlockPool.Put(b)
+}
@@ -0,0 +1,66 @@
+package block
+
+import (
+	"sync"
+
+	"github.com/francoispqt/gojay"
+)
+
+var richTextBlockPool = &sync.Pool{New: func() any { return &RichTextBlock{} }}
+
+var _ Block = (*RichTextBlock)(nil)
+
+// RichTextBlock displays formatted text.
+//
+// Elements should be RichTextSectionElement, RichTextListElement, RichTextPreformattedElement,
+// or RichTextQuoteElement.
+//
+// See https://api.slack.com/reference/block-kit/blocks#rich_text for details.
+type RichTextBlock struct {
+	Elements Elements
+	BlockID  string
+}
+
+func NewRichTextBlock(elements ...Element) *RichTextBlock {
+	rb := richTextBlockPool.Get().(*RichTextBlock) //nolint
+	rb.Elements = elements
+	return rb
+}
+
+func (b RichTextBlock) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "rich_text")
+	enc.AddArrayKey("elements", b.Elements)
+	enc.AddStringKeyOmitEmpty("block_id", b.BlockID)
+}
+
+func (b RichTextBlock) IsNil() bool {
+	return len(b.Elements) == 0
+}
+
+// Prep this block for Marshaling.
+func (b RichTextBlock) Build() gojay.MarshalerJSONObject {
+	return b
+}
+
+// Validate checks the block against Slack limits.
+func (b RichTextBlock) Validate() error {
+	if err := validateLength("block_id", b.BlockID, MaxBlockIDLength); err != nil {
+		return err
+	}
+	for _, el := range b.Elements {
+		if err := validate(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (b *RichTextBlock) Release() {
+	for _, el := range b.Elements {
+		el.Release()
+	}
+	b.Elements = b.Elements[:0]
+	b.BlockID = ""
+	richTextBlockPool.Put(b)
+}
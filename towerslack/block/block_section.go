@@ -78,3 +78,25 @@ func (s *SectionBlock) Release() {
 	}
 	sectionBlockPool.Put(s)
 }
+
+// Validate checks the block against Slack limits.
+func (s SectionBlock) Validate() error {
+	if s.Text == nil && len(s.Fields) == 0 {
+		return errorMissing("section", "text or fields")
+	}
+	if err := validateText("section text", s.Text, MaxTextLength); err != nil {
+		return err
+	}
+	if len(s.Fields) > MaxFields {
+		return errorLimit("section fields", len(s.Fields), MaxFields)
+	}
+	for _, v := range s.Fields {
+		if err := validateText("section field", v, MaxFieldLength); err != nil {
+			return err
+		}
+	}
+	if err := validateLength("block_id", s.BlockID, MaxBlockIDLength); err != nil {
+		return err
+	}
+	return validate(s.Accessory)
+}
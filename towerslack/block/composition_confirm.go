@@ -0,0 +1,62 @@
+package block
+
+import (
+	"sync"
+
+	"github.com/francoispqt/gojay"
+)
+
+var confirmCompositionPool = &sync.Pool{New: func() any { return &ConfirmComposition{} }}
+
+var _ Composition = (*ConfirmComposition)(nil)
+
+// ConfirmComposition is a dialog shown before an interactive element's action is sent.
+//
+// See https://api.slack.com/reference/block-kit/composition-objects#confirm for details.
+type ConfirmComposition struct {
+	Title   *TextComposition
+	Text    *TextComposition
+	Confirm *TextComposition
+	Deny    *TextComposition
+	Style   ButtonStyle
+}
+
+func NewConfirmComposition(title, text, confirm, deny string) *ConfirmComposition {
+	cc := confirmCompositionPool.Get().(*ConfirmComposition) //nolint
+	cc.Title = NewTextComposition(TextPlain, title)
+	cc.Text = NewTextComposition(TextMrkdwn, text)
+	cc.Confirm = NewTextComposition(TextPlain, confirm)
+	cc.Deny = NewTextComposition(TextPlain, deny)
+	return cc
+}
+
+func (c ConfirmComposition) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddObjectKey("title", c.Title)
+	enc.AddObjectKey("text", c.Text)
+	enc.AddObjectKey("confirm", c.Confirm)
+	enc.AddObjectKey("deny", c.Deny)
+	enc.AddStringKeyOmitEmpty("style", string(c.Style))
+}
+
+func (c ConfirmComposition) IsNil() bool {
+	return c.Text == nil
+}
+
+func (c ConfirmComposition) BuildComposition() gojay.MarshalerJSONObject {
+	return c
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (c *ConfirmComposition) Release() {
+	for _, t := range []*TextComposition{c.Title, c.Text, c.Confirm, c.Deny} {
+		if t != nil {
+			t.Release()
+		}
+	}
+	c.Title = nil
+	c.Text = nil
+	c.Confirm = nil
+	c.Deny = nil
+	c.Style = ButtonDefault
+	confirmCompositionPool.Put(c)
+}
@@ -0,0 +1,152 @@
+package block
+
+import (
+	"sync"
+
+	"github.com/francoispqt/gojay"
+)
+
+var (
+	optionCompositionPool      = &sync.Pool{New: func() any { return &OptionComposition{} }}
+	optionGroupCompositionPool = &sync.Pool{New: func() any { return &OptionGroupComposition{} }}
+)
+
+var (
+	_ Composition = (*OptionComposition)(nil)
+	_ Composition = (*OptionGroupComposition)(nil)
+)
+
+// OptionComposition is an item of selects and overflow menus.
+//
+// See https://api.slack.com/reference/block-kit/composition-objects#option for details.
+type OptionComposition struct {
+	Text        *TextComposition
+	Value       string
+	Description *TextComposition
+	URL         string
+}
+
+// Creates New OptionComposition. Text with length higher than 75 will be truncated to that length.
+func NewOptionComposition(text, value string) *OptionComposition {
+	oc := optionCompositionPool.Get().(*OptionComposition) //nolint
+	oc.Text = NewTextComposition(TextPlain, truncate(text, MaxOptionTextLength))
+	oc.Value = value
+	return oc
+}
+
+func (o OptionComposition) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddObjectKey("text", o.Text)
+	enc.AddStringKey("value", o.Value)
+	if o.Description != nil {
+		enc.AddObjectKey("description", o.Description)
+	}
+	enc.AddStringKeyOmitEmpty("url", o.URL)
+}
+
+func (o OptionComposition) IsNil() bool {
+	return o.Text == nil
+}
+
+func (o OptionComposition) BuildComposition() gojay.MarshalerJSONObject {
+	return o
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (o *OptionComposition) Release() {
+	if o.Text != nil {
+		o.Text.Release()
+		o.Text = nil
+	}
+	if o.Description != nil {
+		o.Description.Release()
+		o.Description = nil
+	}
+	o.Value = ""
+	o.URL = ""
+	optionCompositionPool.Put(o)
+}
+
+// Validate checks the option against Slack limits.
+func (o OptionComposition) Validate() error {
+	if o.Text == nil {
+		return errorMissing("option", "text")
+	}
+	if err := validateText("option text", o.Text, MaxOptionTextLength); err != nil {
+		return err
+	}
+	if err := validateLength("option value", o.Value, MaxOptionValueLength); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Options is a list of OptionComposition.
+type Options []*OptionComposition
+
+func (o Options) MarshalJSONArray(enc *gojay.Encoder) {
+	for _, v := range o {
+		enc.AddObject(v)
+	}
+}
+
+func (o Options) IsNil() bool {
+	return len(o) == 0
+}
+
+func (o Options) release() {
+	for _, v := range o {
+		v.Release()
+	}
+}
+
+func (o Options) validate(kind string, limit int) error {
+	if len(o) > limit {
+		return errorLimit(kind+" options", len(o), limit)
+	}
+	for _, v := range o {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OptionGroupComposition groups options in a select menu.
+//
+// See https://api.slack.com/reference/block-kit/composition-objects#option_group for details.
+type OptionGroupComposition struct {
+	Label   *TextComposition
+	Options Options
+}
+
+// Creates New OptionGroupComposition. Label with length higher than 75 will be truncated to that length.
+func NewOptionGroupComposition(label string, options ...*OptionComposition) *OptionGroupComposition {
+	og := optionGroupCompositionPool.Get().(*OptionGroupComposition) //nolint
+	og.Label = NewTextComposition(TextPlain, truncate(label, MaxOptionTextLength))
+	og.Options = options
+	return og
+}
+
+func (o OptionGroupComposition) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddObjectKey("label", o.Label)
+	enc.AddArrayKey("options", o.Options)
+}
+
+func (o OptionGroupComposition) IsNil() bool {
+	return o.Label == nil
+}
+
+func (o OptionGroupComposition) BuildComposition() gojay.MarshalerJSONObject {
+	return o
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (o *OptionGroupComposition) Release() {
+	if o.Label != nil {
+		o.Label.Release()
+		o.Label = nil
+	}
+	o.Options.release()
+	o.Options = o.Options[:0]
+	optionGroupCompositionPool.Put(o)
+}
@@ -8,6 +8,11 @@ import (
 
 var textCompositionPool = &sync.Pool{New: func() any { return &TextComposition{} }}
 
+var (
+	_ Composition = (*TextComposition)(nil)
+	_ Element     = (*TextComposition)(nil)
+)
+
 type TextType string
 
 const (
@@ -46,6 +51,11 @@ func (t TextComposition) BuildComposition() gojay.MarshalerJSONObject {
 	return t
 }
 
+// BuildElement implements Element, so text can be used as element of ContextBlock.
+func (t TextComposition) BuildElement() gojay.MarshalerJSONObject {
+	return t
+}
+
 // Removes all the element and release the associated elements into their own pool for reuse.
 func (t *TextComposition) Release() {
 	t.Type = ""
@@ -54,3 +64,8 @@ func (t *TextComposition) Release() {
 	t.Verbatim = false
 	textCompositionPool.Put(t)
 }
+
+// Validate checks the text against Slack limits.
+func (t TextComposition) Validate() error {
+	return validateLength("text", t.Text, MaxTextLength)
+}
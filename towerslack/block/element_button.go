@@ -0,0 +1,91 @@
+package block
+
+import (
+	"sync"
+
+	"github.com/francoispqt/gojay"
+)
+
+var buttonElementPool = &sync.Pool{New: func() any { return &ButtonElement{} }}
+
+var _ Element = (*ButtonElement)(nil)
+
+type ButtonStyle string
+
+const (
+	ButtonDefault ButtonStyle = ""
+	ButtonPrimary ButtonStyle = "primary"
+	ButtonDanger  ButtonStyle = "danger"
+)
+
+// ButtonElement is an interactive button.
+//
+// See https://api.slack.com/reference/block-kit/block-elements#button for details.
+type ButtonElement struct {
+	Text     *TextComposition
+	ActionID string
+	URL      string
+	Value    string
+	Style    ButtonStyle
+	Confirm  *ConfirmComposition
+}
+
+// Creates New ButtonElement. Text with length higher than 75 will be truncated to that length.
+func NewButtonElement(actionID, text, value string) *ButtonElement {
+	be := buttonElementPool.Get().(*ButtonElement) //nolint
+	be.Text = NewTextComposition(TextPlain, truncate(text, MaxButtonTextLength))
+	be.ActionID = actionID
+	be.Value = value
+	return be
+}
+
+func (b ButtonElement) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "button")
+	enc.AddObjectKey("text", b.Text)
+	enc.AddStringKeyOmitEmpty("action_id", b.ActionID)
+	enc.AddStringKeyOmitEmpty("url", b.URL)
+	enc.AddStringKeyOmitEmpty("value", b.Value)
+	enc.AddStringKeyOmitEmpty("style", string(b.Style))
+	if b.Confirm != nil {
+		enc.AddObjectKey("confirm", b.Confirm)
+	}
+}
+
+func (b ButtonElement) IsNil() bool {
+	return b.Text == nil
+}
+
+func (b ButtonElement) BuildElement() gojay.MarshalerJSONObject {
+	return b
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (b *ButtonElement) Release() {
+	if b.Text != nil {
+		b.Text.Release()
+		b.Text = nil
+	}
+	b.ActionID = ""
+	b.URL = ""
+	b.Value = ""
+	b.Style = ButtonDefault
+	if b.Confirm != nil {
+		b.Confirm.Release()
+		b.Confirm = nil
+	}
+	buttonElementPool.Put(b)
+}
+
+// Validate checks the element against Slack limits.
+func (b ButtonElement) Validate() error {
+	if b.Text == nil {
+		return errorMissing("button", "text")
+	}
+	if err := validateText("button text", b.Text, MaxButtonTextLength); err != nil {
+		return err
+	}
+	if err := validateLength("button action_id", b.ActionID, MaxActionIDLength); err != nil {
+		return err
+	}
+	return validateLength("button value", b.Value, MaxButtonValueLength)
+}
@@ -0,0 +1,36 @@
+package block
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNewButtonElement(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "short text", text: "Acknowledge", want: "Acknowledge"},
+		{name: "ascii text over the limit", text: strings.Repeat("a", 80), want: strings.Repeat("a", 75)},
+		{name: "multi-byte text within the limit", text: strings.Repeat("確", 75), want: strings.Repeat("確", 75)},
+		{name: "multi-byte text over the limit", text: strings.Repeat("確", 80), want: strings.Repeat("確", 75)},
+		{name: "emoji over the limit", text: strings.Repeat("a", 74) + "🔥🔥", want: strings.Repeat("a", 74) + "🔥"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewButtonElement("action", tt.text, "value")
+			defer b.Release()
+			if b.Text.Text != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, b.Text.Text)
+			}
+			if !utf8.ValidString(b.Text.Text) {
+				t.Errorf("expected valid utf-8 text, got %q", b.Text.Text)
+			}
+			if err := b.Validate(); err != nil {
+				t.Errorf("expected truncated button to be valid, got %v", err)
+			}
+		})
+	}
+}
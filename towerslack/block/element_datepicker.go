@@ -0,0 +1,76 @@
+package block
+
+import (
+	"sync"
+	"time"
+
+	"github.com/francoispqt/gojay"
+)
+
+var datePickerElementPool = &sync.Pool{New: func() any { return &DatePickerElement{} }}
+
+var _ Element = (*DatePickerElement)(nil)
+
+// DatePickerElement is a calendar picker.
+//
+// See https://api.slack.com/reference/block-kit/block-elements#datepicker for details.
+type DatePickerElement struct {
+	ActionID    string
+	InitialDate time.Time
+	Placeholder *TextComposition
+	Confirm     *ConfirmComposition
+	FocusOnLoad bool
+}
+
+func NewDatePickerElement(actionID string) *DatePickerElement {
+	de := datePickerElementPool.Get().(*DatePickerElement) //nolint
+	de.ActionID = actionID
+	return de
+}
+
+func (d DatePickerElement) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "datepicker")
+	enc.AddStringKeyOmitEmpty("action_id", d.ActionID)
+	if !d.InitialDate.IsZero() {
+		enc.AddStringKey("initial_date", d.InitialDate.Format("2006-01-02"))
+	}
+	if d.Placeholder != nil {
+		enc.AddObjectKey("placeholder", d.Placeholder)
+	}
+	if d.Confirm != nil {
+		enc.AddObjectKey("confirm", d.Confirm)
+	}
+	enc.AddBoolKeyOmitEmpty("focus_on_load", d.FocusOnLoad)
+}
+
+func (d DatePickerElement) IsNil() bool {
+	return false
+}
+
+func (d DatePickerElement) BuildElement() gojay.MarshalerJSONObject {
+	return d
+}
+
+// Validate checks the element against Slack limits.
+func (d DatePickerElement) Validate() error {
+	if err := validateLength("datepicker action_id", d.ActionID, MaxActionIDLength); err != nil {
+		return err
+	}
+	return validateText("datepicker placeholder", d.Placeholder, MaxPlaceholderLength)
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (d *DatePickerElement) Release() {
+	d.ActionID = ""
+	d.InitialDate = time.Time{}
+	if d.Placeholder != nil {
+		d.Placeholder.Release()
+		d.Placeholder = nil
+	}
+	if d.Confirm != nil {
+		d.Confirm.Release()
+		d.Confirm = nil
+	}
+	d.FocusOnLoad = false
+	datePickerElementPool.Put(d)
+}
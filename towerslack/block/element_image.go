@@ -0,0 +1,58 @@
+package block
+
+import (
+	"sync"
+
+	"github.com/francoispqt/gojay"
+)
+
+var imageElementPool = &sync.Pool{New: func() any { return &ImageElement{} }}
+
+var _ Element = (*ImageElement)(nil)
+
+// ImageElement is an image to be used in section and context blocks.
+//
+// See https://api.slack.com/reference/block-kit/block-elements#image for details.
+type ImageElement struct {
+	ImageURL string
+	AltText  string
+}
+
+func NewImageElement(imageURL, altText string) *ImageElement {
+	ie := imageElementPool.Get().(*ImageElement) //nolint
+	ie.ImageURL = imageURL
+	ie.AltText = altText
+	return ie
+}
+
+func (i ImageElement) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "image")
+	enc.AddStringKey("image_url", i.ImageURL)
+	enc.AddStringKey("alt_text", i.AltText)
+}
+
+func (i ImageElement) IsNil() bool {
+	return i.ImageURL == ""
+}
+
+func (i ImageElement) BuildElement() gojay.MarshalerJSONObject {
+	return i
+}
+
+// Validate checks the element against Slack limits.
+func (i ImageElement) Validate() error {
+	if i.ImageURL == "" {
+		return errorMissing("image element", "image_url")
+	}
+	if i.AltText == "" {
+		return errorMissing("image element", "alt_text")
+	}
+	return nil
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (i *ImageElement) Release() {
+	i.ImageURL = ""
+	i.AltText = ""
+	imageElementPool.Put(i)
+}
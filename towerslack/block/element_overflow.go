@@ -0,0 +1,67 @@
+package block
+
+import (
+	"sync"
+
+	"github.com/francoispqt/gojay"
+)
+
+var overflowElementPool = &sync.Pool{New: func() any { return &OverflowElement{} }}
+
+var _ Element = (*OverflowElement)(nil)
+
+// OverflowElement is a menu with up to 5 options hidden behind a button.
+//
+// See https://api.slack.com/reference/block-kit/block-elements#overflow for details.
+type OverflowElement struct {
+	ActionID string
+	Options  Options
+	Confirm  *ConfirmComposition
+}
+
+func NewOverflowElement(actionID string, options ...*OptionComposition) *OverflowElement {
+	oe := overflowElementPool.Get().(*OverflowElement) //nolint
+	oe.ActionID = actionID
+	oe.Options = options
+	return oe
+}
+
+func (o OverflowElement) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "overflow")
+	enc.AddStringKeyOmitEmpty("action_id", o.ActionID)
+	enc.AddArrayKey("options", o.Options)
+	if o.Confirm != nil {
+		enc.AddObjectKey("confirm", o.Confirm)
+	}
+}
+
+func (o OverflowElement) IsNil() bool {
+	return len(o.Options) == 0
+}
+
+func (o OverflowElement) BuildElement() gojay.MarshalerJSONObject {
+	return o
+}
+
+// Validate checks the element against Slack limits.
+func (o OverflowElement) Validate() error {
+	if len(o.Options) < 2 {
+		return errorMissing("overflow", "at least 2 options")
+	}
+	if err := validateLength("overflow action_id", o.ActionID, MaxActionIDLength); err != nil {
+		return err
+	}
+	return o.Options.validate("overflow", MaxOverflowOptions)
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (o *OverflowElement) Release() {
+	o.ActionID = ""
+	o.Options.release()
+	o.Options = o.Options[:0]
+	if o.Confirm != nil {
+		o.Confirm.Release()
+		o.Confirm = nil
+	}
+	overflowElementPool.Put(o)
+}
@@ -0,0 +1,77 @@
+package block
+
+import (
+	"sync"
+
+	"github.com/francoispqt/gojay"
+)
+
+var plainTextInputElementPool = &sync.Pool{New: func() any { return &PlainTextInputElement{} }}
+
+var _ Element = (*PlainTextInputElement)(nil)
+
+// PlainTextInputElement is a free-text input to be used in input blocks.
+//
+// See https://api.slack.com/reference/block-kit/block-elements#input for details.
+type PlainTextInputElement struct {
+	ActionID     string
+	InitialValue string
+	Multiline    bool
+	MinLength    int
+	MaxLength    int
+	Placeholder  *TextComposition
+	FocusOnLoad  bool
+}
+
+func NewPlainTextInputElement(actionID string) *PlainTextInputElement {
+	pe := plainTextInputElementPool.Get().(*PlainTextInputElement) //nolint
+	pe.ActionID = actionID
+	return pe
+}
+
+func (p PlainTextInputElement) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "plain_text_input")
+	enc.AddStringKeyOmitEmpty("action_id", p.ActionID)
+	enc.AddStringKeyOmitEmpty("initial_value", p.InitialValue)
+	enc.AddBoolKeyOmitEmpty("multiline", p.Multiline)
+	enc.AddIntKeyOmitEmpty("min_length", p.MinLength)
+	enc.AddIntKeyOmitEmpty("max_length", p.MaxLength)
+	if p.Placeholder != nil {
+		enc.AddObjectKey("placeholder", p.Placeholder)
+	}
+	enc.AddBoolKeyOmitEmpty("focus_on_load", p.FocusOnLoad)
+}
+
+func (p PlainTextInputElement) IsNil() bool {
+	return false
+}
+
+func (p PlainTextInputElement) BuildElement() gojay.MarshalerJSONObject {
+	return p
+}
+
+// Validate checks the element against Slack limits.
+func (p PlainTextInputElement) Validate() error {
+	if err := validateLength("plain_text_input action_id", p.ActionID, MaxActionIDLength); err != nil {
+		return err
+	}
+	if p.MinLength > MaxPlainTextInputLength {
+		return errorLimit("plain_text_input min_length", p.MinLength, MaxPlainTextInputLength)
+	}
+	return validateText("plain_text_input placeholder", p.Placeholder, MaxPlaceholderLength)
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (p *PlainTextInputElement) Release() {
+	p.ActionID = ""
+	p.InitialValue = ""
+	p.Multiline = false
+	p.MinLength = 0
+	p.MaxLength = 0
+	if p.Placeholder != nil {
+		p.Placeholder.Release()
+		p.Placeholder = nil
+	}
+	p.FocusOnLoad = false
+	plainTextInputElementPool.Put(p)
+}
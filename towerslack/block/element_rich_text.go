@@ -0,0 +1,383 @@
+package block
+
+import (
+	"sync"
+	"unicode/utf8"
+
+	"github.com/francoispqt/gojay"
+)
+
+var (
+	richTextSectionElementPool      = &sync.Pool{New: func() any { return &RichTextSectionElement{} }}
+	richTextPreformattedElementPool = &sync.Pool{New: func() any { return &RichTextPreformattedElement{} }}
+	richTextQuoteElementPool        = &sync.Pool{New: func() any { return &RichTextQuoteElement{} }}
+	richTextListElementPool         = &sync.Pool{New: func() any { return &RichTextListElement{} }}
+	richTextTextElementPool         = &sync.Pool{New: func() any { return &RichTextTextElement{} }}
+	richTextLinkElementPool         = &sync.Pool{New: func() any { return &RichTextLinkElement{} }}
+	richTextEmojiElementPool        = &sync.Pool{New: func() any { return &RichTextEmojiElement{} }}
+	richTextUserElementPool         = &sync.Pool{New: func() any { return &RichTextUserElement{} }}
+)
+
+var (
+	_ Element = (*RichTextSectionElement)(nil)
+	_ Element = (*RichTextPreformattedElement)(nil)
+	_ Element = (*RichTextQuoteElement)(nil)
+	_ Element = (*RichTextListElement)(nil)
+	_ Element = (*RichTextTextElement)(nil)
+	_ Element = (*RichTextLinkElement)(nil)
+	_ Element = (*RichTextEmojiElement)(nil)
+	_ Element = (*RichTextUserElement)(nil)
+)
+
+// RichTextStyle is the styling of inline rich text elements.
+type RichTextStyle struct {
+	Bold   bool
+	Italic bool
+	Strike bool
+	Code   bool
+}
+
+func (s RichTextStyle) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddBoolKeyOmitEmpty("bold", s.Bold)
+	enc.AddBoolKeyOmitEmpty("italic", s.Italic)
+	enc.AddBoolKeyOmitEmpty("strike", s.Strike)
+	enc.AddBoolKeyOmitEmpty("code", s.Code)
+}
+
+func (s RichTextStyle) IsNil() bool {
+	return !s.Bold && !s.Italic && !s.Strike && !s.Code
+}
+
+func releaseElements(elements Elements) Elements {
+	for _, el := range elements {
+		el.Release()
+	}
+	return elements[:0]
+}
+
+func validateTexts(kind string, elements Elements) error {
+	var length int
+	for _, el := range elements {
+		switch el := el.(type) {
+		case *RichTextTextElement:
+			length += utf8.RuneCountInString(el.Text)
+		case *RichTextLinkElement:
+			length += utf8.RuneCountInString(el.Text)
+		default:
+			if err := validate(el); err != nil {
+				return err
+			}
+		}
+	}
+	if length > MaxTextLength {
+		return errorLimit(kind+" text length", length, MaxTextLength)
+	}
+	return nil
+}
+
+// RichTextSectionElement is a paragraph of inline rich text elements.
+type RichTextSectionElement struct {
+	Elements Elements
+}
+
+func NewRichTextSectionElement(elements ...Element) *RichTextSectionElement {
+	re := richTextSectionElementPool.Get().(*RichTextSectionElement) //nolint
+	re.Elements = elements
+	return re
+}
+
+func (r RichTextSectionElement) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "rich_text_section")
+	enc.AddArrayKey("elements", r.Elements)
+}
+
+func (r RichTextSectionElement) IsNil() bool {
+	return false
+}
+
+func (r RichTextSectionElement) BuildElement() gojay.MarshalerJSONObject {
+	return r
+}
+
+// Validate checks the element against Slack limits.
+func (r RichTextSectionElement) Validate() error {
+	return validateTexts("rich_text_section", r.Elements)
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (r *RichTextSectionElement) Release() {
+	r.Elements = releaseElements(r.Elements)
+	richTextSectionElementPool.Put(r)
+}
+
+// RichTextPreformattedElement is a code block of inline rich text elements.
+type RichTextPreformattedElement struct {
+	Elements Elements
+	Border   int
+}
+
+// NewRichTextPreformattedElement creates code block with given text.
+func NewRichTextPreformattedElement(text string) *RichTextPreformattedElement {
+	re := richTextPreformattedElementPool.Get().(*RichTextPreformattedElement) //nolint
+	re.Elements = append(re.Elements, NewRichTextTextElement(text))
+	return re
+}
+
+func (r RichTextPreformattedElement) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "rich_text_preformatted")
+	enc.AddArrayKey("elements", r.Elements)
+	enc.AddIntKeyOmitEmpty("border", r.Border)
+}
+
+func (r RichTextPreformattedElement) IsNil() bool {
+	return false
+}
+
+func (r RichTextPreformattedElement) BuildElement() gojay.MarshalerJSONObject {
+	return r
+}
+
+// Validate checks the element against Slack limits.
+func (r RichTextPreformattedElement) Validate() error {
+	return validateTexts("rich_text_preformatted", r.Elements)
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (r *RichTextPreformattedElement) Release() {
+	r.Elements = releaseElements(r.Elements)
+	r.Border = 0
+	richTextPreformattedElementPool.Put(r)
+}
+
+// RichTextQuoteElement is a quote of inline rich text elements.
+type RichTextQuoteElement struct {
+	Elements Elements
+	Border   int
+}
+
+func NewRichTextQuoteElement(elements ...Element) *RichTextQuoteElement {
+	re := richTextQuoteElementPool.Get().(*RichTextQuoteElement) //nolint
+	re.Elements = elements
+	return re
+}
+
+func (r RichTextQuoteElement) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "rich_text_quote")
+	enc.AddArrayKey("elements", r.Elements)
+	enc.AddIntKeyOmitEmpty("border", r.Border)
+}
+
+func (r RichTextQuoteElement) IsNil() bool {
+	return false
+}
+
+func (r RichTextQuoteElement) BuildElement() gojay.MarshalerJSONObject {
+	return r
+}
+
+// Validate checks the element against Slack limits.
+func (r RichTextQuoteElement) Validate() error {
+	return validateTexts("rich_text_quote", r.Elements)
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (r *RichTextQuoteElement) Release() {
+	r.Elements = releaseElements(r.Elements)
+	r.Border = 0
+	richTextQuoteElementPool.Put(r)
+}
+
+type RichTextListStyle string
+
+const (
+	RichTextListBullet  RichTextListStyle = "bullet"
+	RichTextListOrdered RichTextListStyle = "ordered"
+)
+
+// RichTextListElement is a list of RichTextSectionElement.
+type RichTextListElement struct {
+	Style    RichTextListStyle
+	Elements Elements
+	Indent   int
+	Offset   int
+	Border   int
+}
+
+func NewRichTextListElement(style RichTextListStyle, items ...*RichTextSectionElement) *RichTextListElement {
+	re := richTextListElementPool.Get().(*RichTextListElement) //nolint
+	re.Style = style
+	for _, item := range items {
+		re.Elements = append(re.Elements, item)
+	}
+	return re
+}
+
+func (r RichTextListElement) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "rich_text_list")
+	enc.AddStringKey("style", string(r.Style))
+	enc.AddArrayKey("elements", r.Elements)
+	enc.AddIntKeyOmitEmpty("indent", r.Indent)
+	enc.AddIntKeyOmitEmpty("offset", r.Offset)
+	enc.AddIntKeyOmitEmpty("border", r.Border)
+}
+
+func (r RichTextListElement) IsNil() bool {
+	return false
+}
+
+func (r RichTextListElement) BuildElement() gojay.MarshalerJSONObject {
+	return r
+}
+
+// Validate checks the element against Slack limits.
+func (r RichTextListElement) Validate() error {
+	for _, el := range r.Elements {
+		if err := validate(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (r *RichTextListElement) Release() {
+	r.Style = ""
+	r.Elements = releaseElements(r.Elements)
+	r.Indent = 0
+	r.Offset = 0
+	r.Border = 0
+	richTextListElementPool.Put(r)
+}
+
+// RichTextTextElement is an inline text.
+type RichTextTextElement struct {
+	Text  string
+	Style RichTextStyle
+}
+
+func NewRichTextTextElement(text string) *RichTextTextElement {
+	re := richTextTextElementPool.Get().(*RichTextTextElement) //nolint
+	re.Text = text
+	return re
+}
+
+func (r RichTextTextElement) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "text")
+	enc.AddStringKey("text", r.Text)
+	enc.AddObjectKeyOmitEmpty("style", r.Style)
+}
+
+func (r RichTextTextElement) IsNil() bool {
+	return r.Text == ""
+}
+
+func (r RichTextTextElement) BuildElement() gojay.MarshalerJSONObject {
+	return r
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (r *RichTextTextElement) Release() {
+	r.Text = ""
+	r.Style = RichTextStyle{}
+	richTextTextElementPool.Put(r)
+}
+
+// RichTextLinkElement is an inline link.
+type RichTextLinkElement struct {
+	URL   string
+	Text  string
+	Style RichTextStyle
+}
+
+func NewRichTextLinkElement(url, text string) *RichTextLinkElement {
+	re := richTextLinkElementPool.Get().(*RichTextLinkElement) //nolint
+	re.URL = url
+	re.Text = text
+	return re
+}
+
+func (r RichTextLinkElement) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "link")
+	enc.AddStringKey("url", r.URL)
+	enc.AddStringKeyOmitEmpty("text", r.Text)
+	enc.AddObjectKeyOmitEmpty("style", r.Style)
+}
+
+func (r RichTextLinkElement) IsNil() bool {
+	return r.URL == ""
+}
+
+func (r RichTextLinkElement) BuildElement() gojay.MarshalerJSONObject {
+	return r
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (r *RichTextLinkElement) Release() {
+	r.URL = ""
+	r.Text = ""
+	r.Style = RichTextStyle{}
+	richTextLinkElementPool.Put(r)
+}
+
+// RichTextEmojiElement is an inline emoji.
+type RichTextEmojiElement struct {
+	Name string
+}
+
+func NewRichTextEmojiElement(name string) *RichTextEmojiElement {
+	re := richTextEmojiElementPool.Get().(*RichTextEmojiElement) //nolint
+	re.Name = name
+	return re
+}
+
+func (r RichTextEmojiElement) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "emoji")
+	enc.AddStringKey("name", r.Name)
+}
+
+func (r RichTextEmojiElement) IsNil() bool {
+	return r.Name == ""
+}
+
+func (r RichTextEmojiElement) BuildElement() gojay.MarshalerJSONObject {
+	return r
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (r *RichTextEmojiElement) Release() {
+	r.Name = ""
+	richTextEmojiElementPool.Put(r)
+}
+
+// RichTextUserElement is an inline user mention.
+type RichTextUserElement struct {
+	UserID string
+	Style  RichTextStyle
+}
+
+func NewRichTextUserElement(userID string) *RichTextUserElement {
+	re := richTextUserElementPool.Get().(*RichTextUserElement) //nolint
+	re.UserID = userID
+	return re
+}
+
+func (r RichTextUserElement) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "user")
+	enc.AddStringKey("user_id", r.UserID)
+	enc.AddObjectKeyOmitEmpty("style", r.Style)
+}
+
+func (r RichTextUserElement) IsNil() bool {
+	return r.UserID == ""
+}
+
+func (r RichTextUserElement) BuildElement() gojay.MarshalerJSONObject {
+	return r
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (r *RichTextUserElement) Release() {
+	r.UserID = ""
+	r.Style = RichTextStyle{}
+	richTextUserElementPool.Put(r)
+}
@@ -0,0 +1,111 @@
+package block
+
+import (
+	"sync"
+
+	"github.com/francoispqt/gojay"
+)
+
+var staticSelectElementPool = &sync.Pool{New: func() any { return &StaticSelectElement{} }}
+
+var _ Element = (*StaticSelectElement)(nil)
+
+// StaticSelectElement is a select menu with predefined options.
+//
+// See https://api.slack.com/reference/block-kit/block-elements#static_select for details.
+type StaticSelectElement struct {
+	Placeholder   *TextComposition
+	ActionID      string
+	Options       Options
+	OptionGroups  []*OptionGroupComposition
+	InitialOption *OptionComposition
+	Confirm       *ConfirmComposition
+	FocusOnLoad   bool
+}
+
+// Creates New StaticSelectElement. Placeholder with length higher than 150 will be truncated to that length.
+func NewStaticSelectElement(actionID, placeholder string, options ...*OptionComposition) *StaticSelectElement {
+	se := staticSelectElementPool.Get().(*StaticSelectElement) //nolint
+	placeholder = truncate(placeholder, MaxPlaceholderLength)
+	se.ActionID = actionID
+	se.Placeholder = NewTextComposition(TextPlain, placeholder)
+	se.Options = options
+	return se
+}
+
+func (s StaticSelectElement) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("type", "static_select")
+	if s.Placeholder != nil {
+		enc.AddObjectKey("placeholder", s.Placeholder)
+	}
+	enc.AddStringKeyOmitEmpty("action_id", s.ActionID)
+	if len(s.OptionGroups) > 0 {
+		enc.AddArrayKey("option_groups", gojay.EncodeArrayFunc(func(e *gojay.Encoder) {
+			for _, v := range s.OptionGroups {
+				e.AddObject(v)
+			}
+		}))
+	} else {
+		enc.AddArrayKey("options", s.Options)
+	}
+	if s.InitialOption != nil {
+		enc.AddObjectKey("initial_option", s.InitialOption)
+	}
+	if s.Confirm != nil {
+		enc.AddObjectKey("confirm", s.Confirm)
+	}
+	enc.AddBoolKeyOmitEmpty("focus_on_load", s.FocusOnLoad)
+}
+
+func (s StaticSelectElement) IsNil() bool {
+	return len(s.Options) == 0 && len(s.OptionGroups) == 0
+}
+
+func (s StaticSelectElement) BuildElement() gojay.MarshalerJSONObject {
+	return s
+}
+
+// Validate checks the element against Slack limits.
+func (s StaticSelectElement) Validate() error {
+	if err := validateLength("static_select action_id", s.ActionID, MaxActionIDLength); err != nil {
+		return err
+	}
+	if len(s.OptionGroups) > MaxSelectOptionGroups {
+		return errorLimit("static_select option groups", len(s.OptionGroups), MaxSelectOptionGroups)
+	}
+	if err := validateText("static_select placeholder", s.Placeholder, MaxPlaceholderLength); err != nil {
+		return err
+	}
+	for _, group := range s.OptionGroups {
+		if err := validateText("static_select option group label", group.Label, MaxOptionTextLength); err != nil {
+			return err
+		}
+		if err := group.Options.validate("static_select option group", MaxSelectOptions); err != nil {
+			return err
+		}
+	}
+	return s.Options.validate("static_select", MaxSelectOptions)
+}
+
+// Removes all the element and release the associated elements into their own pool for reuse.
+func (s *StaticSelectElement) Release() {
+	if s.Placeholder != nil {
+		s.Placeholder.Release()
+		s.Placeholder = nil
+	}
+	s.ActionID = ""
+	s.Options.release()
+	s.Options = s.Options[:0]
+	for _, v := range s.OptionGroups {
+		v.Release()
+	}
+	s.OptionGroups = s.OptionGroups[:0]
+	// InitialOption usually points to one of the options, so it's only dereferenced.
+	s.InitialOption = nil
+	if s.Confirm != nil {
+		s.Confirm.Release()
+		s.Confirm = nil
+	}
+	s.FocusOnLoad = false
+	staticSelectElementPool.Put(s)
+}
@@ -0,0 +1,103 @@
+package block
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Slack Block Kit limits.
+//
+// See https://api.slack.com/reference/block-kit/blocks for details.
+const (
+	MaxBlocks               = 50
+	MaxBlockIDLength        = 255
+	MaxTextLength           = 3000
+	MaxFieldLength          = 2000
+	MaxFields               = 10
+	MaxHeaderLength         = 150
+	MaxContextElements      = 10
+	MaxActionsElements      = 25
+	MaxLabelLength          = 2000
+	MaxImageURLLength       = 3000
+	MaxAltTextLength        = 2000
+	MaxActionIDLength       = 255
+	MaxButtonTextLength     = 75
+	MaxButtonValueLength    = 2000
+	MaxOptionTextLength     = 75
+	MaxOptionValueLength    = 150
+	MaxPlaceholderLength    = 150
+	MaxOverflowOptions      = 5
+	MaxSelectOptions        = 100
+	MaxSelectOptionGroups   = 100
+	MaxPlainTextInputLength = 3000
+)
+
+// ErrLimitExceeded is returned when blocks exceed Slack limits.
+var ErrLimitExceeded = errors.New("slack block kit limit exceeded")
+
+// ErrMissingField is returned when blocks miss a field required by Slack.
+var ErrMissingField = errors.New("slack block kit required field is missing")
+
+// Validator is implemented by blocks, elements, and compositions that can check themselves against Slack limits.
+type Validator interface {
+	Validate() error
+}
+
+// Validate checks the blocks against Slack limits. Slack rejects the whole message when any of the limits are exceeded,
+// so it's better to check them before sending.
+func (b Blocks) Validate() error {
+	if len(b) > MaxBlocks {
+		return errorLimit("blocks", len(b), MaxBlocks)
+	}
+	for i, v := range b {
+		if err := validate(v); err != nil {
+			return fmt.Errorf("block[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validate(v any) error {
+	if v, ok := v.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+func errorLimit(kind string, got, limit int) error {
+	return fmt.Errorf("%w: %s has %d, maximum is %d", ErrLimitExceeded, kind, got, limit)
+}
+
+func errorMissing(kind, field string) error {
+	return fmt.Errorf("%w: %s requires %s", ErrMissingField, kind, field)
+}
+
+func validateLength(kind, s string, limit int) error {
+	if n := utf8.RuneCountInString(s); n > limit {
+		return errorLimit(kind+" length", n, limit)
+	}
+	return nil
+}
+
+func validateText(kind string, t *TextComposition, limit int) error {
+	if t == nil {
+		return nil
+	}
+	return validateLength(kind, t.Text, limit)
+}
+
+// truncate cuts s to at most limit characters without splitting multi-byte characters.
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	var n int
+	for i := range s {
+		if n == limit {
+			return s[:i]
+		}
+		n++
+	}
+	return s
+}
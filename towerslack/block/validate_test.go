@@ -0,0 +1,197 @@
+package block
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// runes returns a string of n multi-byte characters, so the tests fail if lengths are counted in bytes.
+func runes(n int) string {
+	return strings.Repeat("確", n)
+}
+
+func plain(text string) *TextComposition {
+	return &TextComposition{Type: TextPlain, Text: text}
+}
+
+func options(n int) Options {
+	opts := make(Options, n)
+	for i := range opts {
+		opts[i] = &OptionComposition{Text: plain("option"), Value: "value"}
+	}
+	return opts
+}
+
+func elements(n int) Elements {
+	els := make(Elements, n)
+	for i := range els {
+		els[i] = &ButtonElement{Text: plain("button")}
+	}
+	return els
+}
+
+func dividers(n int) Blocks {
+	blocks := make(Blocks, n)
+	for i := range blocks {
+		blocks[i] = &DividerBlock{}
+	}
+	return blocks
+}
+
+func optionGroups(n int, label string) []*OptionGroupComposition {
+	groups := make([]*OptionGroupComposition, n)
+	for i := range groups {
+		groups[i] = &OptionGroupComposition{Label: plain(label), Options: options(1)}
+	}
+	return groups
+}
+
+func TestBlocks_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		blocks  Blocks
+		wantErr error
+	}{
+		{name: "max blocks", blocks: dividers(MaxBlocks)},
+		{name: "over max blocks", blocks: dividers(MaxBlocks + 1), wantErr: ErrLimitExceeded},
+		{name: "nested error", blocks: Blocks{&DividerBlock{}, &FileBlock{}}, wantErr: ErrMissingField},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.blocks.Validate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator Validator
+		wantErr   error
+	}{
+		{name: "block_id at limit", validator: &DividerBlock{BlockID: runes(MaxBlockIDLength)}},
+		{name: "block_id over limit", validator: &DividerBlock{BlockID: runes(MaxBlockIDLength + 1)}, wantErr: ErrLimitExceeded},
+
+		{name: "section text at limit", validator: &SectionBlock{Text: plain(runes(MaxTextLength))}},
+		{name: "section text over limit", validator: &SectionBlock{Text: plain(runes(MaxTextLength + 1))}, wantErr: ErrLimitExceeded},
+		{name: "section without text and fields", validator: &SectionBlock{}, wantErr: ErrMissingField},
+		{name: "section fields at limit", validator: NewSectionBlockFields(TextMrkdwn, make([]string, MaxFields)...)},
+		{name: "section fields over limit", validator: NewSectionBlockFields(TextMrkdwn, make([]string, MaxFields+1)...), wantErr: ErrLimitExceeded},
+		{name: "section field at limit", validator: NewSectionBlockFields(TextMrkdwn, runes(MaxFieldLength))},
+		{name: "section field over limit", validator: NewSectionBlockFields(TextMrkdwn, runes(MaxFieldLength+1)), wantErr: ErrLimitExceeded},
+
+		{name: "header at limit", validator: &HeaderBlock{Text: plain(runes(MaxHeaderLength))}},
+		{name: "header over limit", validator: &HeaderBlock{Text: plain(runes(MaxHeaderLength + 1))}, wantErr: ErrLimitExceeded},
+		{name: "header is truncated by constructor", validator: NewHeaderBlock(runes(MaxHeaderLength + 10))},
+		{name: "header without text", validator: &HeaderBlock{}, wantErr: ErrMissingField},
+
+		{name: "context elements at limit", validator: &ContextBlock{Elements: elements(MaxContextElements)}},
+		{name: "context elements over limit", validator: &ContextBlock{Elements: elements(MaxContextElements + 1)}, wantErr: ErrLimitExceeded},
+		{name: "context text over limit", validator: &ContextBlock{Elements: Elements{plain(runes(MaxTextLength + 1))}}, wantErr: ErrLimitExceeded},
+
+		{name: "actions elements at limit", validator: &ActionsBlock{Elements: elements(MaxActionsElements)}},
+		{name: "actions elements over limit", validator: &ActionsBlock{Elements: elements(MaxActionsElements + 1)}, wantErr: ErrLimitExceeded},
+
+		{name: "input label at limit", validator: &InputBlock{Label: plain(runes(MaxLabelLength)), Element: &PlainTextInputElement{}}},
+		{name: "input label over limit", validator: &InputBlock{Label: plain(runes(MaxLabelLength + 1)), Element: &PlainTextInputElement{}}, wantErr: ErrLimitExceeded},
+		{name: "input label is truncated by constructor", validator: NewInputBlock(runes(MaxLabelLength+10), &PlainTextInputElement{})},
+		{name: "input hint over limit", validator: &InputBlock{Label: plain("label"), Hint: plain(runes(MaxLabelLength + 1)), Element: &PlainTextInputElement{}}, wantErr: ErrLimitExceeded},
+		{name: "input without element", validator: &InputBlock{Label: plain("label")}, wantErr: ErrMissingField},
+
+		{name: "image url at limit", validator: &ImageBlock{ImageURL: runes(MaxImageURLLength), AltText: "alt"}},
+		{name: "image url over limit", validator: &ImageBlock{ImageURL: runes(MaxImageURLLength + 1), AltText: "alt"}, wantErr: ErrLimitExceeded},
+		{name: "image alt_text at limit", validator: &ImageBlock{ImageURL: "https://example.com", AltText: runes(MaxAltTextLength)}},
+		{name: "image alt_text over limit", validator: &ImageBlock{ImageURL: "https://example.com", AltText: runes(MaxAltTextLength + 1)}, wantErr: ErrLimitExceeded},
+		{name: "image title over limit", validator: &ImageBlock{ImageURL: "https://example.com", AltText: "alt", Title: plain(runes(MaxLabelLength + 1))}, wantErr: ErrLimitExceeded},
+		{name: "image without alt_text", validator: &ImageBlock{ImageURL: "https://example.com"}, wantErr: ErrMissingField},
+		{name: "file without external_id", validator: &FileBlock{}, wantErr: ErrMissingField},
+
+		{name: "button action_id at limit", validator: &ButtonElement{Text: plain("ok"), ActionID: runes(MaxActionIDLength)}},
+		{name: "button action_id over limit", validator: &ButtonElement{Text: plain("ok"), ActionID: runes(MaxActionIDLength + 1)}, wantErr: ErrLimitExceeded},
+		{name: "button text at limit", validator: &ButtonElement{Text: plain(runes(MaxButtonTextLength))}},
+		{name: "button text over limit", validator: &ButtonElement{Text: plain(runes(MaxButtonTextLength + 1))}, wantErr: ErrLimitExceeded},
+		{name: "button value at limit", validator: &ButtonElement{Text: plain("ok"), Value: runes(MaxButtonValueLength)}},
+		{name: "button value over limit", validator: &ButtonElement{Text: plain("ok"), Value: runes(MaxButtonValueLength + 1)}, wantErr: ErrLimitExceeded},
+
+		{name: "option text at limit", validator: &OptionComposition{Text: plain(runes(MaxOptionTextLength))}},
+		{name: "option text over limit", validator: &OptionComposition{Text: plain(runes(MaxOptionTextLength + 1))}, wantErr: ErrLimitExceeded},
+		{name: "option text is truncated by constructor", validator: NewOptionComposition(runes(MaxOptionTextLength+10), "value")},
+		{name: "option value at limit", validator: &OptionComposition{Text: plain("ok"), Value: runes(MaxOptionValueLength)}},
+		{name: "option value over limit", validator: &OptionComposition{Text: plain("ok"), Value: runes(MaxOptionValueLength + 1)}, wantErr: ErrLimitExceeded},
+
+		{name: "datepicker placeholder at limit", validator: &DatePickerElement{Placeholder: plain(runes(MaxPlaceholderLength))}},
+		{name: "datepicker placeholder over limit", validator: &DatePickerElement{Placeholder: plain(runes(MaxPlaceholderLength + 1))}, wantErr: ErrLimitExceeded},
+		{name: "plain_text_input placeholder over limit", validator: &PlainTextInputElement{Placeholder: plain(runes(MaxPlaceholderLength + 1))}, wantErr: ErrLimitExceeded},
+		{name: "plain_text_input min_length at limit", validator: &PlainTextInputElement{MinLength: MaxPlainTextInputLength}},
+		{name: "plain_text_input min_length over limit", validator: &PlainTextInputElement{MinLength: MaxPlainTextInputLength + 1}, wantErr: ErrLimitExceeded},
+
+		{name: "overflow options at limit", validator: &OverflowElement{Options: options(MaxOverflowOptions)}},
+		{name: "overflow options over limit", validator: &OverflowElement{Options: options(MaxOverflowOptions + 1)}, wantErr: ErrLimitExceeded},
+		{name: "overflow with one option", validator: &OverflowElement{Options: options(1)}, wantErr: ErrMissingField},
+
+		{name: "static_select options at limit", validator: &StaticSelectElement{Options: options(MaxSelectOptions)}},
+		{name: "static_select options over limit", validator: &StaticSelectElement{Options: options(MaxSelectOptions + 1)}, wantErr: ErrLimitExceeded},
+		{name: "static_select option groups at limit", validator: &StaticSelectElement{OptionGroups: optionGroups(MaxSelectOptionGroups, runes(MaxOptionTextLength))}},
+		{name: "static_select option groups over limit", validator: &StaticSelectElement{OptionGroups: optionGroups(MaxSelectOptionGroups+1, "group")}, wantErr: ErrLimitExceeded},
+		{name: "static_select option group label over limit", validator: &StaticSelectElement{OptionGroups: optionGroups(1, runes(MaxOptionTextLength+1))}, wantErr: ErrLimitExceeded},
+		{name: "static_select placeholder over limit", validator: &StaticSelectElement{Placeholder: plain(runes(MaxPlaceholderLength + 1)), Options: options(1)}, wantErr: ErrLimitExceeded},
+		{name: "static_select placeholder is truncated by constructor", validator: NewStaticSelectElement("select", runes(MaxPlaceholderLength+10), options(1)...)},
+
+		{name: "rich text at limit", validator: &RichTextSectionElement{Elements: Elements{
+			&RichTextTextElement{Text: runes(MaxTextLength / 2)},
+			&RichTextLinkElement{URL: "https://example.com", Text: runes(MaxTextLength / 2)},
+		}}},
+		{name: "rich text over limit", validator: &RichTextSectionElement{Elements: Elements{
+			&RichTextTextElement{Text: runes(MaxTextLength / 2)},
+			&RichTextLinkElement{URL: "https://example.com", Text: runes(MaxTextLength/2 + 1)},
+		}}, wantErr: ErrLimitExceeded},
+		{name: "rich text block with nested list over limit", validator: &RichTextBlock{Elements: Elements{
+			&RichTextListElement{Elements: Elements{&RichTextSectionElement{Elements: Elements{&RichTextTextElement{Text: runes(MaxTextLength + 1)}}}}},
+		}}, wantErr: ErrLimitExceeded},
+		{name: "preformatted text over limit", validator: &RichTextPreformattedElement{Elements: Elements{&RichTextTextElement{Text: runes(MaxTextLength + 1)}}}, wantErr: ErrLimitExceeded},
+		{name: "quote text over limit", validator: &RichTextQuoteElement{Elements: Elements{&RichTextTextElement{Text: runes(MaxTextLength + 1)}}}, wantErr: ErrLimitExceeded},
+
+		{name: "text at limit", validator: plain(runes(MaxTextLength))},
+		{name: "text over limit", validator: plain(runes(MaxTextLength + 1)), wantErr: ErrLimitExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validator.Validate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		limit int
+		want  string
+	}{
+		{name: "shorter than limit", input: "foo", limit: 5, want: "foo"},
+		{name: "ascii over limit", input: "foobar", limit: 3, want: "foo"},
+		{name: "multi-byte within limit", input: runes(5), limit: 5, want: runes(5)},
+		{name: "multi-byte over limit", input: runes(10), limit: 5, want: runes(5)},
+		{name: "mixed over limit", input: "a確b確c", limit: 4, want: "a確b確"},
+		{name: "zero limit", input: "foo", limit: 0, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncate(tt.input, tt.limit)
+			if got != tt.want {
+				t.Errorf("truncate() = %q, want %q", got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncate() returned invalid utf-8 %q", got)
+			}
+		})
+	}
+}
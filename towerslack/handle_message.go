@@ -23,19 +23,49 @@ func (s SlackBot) handleMessage(ctx context.Context, msg tower.MessageContext) {
 	if err := s.cache.Set(ctx, s.globalKey, []byte("locked"), time.Second*30); err != nil {
 		_ = msg.Tower().Wrap(err).Message("%s: failed to set global lock to cache", s.Name()).Log(ctx)
 	}
+	extra := &ExtraInformation{CacheKey: key}
+	iterKey := key + s.cache.Separator() + "iter"
+	if s.isMuted(ctx, key) {
+		suppressed := s.countCooldown(msg, s.getIter(ctx, iterKey))
+		if suppressed < maxMuteDuration {
+			suppressed = maxMuteDuration
+		}
+		s.countOccurrence(ctx, msg, key, suppressed)
+		s.cache.Delete(ctx, s.globalKey)
+		return
+	}
 	if msg.SkipVerification() {
-		_ = s.postMessage(ctx, msg)
+		if resp, err := s.postMessage(ctx, msg, extra); err == nil {
+			resp.Release()
+		}
 		return
 	}
 	if s.cache.Exist(ctx, key) {
+		s.countOccurrence(ctx, msg, key, s.countCooldown(msg, s.getIter(ctx, iterKey)))
 		s.cache.Delete(ctx, s.globalKey)
 		return
 	}
 
-	iterKey := key + s.cache.Separator() + "iter"
 	iter := s.getAndSetIter(ctx, iterKey)
-	err := s.postMessage(ctx, msg)
+	extra.Iteration = iter
+	extra.CooldownTimeEnds = time.Now().Add(s.countCooldown(msg, iter))
+	s.getOccurrence(ctx, key, extra)
+	extra.Thread = s.getThreadReference(ctx, key)
+	if extra.Thread != nil {
+		extra.Thread.Count += 1 + extra.Occurrences
+		if msg.Level() > extra.Thread.Level {
+			extra.Broadcast = true
+			extra.Thread.Level = msg.Level()
+		}
+	}
+	resp, err := s.postMessage(ctx, msg, extra)
 	if err == nil {
+		s.resetOccurrence(ctx, msg, key, extra)
+		s.saveThreadReference(ctx, msg, key, extra, resp)
+		resp.Release()
+		if extra.Thread != nil && s.updateParent {
+			s.updateParentMessage(ctx, msg, extra)
+		}
 		message := msg.Message()
 		if msg.Err() != nil {
 			message = msg.Err().Error()
@@ -66,33 +96,57 @@ func (s SlackBot) countCooldown(msg tower.MessageContext, iter int) time.Duratio
 	return s.cooldown * time.Duration(mult)
 }
 
-func (s SlackBot) postMessage(ctx context.Context, msg tower.MessageContext) error {
+// postMessage posts the message to slack. Call Release on the returned response once it's no longer used.
+func (s SlackBot) postMessage(ctx context.Context, msg tower.MessageContext, extra *ExtraInformation) (*slackrest.MessageResponse, error) {
 	payload := slackrest.MessagePayloadPool.Get().(*slackrest.MessagePayload) //nolint
 	payload.Reset()
 	defer func() {
 		slackrest.MessagePayloadPool.Put(payload)
 	}()
 
-	blocks, attachments := s.template.BuildTemplate(ctx, msg)
+	blocks, attachments := s.template.BuildTemplate(contextWithExtraInformation(ctx, extra), msg)
+	if err := blocks.Validate(); err != nil {
+		go s.deleteGlobalKeyAfterOneSec(ctx)
+		return nil, msg.Tower().
+			Wrap(err).
+			Message("%s: message template exceeds slack limits", s.Name()).
+			Context(tower.F{"payload_message": msg.Message()}).
+			Log(ctx)
+	}
 	payload.Blocks = blocks
 	payload.Text = msg.Message()
 	payload.Mrkdwn = true
 	payload.Channel = s.channel
-	ctx, cancel := s.setOperationContext(ctx)
+	if extra.Thread != nil {
+		payload.Channel = extra.Thread.Channel
+		payload.ThreadTS = extra.Thread.TS
+		payload.ReplyBroadcast = extra.Broadcast
+	}
+	opCtx, cancel := s.setOperationContext(ctx)
 	defer cancel()
-	resp, err := slackrest.PostMessage(ctx, s.client, s.token, payload)
-	go s.deleteGlobalKeyAfterOneSec(ctx)
+	resp, err := slackrest.PostMessage(opCtx, s.client, s.token, payload)
+	go s.deleteGlobalKeyAfterOneSec(opCtx)
+	if err != nil && extra.Thread != nil && isThreadGone(err) {
+		// The parent message is deleted. Start a new thread instead.
+		extra.Thread = nil
+		extra.Broadcast = false
+		return s.postMessage(ctx, msg, extra)
+	}
 	if err != nil {
-		return msg.Tower().
+		return nil, msg.Tower().
 			Wrap(err).
 			Message("failed to post message to slack").
 			Context(tower.F{"payload_message": msg.Message()}).
-			Log(ctx)
+			Log(opCtx)
 	}
 	if len(attachments) > 0 {
-		s.uploadAttachments(ctx, msg, resp, attachments)
+		threadTS := resp.Ts
+		if payload.ThreadTS != "" {
+			threadTS = payload.ThreadTS
+		}
+		s.uploadAttachments(opCtx, msg, threadTS, attachments)
 	}
-	return nil
+	return resp, nil
 }
 
 func (s SlackBot) deleteGlobalKeyAfterOneSec(ctx context.Context) {
@@ -120,14 +174,18 @@ func (s SlackBot) buildKey(msg tower.MessageContext) string {
 	return builder.String()
 }
 
-func (s SlackBot) getAndSetIter(ctx context.Context, key string) int {
+// getIter returns the iteration of the last posted message of the key.
+func (s SlackBot) getIter(ctx context.Context, key string) int {
 	var iter int
-	iterByte, err := s.cache.Get(ctx, key)
-	if err == nil {
+	if iterByte, err := s.cache.Get(ctx, key); err == nil {
 		iter, _ = strconv.Atoi(string(iterByte))
 	}
-	iter += 1
-	iterByte = []byte(strconv.Itoa(iter))
+	return iter
+}
+
+func (s SlackBot) getAndSetIter(ctx context.Context, key string) int {
+	iter := s.getIter(ctx, key) + 1
+	iterByte := []byte(strconv.Itoa(iter))
 	nextCooldown := s.cooldown*time.Duration(iter) + s.cooldown
 	_ = s.cache.Set(ctx, key, iterByte, nextCooldown)
 	return iter
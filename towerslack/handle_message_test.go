@@ -0,0 +1,48 @@
+package towerslack
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/bucket"
+	"github.com/tigorlazuardi/tower/towerslack/block"
+)
+
+func TestSlackBot_postMessageValidatesBlocks(t *testing.T) {
+	bot, client := newTestBot()
+	bot.SetMessageTemplate(TemplateFunc(func(ctx context.Context, msg tower.MessageContext) (block.Blocks, []bucket.File) {
+		return block.Blocks{block.NewSectionBlockText(block.TextPlain, strings.Repeat("確", block.MaxTextLength+1))}, nil
+	}))
+	msg := newTestMessage(t, "invalid", tower.ErrorLevel)
+
+	_, err := bot.postMessage(context.Background(), msg, &ExtraInformation{})
+	if !errors.Is(err, block.ErrLimitExceeded) {
+		t.Errorf("expected limit exceeded error, got %v", err)
+	}
+	if n := len(client.Requests()); n != 0 {
+		t.Errorf("expected invalid message to not be sent, got %d requests", n)
+	}
+}
+
+func TestSlackBot_postMessageMultiByte(t *testing.T) {
+	bot, client := newTestBot()
+	bot.SetMessageTemplate(TemplateFunc(func(ctx context.Context, msg tower.MessageContext) (block.Blocks, []bucket.File) {
+		return block.Blocks{
+			block.NewHeaderBlock(strings.Repeat("確", block.MaxHeaderLength+10)),
+			block.NewSectionBlockText(block.TextPlain, strings.Repeat("確", block.MaxTextLength)),
+		}, nil
+	}))
+	msg := newTestMessage(t, "multi-byte", tower.ErrorLevel)
+
+	resp, err := bot.postMessage(context.Background(), msg, &ExtraInformation{})
+	if err != nil {
+		t.Fatalf("expected multi-byte message within the limits to be sent, got %v", err)
+	}
+	resp.Release()
+	if n := len(client.Requests()); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
@@ -0,0 +1,299 @@
+package towerslack
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/towerslack/block"
+)
+
+// Action IDs of the buttons in the default template.
+const (
+	ActionAcknowledge = "tower_acknowledge"
+	ActionMute1h      = "tower_mute_1h"
+	ActionMute24h     = "tower_mute_24h"
+	ActionResolve     = "tower_resolve"
+)
+
+// actionsBlockID is the block ID of the actions block in the default template. The block is replaced by the status of the
+// message when a user interacts with it.
+const actionsBlockID = "tower_actions"
+
+// maxInteractionBodySize is the maximum size of interaction request body that will be read.
+const maxInteractionBodySize = 1 << 20
+
+// signatureMaxAge is the maximum age of a request timestamp before the request is considered a replay.
+const signatureMaxAge = time.Minute * 5
+
+// maxMuteDuration is the longest duration a message can be muted for.
+const maxMuteDuration = time.Hour * 24
+
+// actionValueTTL is how long the buttons of a message keep working after the message is posted.
+const actionValueTTL = time.Hour * 24 * 7
+
+func (s SlackBot) muteKey(key string) string {
+	return key + s.cache.Separator() + "mute"
+}
+
+// isMuted checks if messages with the key are muted by a user.
+func (s SlackBot) isMuted(ctx context.Context, key string) bool {
+	return s.cache.Exist(ctx, s.muteKey(key))
+}
+
+func (s SlackBot) actionValueKey(value string) string {
+	sep := s.cache.Separator()
+	return s.Name() + sep + "action" + sep + value
+}
+
+// actionValue returns the button value for the cache key. Slack limits button values to 2000 characters, so the value
+// is a hash of the key, and the key is stored in the cache for the interaction handler to look up.
+func (s SlackBot) actionValue(ctx context.Context, key string) (string, error) {
+	sum := sha256.Sum256([]byte(key))
+	value := hex.EncodeToString(sum[:16])
+	if err := s.cache.Set(ctx, s.actionValueKey(value), []byte(key), actionValueTTL); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// buildActions builds the actions block for the message. Returns nil if the interaction handler is not configured.
+func (s SlackBot) buildActions(ctx context.Context, extra *ExtraInformation) *block.ActionsBlock {
+	if s.signingSecret == "" || extra.CacheKey == "" {
+		return nil
+	}
+	value, err := s.actionValue(ctx, extra.CacheKey)
+	if err != nil {
+		_ = tower.Wrap(err).
+			Message("%s: failed to set action value to cache", s.Name()).
+			Context(tower.F{"key": extra.CacheKey}).
+			Log(ctx)
+		return nil
+	}
+	ack := block.NewButtonElement(ActionAcknowledge, "Acknowledge", value)
+	ack.Style = block.ButtonPrimary
+	resolve := block.NewButtonElement(ActionResolve, "Resolve", value)
+	actions := block.NewActionsBlock(
+		ack,
+		block.NewButtonElement(ActionMute1h, "Mute 1h", value),
+		block.NewButtonElement(ActionMute24h, "Mute 24h", value),
+		resolve,
+	)
+	actions.BlockID = actionsBlockID
+	return actions
+}
+
+// InteractionHandler returns the http.Handler to be registered as the Request URL of Slack App's Interactivity.
+//
+// The handler verifies the request signature using the secret set by SetSigningSecret, and processes the buttons
+// of the default template:
+//
+//   - Acknowledge: marks the message as acknowledged by the user.
+//   - Mute 1h / Mute 24h: suppresses messages with the same key for the duration.
+//   - Resolve: clears cooldown, occurrences, mute, and thread records of the key, so the next message is posted as new.
+//
+// The original message is updated to show who interacted with it. The buttons stop working a week after the message
+// is posted.
+func (s *SlackBot) InteractionHandler() http.Handler {
+	return interactionHandler{bot: s}
+}
+
+type interactionHandler struct {
+	bot *SlackBot
+}
+
+type interactionUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+type interactionAction struct {
+	ActionID string `json:"action_id"`
+	BlockID  string `json:"block_id"`
+	Value    string `json:"value"`
+}
+
+type interactionPayload struct {
+	Type    string          `json:"type"`
+	User    interactionUser `json:"user"`
+	Channel struct {
+		ID string `json:"id"`
+	} `json:"channel"`
+	Message struct {
+		TS     string            `json:"ts"`
+		Text   string            `json:"text"`
+		Blocks []json.RawMessage `json:"blocks"`
+	} `json:"message"`
+	Actions     []interactionAction `json:"actions"`
+	ResponseURL string              `json:"response_url"`
+}
+
+func (h interactionHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxInteractionBodySize))
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err := h.bot.verifySignature(r.Header, body, time.Now()); err != nil {
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err := r.ParseForm(); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	payload := interactionPayload{}
+	if err := json.Unmarshal([]byte(r.PostForm.Get("payload")), &payload); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	// Slack expects acknowledgement within 3 seconds. The original message is updated after the response is sent.
+	rw.WriteHeader(http.StatusOK)
+	if payload.Type != "block_actions" {
+		return
+	}
+	ctx := tower.DetachedContext(r.Context())
+	go h.bot.handleInteraction(ctx, payload)
+}
+
+// verifySignature verifies the request is sent by Slack.
+//
+// See https://api.slack.com/authentication/verifying-requests-from-slack for details.
+func (s SlackBot) verifySignature(header http.Header, body []byte, now time.Time) error {
+	if s.signingSecret == "" {
+		return tower.BailFreeze("%s: signing secret is not set", s.Name())
+	}
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return tower.WrapFreeze(err, "%s: invalid request timestamp", s.Name())
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > signatureMaxAge || age < -signatureMaxAge {
+		return tower.BailFreeze("%s: request timestamp is too old", s.Name())
+	}
+	mac := hmac.New(sha256.New, []byte(s.signingSecret))
+	_, _ = fmt.Fprintf(mac, "v0:%s:", timestamp)
+	_, _ = mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature"))) {
+		return tower.BailFreeze("%s: request signature mismatch", s.Name())
+	}
+	return nil
+}
+
+func (s SlackBot) handleInteraction(ctx context.Context, payload interactionPayload) {
+	for _, action := range payload.Actions {
+		if action.Value == "" {
+			continue
+		}
+		switch action.ActionID {
+		case ActionAcknowledge, ActionMute1h, ActionMute24h, ActionResolve:
+		default:
+			continue
+		}
+		user := fmt.Sprintf("<@%s>", payload.User.ID)
+		b, err := s.cache.Get(ctx, s.actionValueKey(action.Value))
+		if err != nil {
+			s.updateInteractedMessage(ctx, payload, fmt.Sprintf(":warning: The buttons have expired. The action by %s is not applied", user))
+			continue
+		}
+		key := string(b)
+		var status string
+		switch action.ActionID {
+		case ActionAcknowledge:
+			status = fmt.Sprintf(":white_check_mark: Acknowledged by %s", user)
+		case ActionMute1h:
+			status = s.mute(ctx, key, user, time.Hour)
+		case ActionMute24h:
+			status = s.mute(ctx, key, user, maxMuteDuration)
+		case ActionResolve:
+			s.resolve(ctx, key)
+			status = fmt.Sprintf(":heavy_check_mark: Resolved by %s", user)
+		}
+		s.updateInteractedMessage(ctx, payload, status)
+	}
+}
+
+func (s SlackBot) mute(ctx context.Context, key, user string, dur time.Duration) string {
+	until := time.Now().Add(dur)
+	if err := s.cache.Set(ctx, s.muteKey(key), []byte(user), dur); err != nil {
+		_ = tower.Wrap(err).
+			Message("%s: failed to set mute state to cache", s.Name()).
+			Context(tower.F{"key": s.muteKey(key)}).
+			Log(ctx)
+	}
+	return fmt.Sprintf(":no_bell: Muted by %s until <!date^%d^{date_short_pretty} {time}|%s>", user, until.Unix(), until.Format(time.RubyDate))
+}
+
+func (s SlackBot) resolve(ctx context.Context, key string) {
+	sep := s.cache.Separator()
+	s.cache.Delete(ctx, key)
+	s.cache.Delete(ctx, key+sep+"iter")
+	s.cache.Delete(ctx, s.muteKey(key))
+	s.cache.Delete(ctx, s.threadKey(key))
+	s.cache.Delete(ctx, s.occurrenceCountKey(key))
+	s.cache.Delete(ctx, s.occurrenceSinceKey(key))
+}
+
+// updateInteractedMessage replaces the actions block of the original message with the status.
+func (s SlackBot) updateInteractedMessage(ctx context.Context, payload interactionPayload, status string) {
+	if payload.ResponseURL == "" {
+		return
+	}
+	blocks := make([]json.RawMessage, 0, len(payload.Message.Blocks)+1)
+	for _, b := range payload.Message.Blocks {
+		var meta struct {
+			BlockID string `json:"block_id"`
+		}
+		_ = json.Unmarshal(b, &meta)
+		if meta.BlockID == actionsBlockID {
+			continue
+		}
+		blocks = append(blocks, b)
+	}
+	statusBlock, _ := json.Marshal(map[string]any{
+		"type":     "context",
+		"elements": []map[string]any{{"type": "mrkdwn", "text": status}},
+	})
+	blocks = append(blocks, statusBlock)
+	body, _ := json.Marshal(map[string]any{
+		"replace_original": true,
+		"text":             payload.Message.Text,
+		"blocks":           blocks,
+	})
+
+	ctx, cancel := s.setOperationContext(ctx)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, payload.ResponseURL, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		_ = tower.Wrap(err).
+			Message("%s: failed to update interacted message", s.Name()).
+			Context(tower.F{"channel": payload.Channel.ID, "ts": payload.Message.TS}).
+			Log(ctx)
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		_ = tower.Bail("%s: failed to update interacted message", s.Name()).
+			Context(tower.F{"channel": payload.Channel.ID, "ts": payload.Message.TS, "status": resp.StatusCode}).
+			Log(ctx)
+	}
+}
@@ -0,0 +1,367 @@
+package towerslack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tigorlazuardi/tower/towerslack/block"
+)
+
+const testSigningSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+func signRequest(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "v0:%s:", timestamp)
+	_, _ = mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestSlackBot_verifySignature(t *testing.T) {
+	now := time.Unix(1531420618, 0)
+	body := []byte("payload=%7B%7D")
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	tests := []struct {
+		name    string
+		secret  string
+		header  http.Header
+		wantErr bool
+	}{
+		{
+			name:   "valid signature",
+			secret: testSigningSecret,
+			header: http.Header{
+				"X-Slack-Request-Timestamp": {timestamp},
+				"X-Slack-Signature":         {signRequest(testSigningSecret, timestamp, body)},
+			},
+		},
+		{
+			name:   "bad signature",
+			secret: testSigningSecret,
+			header: http.Header{
+				"X-Slack-Request-Timestamp": {timestamp},
+				"X-Slack-Signature":         {signRequest("other secret", timestamp, body)},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "missing signature header",
+			secret:  testSigningSecret,
+			header:  http.Header{"X-Slack-Request-Timestamp": {timestamp}},
+			wantErr: true,
+		},
+		{
+			name:    "missing timestamp header",
+			secret:  testSigningSecret,
+			header:  http.Header{"X-Slack-Signature": {signRequest(testSigningSecret, timestamp, body)}},
+			wantErr: true,
+		},
+		{
+			name:   "timestamp older than 5 minutes",
+			secret: testSigningSecret,
+			header: func() http.Header {
+				ts := strconv.FormatInt(now.Add(-signatureMaxAge-time.Second).Unix(), 10)
+				return http.Header{
+					"X-Slack-Request-Timestamp": {ts},
+					"X-Slack-Signature":         {signRequest(testSigningSecret, ts, body)},
+				}
+			}(),
+			wantErr: true,
+		},
+		{
+			name:   "timestamp newer than 5 minutes",
+			secret: testSigningSecret,
+			header: func() http.Header {
+				ts := strconv.FormatInt(now.Add(signatureMaxAge+time.Second).Unix(), 10)
+				return http.Header{
+					"X-Slack-Request-Timestamp": {ts},
+					"X-Slack-Signature":         {signRequest(testSigningSecret, ts, body)},
+				}
+			}(),
+			wantErr: true,
+		},
+		{
+			name:   "timestamp within 5 minutes",
+			secret: testSigningSecret,
+			header: func() http.Header {
+				ts := strconv.FormatInt(now.Add(-signatureMaxAge+time.Second).Unix(), 10)
+				return http.Header{
+					"X-Slack-Request-Timestamp": {ts},
+					"X-Slack-Signature":         {signRequest(testSigningSecret, ts, body)},
+				}
+			}(),
+		},
+		{
+			name:   "signing secret is not set",
+			secret: "",
+			header: http.Header{
+				"X-Slack-Request-Timestamp": {timestamp},
+				"X-Slack-Signature":         {signRequest("", timestamp, body)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSlackBot("", "")
+			s.SetSigningSecret(tt.secret)
+			err := s.verifySignature(tt.header, body, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifySignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// newInteractionRequest creates a signed interaction request of the action on the message with the button value.
+func newInteractionRequest(t *testing.T, actionID, value string) *http.Request {
+	t.Helper()
+	payload, _ := json.Marshal(map[string]any{
+		"type":    "block_actions",
+		"user":    map[string]any{"id": "U1", "username": "jane"},
+		"channel": map[string]any{"id": "C1"},
+		"message": map[string]any{
+			"ts":   "1000.0001",
+			"text": "foo",
+			"blocks": []map[string]any{
+				{"type": "section", "block_id": "summary", "text": map[string]any{"type": "mrkdwn", "text": "foo"}},
+				{"type": "actions", "block_id": actionsBlockID, "elements": []any{}},
+			},
+		},
+		"actions":      []map[string]any{{"action_id": actionID, "block_id": actionsBlockID, "value": value}},
+		"response_url": "https://hooks.slack.com/actions/T1/1/abc",
+	})
+	body := url.Values{"payload": {string(payload)}}.Encode()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/slack/interaction", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", signRequest(testSigningSecret, timestamp, []byte(body)))
+	return req
+}
+
+// resolvedKeys returns the cache keys of the message that are deleted by the resolve button, besides the cooldown key.
+func resolvedKeys(bot *SlackBot, key string) []string {
+	return []string{
+		key + bot.cache.Separator() + "iter",
+		bot.muteKey(key),
+		bot.threadKey(key),
+		bot.occurrenceCountKey(key),
+		bot.occurrenceSinceKey(key),
+	}
+}
+
+// waitRequests waits until the client receives n requests.
+func waitRequests(t *testing.T, client *fakeClient, n int) []slackRequest {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if requests := client.Requests(); len(requests) >= n {
+			return requests
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected %d requests, got %d", n, len(client.Requests()))
+	return nil
+}
+
+func TestSlackBot_InteractionHandler(t *testing.T) {
+	const key = "slack:test:test:test:interaction"
+	tests := []struct {
+		name     string
+		actionID string
+		status   string
+		check    func(t *testing.T, bot *SlackBot, recorder *ttlRecorder)
+	}{
+		{
+			name:     "acknowledge",
+			actionID: ActionAcknowledge,
+			status:   ":white_check_mark: Acknowledged by <@U1>",
+			check: func(t *testing.T, bot *SlackBot, recorder *ttlRecorder) {
+				if bot.isMuted(context.Background(), key) {
+					t.Error("expected acknowledge to not mute the message")
+				}
+				if !bot.cache.Exist(context.Background(), key) {
+					t.Error("expected acknowledge to keep the cooldown")
+				}
+			},
+		},
+		{
+			name:     "mute 1h",
+			actionID: ActionMute1h,
+			status:   ":no_bell: Muted by <@U1> until",
+			check: func(t *testing.T, bot *SlackBot, recorder *ttlRecorder) {
+				muteKey := bot.muteKey(key)
+				b, err := bot.cache.Get(context.Background(), muteKey)
+				if err != nil || string(b) != "<@U1>" {
+					t.Errorf("expected %s to be set to the user, got %q (%v)", muteKey, b, err)
+				}
+				if ttl := recorder.TTL(muteKey); ttl != time.Hour {
+					t.Errorf("expected mute ttl to be 1h, got %s", ttl)
+				}
+			},
+		},
+		{
+			name:     "mute 24h",
+			actionID: ActionMute24h,
+			status:   ":no_bell: Muted by <@U1> until",
+			check: func(t *testing.T, bot *SlackBot, recorder *ttlRecorder) {
+				muteKey := bot.muteKey(key)
+				if !bot.cache.Exist(context.Background(), muteKey) {
+					t.Errorf("expected %s to be set", muteKey)
+				}
+				if ttl := recorder.TTL(muteKey); ttl != time.Hour*24 {
+					t.Errorf("expected mute ttl to be 24h, got %s", ttl)
+				}
+			},
+		},
+		{
+			name:     "resolve",
+			actionID: ActionResolve,
+			status:   ":heavy_check_mark: Resolved by <@U1>",
+			check: func(t *testing.T, bot *SlackBot, recorder *ttlRecorder) {
+				for _, k := range append(resolvedKeys(bot, key), key) {
+					if bot.cache.Exist(context.Background(), k) {
+						t.Errorf("expected %s to be deleted", k)
+					}
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot, client := newTestBot()
+			recorder := newTTLRecorder()
+			bot.SetCache(recorder)
+			bot.SetSigningSecret(testSigningSecret)
+			ctx := context.Background()
+			if tt.actionID == ActionResolve {
+				for _, k := range resolvedKeys(bot, key) {
+					_ = bot.cache.Set(ctx, k, []byte("1"), time.Hour)
+				}
+			}
+			_ = bot.cache.Set(ctx, key, []byte("foo"), time.Hour)
+			value, err := bot.actionValue(ctx, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rec := httptest.NewRecorder()
+			bot.InteractionHandler().ServeHTTP(rec, newInteractionRequest(t, tt.actionID, value))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", rec.Code)
+			}
+			requests := waitRequests(t, client, 1)
+			req := requests[0]
+			if req.URL != "https://hooks.slack.com/actions/T1/1/abc" {
+				t.Errorf("expected response_url to be called, got %s", req.URL)
+			}
+			var got struct {
+				ReplaceOriginal bool `json:"replace_original"`
+				Blocks          []struct {
+					Type     string `json:"type"`
+					BlockID  string `json:"block_id"`
+					Elements []struct {
+						Text string `json:"text"`
+					} `json:"elements"`
+				} `json:"blocks"`
+			}
+			if err := json.Unmarshal(req.Body, &got); err != nil {
+				t.Fatalf("failed to decode response_url body %s: %v", req.Body, err)
+			}
+			if !got.ReplaceOriginal {
+				t.Error("expected original message to be replaced")
+			}
+			if len(got.Blocks) != 2 || got.Blocks[0].BlockID != "summary" || got.Blocks[1].Type != "context" {
+				t.Fatalf("expected actions block to be replaced by the status, got %s", req.Body)
+			}
+			if status := got.Blocks[1].Elements[0].Text; !strings.HasPrefix(status, tt.status) {
+				t.Errorf("expected status %q, got %q", tt.status, status)
+			}
+			tt.check(t, bot, recorder)
+		})
+	}
+}
+
+func TestSlackBot_InteractionHandlerRejects(t *testing.T) {
+	bot, client := newTestBot()
+	bot.SetSigningSecret(testSigningSecret)
+	handler := bot.InteractionHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slack/interaction", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405 for GET, got %d", rec.Code)
+	}
+
+	req := newInteractionRequest(t, ActionMute1h, "key")
+	req.Header.Set("X-Slack-Signature", "v0=bad")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 for bad signature, got %d", rec.Code)
+	}
+
+	req = newInteractionRequest(t, ActionMute1h, "key")
+	req.Header.Del("X-Slack-Request-Timestamp")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 for missing timestamp, got %d", rec.Code)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if bot.isMuted(context.Background(), "key") {
+		t.Error("expected rejected requests to not mute the message")
+	}
+	if n := len(client.Requests()); n != 0 {
+		t.Errorf("expected rejected requests to not call response_url, got %d requests", n)
+	}
+}
+
+func TestSlackBot_buildActionsLongKey(t *testing.T) {
+	bot, _ := newTestBot()
+	bot.SetSigningSecret(testSigningSecret)
+	ctx := context.Background()
+	key := strings.Repeat("k", block.MaxButtonValueLength+1)
+
+	actions := bot.buildActions(ctx, &ExtraInformation{CacheKey: key})
+	if actions == nil {
+		t.Fatal("expected the actions block to be built")
+	}
+	if err := actions.Validate(); err != nil {
+		t.Fatalf("expected the actions block to be valid, got %v", err)
+	}
+	value, _ := bot.actionValue(ctx, key)
+	if b, err := bot.cache.Get(ctx, bot.actionValueKey(value)); err != nil || string(b) != key {
+		t.Errorf("expected the button value to resolve to the key, got %q (%v)", b, err)
+	}
+}
+
+func TestSlackBot_InteractionHandlerExpiredValue(t *testing.T) {
+	bot, client := newTestBot()
+	bot.SetSigningSecret(testSigningSecret)
+
+	rec := httptest.NewRecorder()
+	bot.InteractionHandler().ServeHTTP(rec, newInteractionRequest(t, ActionMute1h, "unknown"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	requests := waitRequests(t, client, 1)
+	if !strings.Contains(string(requests[0].Body), "The buttons have expired") {
+		t.Errorf("expected the expired status, got %s", requests[0].Body)
+	}
+	if bot.isMuted(context.Background(), "unknown") {
+		t.Error("expected expired buttons to not mute the message")
+	}
+}
@@ -0,0 +1,77 @@
+package towerslack
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/cache"
+)
+
+// occurrenceTTL returns the lifetime of occurrence records of a message with the given cooldown.
+//
+// Occurrences are counted while the message is in cooldown and read when the next message is posted after the cooldown
+// ends. The expiry of the counter is refreshed on every count, so twice the cooldown keeps the records alive until the
+// next message.
+func occurrenceTTL(cooldown time.Duration) time.Duration {
+	return cooldown * 2
+}
+
+func (s SlackBot) occurrenceCountKey(key string) string {
+	return key + s.cache.Separator() + "occurrences"
+}
+
+func (s SlackBot) occurrenceSinceKey(key string) string {
+	return key + s.cache.Separator() + "since"
+}
+
+// countOccurrence records a message that is suppressed for the given duration.
+func (s SlackBot) countOccurrence(ctx context.Context, msg tower.MessageContext, key string, suppressed time.Duration) {
+	countKey := s.occurrenceCountKey(key)
+	if _, err := cache.Increment(ctx, s.cache, countKey, 1, occurrenceTTL(suppressed)); err != nil {
+		_ = msg.Tower().
+			Wrap(err).
+			Message("%s: failed to increment message occurrences in cache", s.Name()).
+			Context(tower.F{"key": countKey}).
+			Log(ctx)
+	}
+}
+
+// getOccurrence fills the occurrences information of the key to extra.
+func (s SlackBot) getOccurrence(ctx context.Context, key string, extra *ExtraInformation) {
+	if b, err := s.cache.Get(ctx, s.occurrenceCountKey(key)); err == nil {
+		extra.Occurrences, _ = strconv.ParseInt(string(b), 10, 64)
+	}
+	if b, err := s.cache.Get(ctx, s.occurrenceSinceKey(key)); err == nil {
+		extra.OccurrencesSince, _ = time.Parse(time.RFC3339Nano, string(b))
+	}
+}
+
+// resetOccurrence subtracts the reported occurrences from the counter and marks the current time as the starting point
+// of the next occurrences.
+//
+// The counter is decremented instead of deleted, so occurrences counted by other instances while the message is being
+// posted are not lost.
+func (s SlackBot) resetOccurrence(ctx context.Context, msg tower.MessageContext, key string, extra *ExtraInformation) {
+	ttl := occurrenceTTL(time.Until(extra.CooldownTimeEnds))
+	if extra.Occurrences > 0 {
+		countKey := s.occurrenceCountKey(key)
+		if _, err := cache.Increment(ctx, s.cache, countKey, -extra.Occurrences, ttl); err != nil {
+			_ = msg.Tower().
+				Wrap(err).
+				Message("%s: failed to reset message occurrences in cache", s.Name()).
+				Context(tower.F{"key": countKey}).
+				Log(ctx)
+		}
+	}
+	sinceKey := s.occurrenceSinceKey(key)
+	since := []byte(time.Now().Format(time.RFC3339Nano))
+	if err := s.cache.Set(ctx, sinceKey, since, ttl); err != nil {
+		_ = msg.Tower().
+			Wrap(err).
+			Message("%s: failed to set message occurrences time to cache", s.Name()).
+			Context(tower.F{"key": sinceKey}).
+			Log(ctx)
+	}
+}
@@ -0,0 +1,125 @@
+package towerslack
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/bucket"
+	"github.com/tigorlazuardi/tower/towerslack/block"
+)
+
+func TestSlackBot_occurrence(t *testing.T) {
+	ctx := context.Background()
+	msg := newTestMessage(t, "occurrence", tower.ErrorLevel)
+	s := NewSlackBot("", "")
+	key := s.buildKey(msg)
+
+	extra := &ExtraInformation{CooldownTimeEnds: time.Now().Add(time.Minute)}
+	s.getOccurrence(ctx, key, extra)
+	if extra.Occurrences != 0 {
+		t.Fatalf("expected occurrences to be 0, got %d", extra.Occurrences)
+	}
+	if !extra.OccurrencesSince.IsZero() {
+		t.Fatalf("expected occurrences since to be zero, got %s", extra.OccurrencesSince)
+	}
+
+	before := time.Now()
+	s.resetOccurrence(ctx, msg, key, extra)
+	for i := 0; i < 3; i++ {
+		s.countOccurrence(ctx, msg, key, time.Minute)
+	}
+	s.getOccurrence(ctx, key, extra)
+	if extra.Occurrences != 3 {
+		t.Fatalf("expected occurrences to be 3, got %d", extra.Occurrences)
+	}
+	if extra.OccurrencesSince.Before(before) {
+		t.Fatalf("expected occurrences since to be after %s, got %s", before, extra.OccurrencesSince)
+	}
+
+	// occurrence that happens while the message is being posted must not be lost.
+	s.countOccurrence(ctx, msg, key, time.Minute)
+	s.resetOccurrence(ctx, msg, key, extra)
+	extra = &ExtraInformation{}
+	s.getOccurrence(ctx, key, extra)
+	if extra.Occurrences != 1 {
+		t.Fatalf("expected occurrences to be 1, got %d", extra.Occurrences)
+	}
+}
+
+func TestSlackBot_occurrenceTTL(t *testing.T) {
+	bot, _ := newTestBot()
+	recorder := newTTLRecorder()
+	bot.SetCache(recorder)
+	// Longer than the maximum cooldown the occurrence records used to have.
+	bot.SetBaseCooldown(time.Hour * 30)
+	msg := newTestMessage(t, "ttl", tower.ErrorLevel)
+	key := bot.buildKey(msg)
+
+	handle(bot, msg)
+	if ttl := recorder.TTL(bot.occurrenceSinceKey(key)); ttl < time.Hour*30 {
+		t.Errorf("expected occurrences since ttl to outlive the cooldown, got %s", ttl)
+	}
+	handle(bot, msg)
+	if ttl := recorder.TTL(bot.occurrenceCountKey(key)); ttl < time.Hour*30 {
+		t.Errorf("expected occurrences count ttl to outlive the cooldown, got %s", ttl)
+	}
+
+	bot.mute(context.Background(), key, "<@U1>", maxMuteDuration)
+	bot.SetBaseCooldown(time.Minute)
+	handle(bot, msg)
+	if ttl := recorder.TTL(bot.occurrenceCountKey(key)); ttl < maxMuteDuration {
+		t.Errorf("expected occurrences count ttl to outlive the mute, got %s", ttl)
+	}
+}
+
+func TestSlackBot_handleMessageOccurrences(t *testing.T) {
+	bot, client := newTestBot()
+	var got []*ExtraInformation
+	bot.SetMessageTemplate(TemplateFunc(func(ctx context.Context, msg tower.MessageContext) (block.Blocks, []bucket.File) {
+		extra := *ExtraInformationFromContext(ctx)
+		got = append(got, &extra)
+		return bot.defaultTemplate(ctx, msg)
+	}))
+	msg := newTestMessage(t, "handle-occurrence", tower.ErrorLevel)
+	key := bot.buildKey(msg)
+
+	handle(bot, msg)
+	handle(bot, msg)
+	handle(bot, msg)
+	if len(client.Requests()) != 1 {
+		t.Fatalf("expected messages in cooldown to be suppressed, got %d requests", len(client.Requests()))
+	}
+
+	// cooldown ends.
+	bot.cache.Delete(context.Background(), key)
+	handle(bot, msg)
+	requests := client.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected message to be posted after cooldown, got %d requests", len(requests))
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected template to be built twice, got %d", len(got))
+	}
+	if got[0].Occurrences != 0 || got[0].CacheKey != key {
+		t.Errorf("unexpected extra information of the first message: %+v", got[0])
+	}
+	if got[1].Occurrences != 2 || got[1].OccurrencesSince.IsZero() {
+		t.Errorf("expected 2 occurrences since the first message, got %+v", got[1])
+	}
+	if !bytes.Contains(requests[1].Body, []byte("occurred 2 times since")) {
+		t.Errorf("expected occurrences in the posted message, got %s", requests[1].Body)
+	}
+}
+
+func TestExtraInformationFromContext(t *testing.T) {
+	if extra := ExtraInformationFromContext(context.Background()); extra == nil || extra.Occurrences != 0 {
+		t.Fatalf("expected empty extra information, got %+v", extra)
+	}
+	ctx := contextWithExtraInformation(context.Background(), &ExtraInformation{Occurrences: 4})
+	if extra := ExtraInformationFromContext(ctx); extra.Occurrences != 4 {
+		t.Fatalf("expected 4 occurrences, got %d", extra.Occurrences)
+	}
+}
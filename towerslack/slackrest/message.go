@@ -70,6 +70,7 @@ func (m *MessagePayload) Reset() {
 }
 
 func (m MessagePayload) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKeyOmitEmpty("channel", m.Channel)
 	enc.AddStringKeyOmitEmpty("text", m.Text)
 	enc.AddArrayKeyOmitEmpty("blocks", m.Blocks)
 	enc.AddArrayKeyOmitEmpty("attachments", gojay.EncodeArrayFunc(func(e *gojay.Encoder) {
@@ -81,7 +82,9 @@ func (m MessagePayload) MarshalJSONObject(enc *gojay.Encoder) {
 	enc.AddStringKeyOmitEmpty("icon_emoji", m.IconEmoji)
 	enc.AddStringKeyOmitEmpty("icon_url", m.IconURL)
 	enc.AddBoolKeyOmitEmpty("link_names", m.LinkNames)
-	enc.AddArrayKeyOmitEmpty("metadata", m.Metadata)
+	if m.Metadata != nil {
+		enc.AddArrayKeyOmitEmpty("metadata", m.Metadata)
+	}
 	enc.AddBoolKeyOmitEmpty("mrkdwn", m.Mrkdwn)
 	enc.AddStringKeyOmitEmpty("parse", string(m.Parse))
 	enc.AddBoolKeyOmitEmpty("reply_broadcast", m.ReplyBroadcast)
@@ -104,6 +107,8 @@ type MessageResponse struct {
 
 func (i *MessageResponse) Release() {
 	i.Reset()
+	// The generated Reset does not reset nested objects.
+	i.Message.Reset()
 	MessageResponsePool.Put(i)
 }
 
@@ -118,13 +123,59 @@ type MessageResponseItem struct {
 }
 
 // PostMessage Posts Message to Slack. This is not for messages with file attachments.
+//
+// Call Release on the returned response once it's no longer used.
 func PostMessage(ctx context.Context, client Client, token string, payload *MessagePayload) (resp *MessageResponse, err error) {
+	return sendMessage(ctx, client, token, "https://slack.com/api/chat.postMessage", payload)
+}
+
+// UpdateMessagePayload is the payload to update existing message.
+//
+// See https://api.slack.com/methods/chat.update for details.
+type UpdateMessagePayload struct {
+	Channel        string                      `json:"channel"`
+	TS             string                      `json:"ts"`
+	Text           string                      `json:"text"`
+	Blocks         block.Blocks                `json:"blocks"`
+	Attachments    []gojay.MarshalerJSONObject `json:"attachments"`
+	LinkNames      bool                        `json:"link_names"`
+	Parse          MessageParseOption          `json:"parse"`
+	ReplyBroadcast bool                        `json:"reply_broadcast"`
+}
+
+func (m UpdateMessagePayload) MarshalJSONObject(enc *gojay.Encoder) {
+	enc.AddStringKey("channel", m.Channel)
+	enc.AddStringKey("ts", m.TS)
+	enc.AddStringKeyOmitEmpty("text", m.Text)
+	enc.AddArrayKeyOmitEmpty("blocks", m.Blocks)
+	enc.AddArrayKeyOmitEmpty("attachments", gojay.EncodeArrayFunc(func(e *gojay.Encoder) {
+		for _, v := range m.Attachments {
+			e.AddObject(v)
+		}
+	}))
+	enc.AddBoolKeyOmitEmpty("link_names", m.LinkNames)
+	enc.AddStringKeyOmitEmpty("parse", string(m.Parse))
+	enc.AddBoolKeyOmitEmpty("reply_broadcast", m.ReplyBroadcast)
+}
+
+func (m UpdateMessagePayload) IsNil() bool {
+	return len(m.Text) == 0 && len(m.Blocks) == 0 && len(m.Attachments) == 0
+}
+
+// UpdateMessage updates existing message in Slack.
+//
+// Call Release on the returned response once it's no longer used.
+func UpdateMessage(ctx context.Context, client Client, token string, payload *UpdateMessagePayload) (resp *MessageResponse, err error) {
+	return sendMessage(ctx, client, token, "https://slack.com/api/chat.update", payload)
+}
+
+func sendMessage(ctx context.Context, client Client, token, url string, payload gojay.MarshalerJSONObject) (resp *MessageResponse, err error) {
 	buf := &bytes.Buffer{}
 	enc := gojay.BorrowEncoder(buf)
 	defer enc.Release()
 	_ = enc.EncodeObject(payload)
 
-	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "https://slack.com/api/chat.postMessage", buf)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, url, buf)
 	req.Header.Add("Authorization", "Bearer "+token)
 	req.Header.Add("Content-Type", "application/json; charset=utf-8")
 
@@ -138,15 +189,25 @@ func PostMessage(ctx context.Context, client Client, token string, payload *Mess
 			_ = tower.WrapFreeze(err, "failed to close response body").Log(ctx)
 		}
 	}(res.Body)
-	if res.StatusCode >= 400 {
-		errResp := &ErrorResponse{}
-		err = gojay.NewDecoder(res.Body).DecodeObject(errResp)
-		if err != nil {
-			return resp, fmt.Errorf("failed to unmarshal json response body from slack: %w", err)
-		}
-		return resp, errResp
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return resp, fmt.Errorf("failed to read response body from slack: %w", err)
 	}
+	// Slack reports most errors with status 200 and "ok" set to false.
 	resp = MessageResponsePool.Get().(*MessageResponse) //nolint
-
-	return
+	resp.Reset()
+	err = gojay.UnmarshalJSONObject(body, resp)
+	if err == nil && res.StatusCode < 400 && resp.Ok {
+		return resp, nil
+	}
+	resp.Release()
+	resp = nil
+	errResp := &ErrorResponse{}
+	if err := gojay.UnmarshalJSONObject(body, errResp); err != nil {
+		return resp, fmt.Errorf("failed to unmarshal json response body from slack: %w", err)
+	}
+	if errResp.Err == "" {
+		errResp.Err = http.StatusText(res.StatusCode)
+	}
+	return resp, errResp
 }
@@ -23,9 +23,47 @@ type TemplateBuilder interface {
 	// If you have no attachments to upload, a simple nil return on the attachments is safe.
 	//
 	// Note: The blocks are required and must not be nil (returning empty blocks are safe however), regardless of attachments.
+	//
+	// Use ExtraInformationFromContext to get the occurrences and thread information of the message.
 	BuildTemplate(ctx context.Context, msg tower.MessageContext) (blocks block.Blocks, attachments []bucket.File)
 }
 
+// ExtraInformation holds the information SlackBot gathered about the message before it is posted.
+type ExtraInformation struct {
+	Iteration        int
+	CooldownTimeEnds time.Time
+	CacheKey         string
+	// Occurrences is the number of times messages with the same key were suppressed by cooldown since the previous
+	// message was posted.
+	Occurrences int64
+	// OccurrencesSince is the time when the previous message with the same key was posted.
+	// Zero value if there is no record of previous message.
+	OccurrencesSince time.Time
+	// Thread is the reference to the first message posted for the key. Nil if thread replies are disabled or the
+	// message is the first of its kind.
+	Thread *ThreadReference
+	// Broadcast is true if the thread reply is also sent to the channel because the level escalated.
+	Broadcast bool
+	// UpdatingParent is true when the template is built to update the parent message of the thread.
+	UpdatingParent bool
+}
+
+var extraInformationKey = struct{ key int }{1}
+
+func contextWithExtraInformation(ctx context.Context, extra *ExtraInformation) context.Context {
+	return context.WithValue(ctx, extraInformationKey, extra)
+}
+
+// ExtraInformationFromContext returns the ExtraInformation of the message the template is built for.
+//
+// Returns empty ExtraInformation if the context is not given by SlackBot.
+func ExtraInformationFromContext(ctx context.Context) *ExtraInformation {
+	if extra, ok := ctx.Value(extraInformationKey).(*ExtraInformation); ok && extra != nil {
+		return extra
+	}
+	return &ExtraInformation{}
+}
+
 var _ TemplateBuilder = (TemplateFunc)(nil)
 
 type TemplateFunc func(ctx context.Context, msg tower.MessageContext) (block.Blocks, []bucket.File)
@@ -36,6 +74,7 @@ func (f TemplateFunc) BuildTemplate(ctx context.Context, msg tower.MessageContex
 }
 
 func (s SlackBot) defaultTemplate(ctx context.Context, msg tower.MessageContext) (block.Blocks, []bucket.File) {
+	extra := ExtraInformationFromContext(ctx)
 	blocks := make(block.Blocks, 0, 6)
 	attachments := make([]bucket.File, 0, 5)
 	blocks = append(blocks, buildHeadline(msg))
@@ -44,6 +83,15 @@ func (s SlackBot) defaultTemplate(ctx context.Context, msg tower.MessageContext)
 	blocks = append(blocks, block.NewHeaderBlock("Summary"))
 	blocks = append(blocks, buildSummary(msg))
 	blocks = append(blocks, s.buildMetadata(ctx, msg))
+	if extra.Occurrences > 0 {
+		blocks = append(blocks, buildOccurrences(extra))
+	}
+	if extra.Thread != nil {
+		blocks = append(blocks, buildThreadOccurrences(extra))
+	}
+	if actions := s.buildActions(ctx, extra); actions != nil {
+		blocks = append(blocks, actions)
+	}
 
 	return blocks, attachments
 }
@@ -124,3 +172,26 @@ func (s SlackBot) buildMetadata(ctx context.Context, msg tower.MessageContext) *
 
 	return block.NewSectionBlockFields(block.TextMrkdwn, texts...)
 }
+
+func buildOccurrences(extra *ExtraInformation) *block.ContextBlock {
+	text := fmt.Sprintf("occurred %d times", extra.Occurrences)
+	if extra.Occurrences == 1 {
+		text = "occurred 1 time"
+	}
+	if !extra.OccurrencesSince.IsZero() {
+		since := extra.OccurrencesSince
+		text += fmt.Sprintf(" since <!date^%d^{date_short_pretty} {time_secs}|%s>", since.Unix(), since.Format(time.RubyDate))
+	}
+	return block.NewContextBlock(block.NewTextComposition(block.TextMrkdwn, text))
+}
+
+func buildThreadOccurrences(extra *ExtraInformation) *block.ContextBlock {
+	first := extra.Thread.FirstSeen
+	text := fmt.Sprintf("occurred %d times since <!date^%d^{date_short_pretty} {time_secs}|%s>",
+		extra.Thread.Count, first.Unix(), first.Format(time.RubyDate))
+	if extra.UpdatingParent {
+		now := time.Now()
+		text += fmt.Sprintf(". Last updated <!date^%d^{date_short_pretty} {time_secs}|%s>. See thread for the latest details", now.Unix(), now.Format(time.RubyDate))
+	}
+	return block.NewContextBlock(block.NewTextComposition(block.TextMrkdwn, text))
+}
@@ -0,0 +1,116 @@
+package towerslack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/towerslack/slackrest"
+)
+
+// ThreadReference is the record of the first message posted for a key. Repeated messages with the same key are posted
+// as replies to this message.
+type ThreadReference struct {
+	// Channel is the channel ID where the parent message lives.
+	Channel string `json:"channel"`
+	// TS is the timestamp of the parent message. Slack uses this as the thread ID.
+	TS string `json:"ts"`
+	// Level is the highest level of messages posted in the thread.
+	Level tower.Level `json:"level"`
+	// Count is the number of times the message has occurred, including the ones suppressed by cooldown.
+	Count int64 `json:"count"`
+	// FirstSeen is the time the parent message was posted.
+	FirstSeen time.Time `json:"first_seen"`
+}
+
+// threadTTL is the lifetime of thread references since the last reply.
+const threadTTL = time.Hour * 24
+
+func (s SlackBot) threadKey(key string) string {
+	return key + s.cache.Separator() + "thread"
+}
+
+// getThreadReference returns the thread reference of the key. Returns nil if thread replies are disabled or there is no
+// record of the key.
+func (s SlackBot) getThreadReference(ctx context.Context, key string) *ThreadReference {
+	if !s.replyInThread {
+		return nil
+	}
+	b, err := s.cache.Get(ctx, s.threadKey(key))
+	if err != nil {
+		return nil
+	}
+	ref := &ThreadReference{}
+	if err := json.Unmarshal(b, ref); err != nil || ref.TS == "" {
+		return nil
+	}
+	return ref
+}
+
+// saveThreadReference stores the thread reference of the key. If the message is not posted to a thread, the posted
+// message becomes the parent of the following messages.
+func (s SlackBot) saveThreadReference(ctx context.Context, msg tower.MessageContext, key string, extra *ExtraInformation, resp *slackrest.MessageResponse) {
+	if !s.replyInThread {
+		return
+	}
+	ref := extra.Thread
+	if ref == nil {
+		ref = &ThreadReference{
+			Channel:   resp.Channel,
+			TS:        resp.Ts,
+			Level:     msg.Level(),
+			Count:     1 + extra.Occurrences,
+			FirstSeen: time.Now(),
+		}
+	}
+	b, _ := json.Marshal(ref)
+	threadKey := s.threadKey(key)
+	if err := s.cache.Set(ctx, threadKey, b, threadTTL); err != nil {
+		_ = msg.Tower().
+			Wrap(err).
+			Message("%s: failed to set thread reference to cache", s.Name()).
+			Context(tower.F{"key": threadKey, "payload": ref}).
+			Log(ctx)
+	}
+}
+
+// isThreadGone checks if the error is caused by the parent message no longer exists.
+func isThreadGone(err error) bool {
+	var errResp *slackrest.ErrorResponse
+	if !errors.As(err, &errResp) {
+		return false
+	}
+	switch errResp.Err {
+	case "thread_not_found", "message_not_found", "invalid_thread_ts":
+		return true
+	}
+	return false
+}
+
+// updateParentMessage replaces the content of the parent message with the latest message, so the occurrence count is
+// visible from the channel without opening the thread.
+func (s SlackBot) updateParentMessage(ctx context.Context, msg tower.MessageContext, extra *ExtraInformation) {
+	payload := &slackrest.UpdateMessagePayload{
+		Channel: extra.Thread.Channel,
+		TS:      extra.Thread.TS,
+		Text:    msg.Message(),
+	}
+	parentExtra := *extra
+	parentExtra.UpdatingParent = true
+	blocks, _ := s.template.BuildTemplate(contextWithExtraInformation(ctx, &parentExtra), msg)
+	payload.Blocks = blocks
+	ctx, cancel := s.setOperationContext(ctx)
+	defer cancel()
+	resp, err := slackrest.UpdateMessage(ctx, s.client, s.token, payload)
+	if err != nil {
+		_ = msg.Tower().
+			Wrap(err).
+			Message("%s: failed to update parent message", s.Name()).
+			Context(tower.F{"channel": payload.Channel, "ts": payload.TS}).
+			Log(ctx)
+		return
+	}
+	resp.Release()
+}
@@ -0,0 +1,177 @@
+package towerslack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tigorlazuardi/tower"
+)
+
+type postedMessage struct {
+	Channel        string `json:"channel"`
+	TS             string `json:"ts"`
+	ThreadTS       string `json:"thread_ts"`
+	ReplyBroadcast bool   `json:"reply_broadcast"`
+	Blocks         []struct {
+		Elements []struct {
+			Text string `json:"text"`
+		} `json:"elements"`
+	} `json:"blocks"`
+}
+
+func decodePosted(t *testing.T, req slackRequest) postedMessage {
+	t.Helper()
+	var m postedMessage
+	if err := json.Unmarshal(req.Body, &m); err != nil {
+		t.Fatalf("failed to decode request body %s: %v", req.Body, err)
+	}
+	return m
+}
+
+// expireCooldown removes the cooldown of the message, so the next message with the same key is posted.
+func expireCooldown(bot *SlackBot, msg tower.MessageContext) {
+	bot.cache.Delete(context.Background(), bot.buildKey(msg))
+}
+
+func TestSlackBot_replyInThread(t *testing.T) {
+	bot, client := newTestBot()
+	bot.SetReplyInThread(true)
+	warn := newTestMessage(t, "thread", tower.WarnLevel)
+	errMsg := newTestMessage(t, "thread", tower.ErrorLevel)
+
+	handle(bot, warn)
+	expireCooldown(bot, warn)
+	handle(bot, warn)
+	expireCooldown(bot, warn)
+	handle(bot, errMsg)
+	expireCooldown(bot, errMsg)
+	handle(bot, errMsg)
+	expireCooldown(bot, errMsg)
+	handle(bot, warn)
+
+	requests := client.Requests()
+	if len(requests) != 5 {
+		t.Fatalf("expected 5 requests, got %d", len(requests))
+	}
+	tests := []struct {
+		name      string
+		thread    string
+		broadcast bool
+	}{
+		{name: "parent message", thread: "", broadcast: false},
+		{name: "reply with the same level", thread: "1000.0001", broadcast: false},
+		{name: "reply with escalated level", thread: "1000.0001", broadcast: true},
+		{name: "reply with the escalated level again", thread: "1000.0001", broadcast: false},
+		{name: "reply with lower level", thread: "1000.0001", broadcast: false},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := requests[i]
+			if !strings.HasSuffix(req.URL, "/chat.postMessage") {
+				t.Errorf("expected chat.postMessage, got %s", req.URL)
+			}
+			m := decodePosted(t, req)
+			if m.ThreadTS != tt.thread {
+				t.Errorf("expected thread_ts %q, got %q", tt.thread, m.ThreadTS)
+			}
+			if m.ReplyBroadcast != tt.broadcast {
+				t.Errorf("expected reply_broadcast %t, got %t", tt.broadcast, m.ReplyBroadcast)
+			}
+		})
+	}
+
+	ref := bot.getThreadReference(context.Background(), bot.buildKey(warn))
+	if ref == nil {
+		t.Fatal("expected thread reference to be saved")
+	}
+	if ref.Count != 5 || ref.Level != tower.ErrorLevel || ref.TS != "1000.0001" || ref.Channel != "C1" {
+		t.Errorf("unexpected thread reference: %+v", ref)
+	}
+}
+
+func TestSlackBot_updateParentMessage(t *testing.T) {
+	bot, client := newTestBot()
+	bot.SetReplyInThread(true)
+	bot.SetUpdateParentMessage(true)
+	msg := newTestMessage(t, "update-parent", tower.ErrorLevel)
+
+	handle(bot, msg)
+	expireCooldown(bot, msg)
+	handle(bot, msg)
+
+	requests := client.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("expected parent, reply, and update requests, got %d", len(requests))
+	}
+	update := requests[2]
+	if !strings.HasSuffix(update.URL, "/chat.update") {
+		t.Fatalf("expected chat.update, got %s", update.URL)
+	}
+	m := decodePosted(t, update)
+	if m.Channel != "C1" || m.TS != "1000.0001" {
+		t.Errorf("expected parent message to be updated, got channel %q ts %q", m.Channel, m.TS)
+	}
+	if !strings.Contains(string(update.Body), "occurred 2 times since") || !strings.Contains(string(update.Body), "See thread for the latest details") {
+		t.Errorf("expected occurrence count in the updated parent, got %s", update.Body)
+	}
+}
+
+func TestSlackBot_replyInThreadGone(t *testing.T) {
+	bot, client := newTestBot()
+	bot.SetReplyInThread(true)
+	client.respond = func(req *http.Request) (int, string) {
+		if len(client.Requests()) == 2 {
+			return http.StatusOK, `{"ok":false,"error":"thread_not_found"}`
+		}
+		return http.StatusOK, `{"ok":true,"channel":"C1","ts":"2000.0001"}`
+	}
+	msg := newTestMessage(t, "thread-gone", tower.ErrorLevel)
+
+	handle(bot, msg)
+	expireCooldown(bot, msg)
+	handle(bot, msg)
+
+	requests := client.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("expected the reply to be reposted as new message, got %d requests", len(requests))
+	}
+	if m := decodePosted(t, requests[1]); m.ThreadTS != "2000.0001" {
+		t.Errorf("expected reply to the thread, got thread_ts %q", m.ThreadTS)
+	}
+	if m := decodePosted(t, requests[2]); m.ThreadTS != "" {
+		t.Errorf("expected new message outside of the thread, got thread_ts %q", m.ThreadTS)
+	}
+}
+
+func TestSlackBot_replyInThreadDisabled(t *testing.T) {
+	bot, client := newTestBot()
+	msg := newTestMessage(t, "no-thread", tower.ErrorLevel)
+
+	handle(bot, msg)
+	expireCooldown(bot, msg)
+	handle(bot, msg)
+
+	for _, req := range client.Requests() {
+		if m := decodePosted(t, req); m.ThreadTS != "" {
+			t.Errorf("expected no thread replies when disabled, got thread_ts %q", m.ThreadTS)
+		}
+	}
+}
+
+func TestIsThreadGone(t *testing.T) {
+	bot, client := newTestBot()
+	client.respond = func(*http.Request) (int, string) {
+		return http.StatusOK, `{"ok":false,"error":"message_not_found"}`
+	}
+	msg := newTestMessage(t, "gone", tower.ErrorLevel)
+	_, err := bot.postMessage(context.Background(), msg, &ExtraInformation{})
+	if !isThreadGone(err) {
+		t.Errorf("expected message_not_found to be detected, got %v", err)
+	}
+	if isThreadGone(tower.BailFreeze("thread_not_found")) {
+		t.Error("expected non slack errors to be ignored")
+	}
+}
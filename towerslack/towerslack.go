@@ -40,6 +40,9 @@ type SlackBot struct {
 	globalKey     string
 	globalFileKey string
 	cooldown      time.Duration
+	replyInThread bool
+	updateParent  bool
+	signingSecret string
 }
 
 // SetBucket sets the bucket to upload files for the slackbot. If not set, upload files to slack instead.
@@ -127,6 +130,28 @@ func (s *SlackBot) SetBaseCooldown(cooldown time.Duration) {
 	s.cooldown = cooldown
 }
 
+// SetReplyInThread when enabled, repeated messages with the same key are posted as replies in the thread of the first
+// message instead of as new messages in the channel. Replies are broadcast to the channel only when the level of the
+// message is higher than every message posted in the thread before.
+//
+// The thread reference is stored in the cache for 24 hours since the last reply.
+func (s *SlackBot) SetReplyInThread(enabled bool) {
+	s.replyInThread = enabled
+}
+
+// SetUpdateParentMessage when enabled alongside SetReplyInThread, the parent message of the thread is updated with the
+// latest message and the occurrence count every time a reply is posted.
+func (s *SlackBot) SetUpdateParentMessage(enabled bool) {
+	s.updateParent = enabled
+}
+
+// SetSigningSecret sets the Slack App's signing secret to verify requests sent to InteractionHandler.
+//
+// When set, the default template includes Acknowledge, Mute, and Resolve buttons.
+func (s *SlackBot) SetSigningSecret(secret string) {
+	s.signingSecret = secret
+}
+
 // Name Returns the name of the Messenger.
 func (s SlackBot) Name() string {
 	if s.name == "" {
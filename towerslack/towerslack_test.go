@@ -0,0 +1,120 @@
+package towerslack
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tigorlazuardi/tower"
+	"github.com/tigorlazuardi/tower/cache"
+)
+
+type captureMessenger chan tower.MessageContext
+
+func (c captureMessenger) Name() string { return "capture" }
+
+func (c captureMessenger) SendMessage(_ context.Context, msg tower.MessageContext) { c <- msg }
+
+func (c captureMessenger) Wait(context.Context) error { return nil }
+
+// newTestMessage creates a message with the given key and level.
+func newTestMessage(t *testing.T, key string, level tower.Level) tower.MessageContext {
+	t.Helper()
+	tow := tower.NewTower(tower.Service{Name: "test", Environment: "test", Type: "test"})
+	capture := make(captureMessenger, 1)
+	tow.NewEntry("foo").Key(key).Level(level).Notify(context.Background(), tower.OnlyThisMessenger(capture))
+	select {
+	case msg := <-capture:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("message is not sent to messenger")
+		return nil
+	}
+}
+
+type slackRequest struct {
+	URL  string
+	Body []byte
+}
+
+// fakeClient records the requests sent to Slack and responds with the body returned by respond.
+type fakeClient struct {
+	mu       sync.Mutex
+	requests []slackRequest
+	respond  func(req *http.Request) (status int, body string)
+}
+
+func (f *fakeClient) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+	f.mu.Lock()
+	f.requests = append(f.requests, slackRequest{URL: req.URL.String(), Body: body})
+	f.mu.Unlock()
+	status, respBody := http.StatusOK, `{"ok":true,"channel":"C1","ts":"1000.0001"}`
+	if f.respond != nil {
+		status, respBody = f.respond(req)
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewBufferString(respBody)),
+		Request:    req,
+	}, nil
+}
+
+func (f *fakeClient) Requests() []slackRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]slackRequest(nil), f.requests...)
+}
+
+// ttlRecorder records the ttl of the last write of every key.
+type ttlRecorder struct {
+	cache.Cacher
+	mu   sync.Mutex
+	ttls map[string]time.Duration
+}
+
+func newTTLRecorder() *ttlRecorder {
+	return &ttlRecorder{Cacher: cache.NewLocalCache(), ttls: map[string]time.Duration{}}
+}
+
+func (r *ttlRecorder) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	r.mu.Lock()
+	r.ttls[key] = ttl
+	r.mu.Unlock()
+	return r.Cacher.Set(ctx, key, value, ttl)
+}
+
+func (r *ttlRecorder) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	r.mu.Lock()
+	r.ttls[key] = ttl
+	r.mu.Unlock()
+	return cache.Increment(ctx, r.Cacher, key, delta, ttl)
+}
+
+func (r *ttlRecorder) TTL(key string) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ttls[key]
+}
+
+// newTestBot creates a SlackBot that sends requests to the returned fakeClient.
+func newTestBot() (*SlackBot, *fakeClient) {
+	client := &fakeClient{}
+	bot := NewSlackBot("token", "C1")
+	bot.SetClient(client)
+	return bot, client
+}
+
+// handle handles the message synchronously and releases the global lock, so the next message is not delayed.
+func handle(bot *SlackBot, msg tower.MessageContext) {
+	bot.handleMessage(context.Background(), msg)
+	bot.cache.Delete(context.Background(), bot.globalKey)
+}
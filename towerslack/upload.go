@@ -79,15 +79,15 @@ func PostToThread(ctx context.Context, tower *tower.Tower, thread string) Upload
 	}
 }
 
-func (s SlackBot) uploadAttachments(ctx context.Context, msg tower.MessageContext, resp *slackrest.MessageResponse, attachments []bucket.File) {
+func (s SlackBot) uploadAttachments(ctx context.Context, msg tower.MessageContext, threadTS string, attachments []bucket.File) {
 	if s.bucket != nil {
-		s.uploadToBucket(ctx, msg, resp, attachments)
+		s.uploadToBucket(ctx, msg, threadTS, attachments)
 		return
 	}
-	s.uploadToSlack(ctx, msg, resp, attachments)
+	s.uploadToSlack(ctx, msg, threadTS, attachments)
 }
 
-func (s SlackBot) uploadToBucket(ctx context.Context, msg tower.MessageContext, resp *slackrest.MessageResponse, attachments []bucket.File) {
+func (s SlackBot) uploadToBucket(ctx context.Context, msg tower.MessageContext, threadTS string, attachments []bucket.File) {
 	results := s.bucket.Upload(ctx, attachments)
 	for _, result := range results {
 		if result.Error != nil {
@@ -97,9 +97,9 @@ func (s SlackBot) uploadToBucket(ctx context.Context, msg tower.MessageContext,
 	}
 }
 
-func (s SlackBot) uploadToSlack(ctx context.Context, msg tower.MessageContext, resp *slackrest.MessageResponse, attachments []bucket.File) {
+func (s SlackBot) uploadToSlack(ctx context.Context, msg tower.MessageContext, threadTS string, attachments []bucket.File) {
 	for _, attachment := range attachments {
-		key := PostToThread(ctx, msg.Tower(), resp.Ts)
+		key := PostToThread(ctx, msg.Tower(), threadTS)
 		value := attachment
 		item := tower.NewKeyValue(key, value)
 		s.fileQueue.Enqueue(item)